@@ -0,0 +1,98 @@
+// Package atomicx provides typed atomic values, for lock-free shared state
+// without the type assertions atomic.Value needs.
+package atomicx
+
+import "sync/atomic"
+
+// Value holds a value of type T that can be read and written atomically. The
+// zero value holds the zero value of T and is ready to use. A Value must not
+// be copied after first use.
+//
+// Values are stored behind a pointer, so T can be any type, including ones
+// that are too big to be written atomically on their own. Don't modify a
+// value after storing it, or after loading it, if it contains slices, maps or
+// pointers, since other goroutines may be reading it; store a modified copy
+// instead.
+//
+// Example usage:
+//
+//	var best atomicx.Value[Score]
+//	slice.ParMap(ctx, candidates, func(ctx context.Context, _ uint, c Candidate) (Score, error) {
+//		s := score(c)
+//		best.Update(func(cur Score) Score { return max(cur, s) })
+//		return s, nil
+//	})
+type Value[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewValue creates a Value that holds t
+func NewValue[T any](t T) *Value[T] {
+	v := &Value[T]{}
+	v.Store(t)
+	return v
+}
+
+// Load returns the value v holds
+func (v *Value[T]) Load() T {
+	if p := v.p.Load(); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Store sets the value v holds to t
+func (v *Value[T]) Store(t T) {
+	v.p.Store(&t)
+}
+
+// Swap sets the value v holds to t and returns the value it held before
+func (v *Value[T]) Swap(t T) T {
+	if p := v.p.Swap(&t); p != nil {
+		return *p
+	}
+	var zero T
+	return zero
+}
+
+// Update sets the value v holds to fn applied to it, and returns the new
+// value. If another goroutine changes v while fn is running, fn is called
+// again with the newer value, so fn may be called more than once and should
+// have no side effects
+func (v *Value[T]) Update(fn func(T) T) T {
+	for {
+		old := v.p.Load()
+		var cur T
+		if old != nil {
+			cur = *old
+		}
+		next := fn(cur)
+		if v.p.CompareAndSwap(old, &next) {
+			return next
+		}
+	}
+}
+
+// CompareAndSwap sets the value v holds to new and returns true if it's
+// currently equal to old, and returns false without changing it otherwise.
+//
+// CompareAndSwap is a function rather than a method because it needs T to be
+// comparable, and Value doesn't. Use Update for types that aren't comparable.
+func CompareAndSwap[T comparable](v *Value[T], old, new T) bool {
+	for {
+		p := v.p.Load()
+		var cur T
+		if p != nil {
+			cur = *p
+		}
+		if cur != old {
+			return false
+		}
+		if v.p.CompareAndSwap(p, &new) {
+			return true
+		}
+		// another goroutine stored a value between the Load and the swap, so
+		// check again against the newer value
+	}
+}
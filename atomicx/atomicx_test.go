@@ -0,0 +1,50 @@
+package atomicx
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValue(t *testing.T) {
+	r := require.New(t)
+	var v Value[[]int]
+	r.Nil(v.Load())
+	v.Store([]int{1})
+	r.Equal([]int{1}, v.Load())
+	r.Equal([]int{1}, v.Swap([]int{2}))
+	r.Equal([]int{2}, v.Load())
+
+	var empty Value[string]
+	r.Equal("", empty.Swap("a"))
+	r.Equal("a", NewValue("a").Load())
+}
+
+func TestCompareAndSwap(t *testing.T) {
+	r := require.New(t)
+	var v Value[int]
+	r.True(CompareAndSwap(&v, 0, 1))
+	r.False(CompareAndSwap(&v, 0, 2))
+	r.Equal(1, v.Load())
+	r.True(CompareAndSwap(&v, 1, 2))
+	r.Equal(2, v.Load())
+}
+
+func TestUpdate(t *testing.T) {
+	r := require.New(t)
+	var v Value[map[string]int]
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v.Update(func(m map[string]int) map[string]int {
+				next := map[string]int{"n": m["n"] + 1}
+				return next
+			})
+		}()
+	}
+	wg.Wait()
+	r.Equal(50, v.Load()["n"])
+}
@@ -0,0 +1,102 @@
+// Package backoff provides backoff schedules as lazy sequences of durations.
+//
+// A schedule is a plain iter.Seq[time.Duration], with one duration to wait
+// before each retry, so it can be ranged over in a retry loop, cut short with
+// a break, or passed to anything else that takes a sequence. Ranging over a
+// schedule again starts it from the beginning.
+//
+// Example usage:
+//
+//	attempt := 0
+//	for d := range backoff.Exponential(100*time.Millisecond, 2, 10*time.Second) {
+//		if err = call(ctx); err == nil || attempt == 5 {
+//			break
+//		}
+//		attempt++
+//		time.Sleep(d)
+//	}
+package backoff
+
+import (
+	"iter"
+	"math/rand/v2"
+	"time"
+)
+
+// Exponential returns an infinite schedule that starts at base and
+// multiplies by factor each time, up to max. Every duration after it first
+// reaches max is max. It panics if base <= 0, factor < 1 or max < base.
+//
+// Example usage:
+//
+//	// yields 100ms, 200ms, 400ms, 800ms, 1s, 1s, ...
+//	backoff.Exponential(100*time.Millisecond, 2, time.Second)
+func Exponential(base time.Duration, factor float64, max time.Duration) iter.Seq[time.Duration] {
+	checkBounds(base, max)
+	if factor < 1 {
+		panic("backoff: Exponential called with a factor less than 1")
+	}
+	return func(yield func(time.Duration) bool) {
+		// tracking the next duration as a float64 keeps it from overflowing
+		// before it's capped
+		next := float64(base)
+		for {
+			d := max
+			if next < float64(max) {
+				d = time.Duration(next)
+				next *= factor
+			}
+			if !yield(d) {
+				return
+			}
+		}
+	}
+}
+
+// DecorrelatedJitter returns an infinite schedule in which each duration is
+// picked at random between base and three times the previous one, capped at
+// max, starting from base. The randomness keeps many clients retrying
+// against the same server from doing it in lockstep, while still backing off
+// roughly exponentially.
+//
+// The durations are drawn from r, or from the global source in math/rand/v2
+// if r is nil. Passing a seeded r makes the schedule reproducible. Since a
+// *rand.Rand isn't safe for concurrent use, a schedule built with a non-nil r
+// mustn't be ranged over from more than one goroutine at once. It panics if
+// base <= 0 or max < base.
+//
+// Example usage:
+//
+//	for d := range backoff.DecorrelatedJitter(50*time.Millisecond, 5*time.Second, nil) {
+//		...
+//	}
+func DecorrelatedJitter(base, max time.Duration, r *rand.Rand) iter.Seq[time.Duration] {
+	checkBounds(base, max)
+	int64N := rand.Int64N
+	if r != nil {
+		int64N = r.Int64N
+	}
+	return func(yield func(time.Duration) bool) {
+		prev := base
+		for {
+			hi := max
+			if f := float64(prev) * 3; f < float64(max) {
+				hi = time.Duration(f)
+			}
+			d := base + time.Duration(int64N(int64(hi-base)+1))
+			if !yield(d) {
+				return
+			}
+			prev = d
+		}
+	}
+}
+
+func checkBounds(base, max time.Duration) {
+	if base <= 0 {
+		panic("backoff: called with a base <= 0")
+	}
+	if max < base {
+		panic("backoff: called with a max less than base")
+	}
+}
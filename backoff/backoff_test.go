@@ -0,0 +1,63 @@
+package backoff
+
+import (
+	"iter"
+	"math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func take(s iter.Seq[time.Duration], n int) []time.Duration {
+	var ret []time.Duration
+	for d := range s {
+		if len(ret) == n {
+			break
+		}
+		ret = append(ret, d)
+	}
+	return ret
+}
+
+func TestExponential(t *testing.T) {
+	r := require.New(t)
+	ms := time.Millisecond
+	s := Exponential(100*ms, 2, time.Second)
+	r.Equal([]time.Duration{100 * ms, 200 * ms, 400 * ms, 800 * ms, time.Second, time.Second}, take(s, 6))
+	// ranging again starts over
+	r.Equal([]time.Duration{100 * ms, 200 * ms}, take(s, 2))
+
+	r.Equal([]time.Duration{ms, ms, ms}, take(Exponential(ms, 1, time.Second), 3))
+
+	// a huge factor doesn't overflow past max
+	got := take(Exponential(ms, 1e12, time.Hour), 4)
+	r.Equal([]time.Duration{ms, time.Hour, time.Hour, time.Hour}, got)
+
+	r.Panics(func() { Exponential(0, 2, time.Second) })
+	r.Panics(func() { Exponential(ms, 0.5, time.Second) })
+	r.Panics(func() { Exponential(time.Second, 2, ms) })
+}
+
+func TestDecorrelatedJitter(t *testing.T) {
+	r := require.New(t)
+	base, max := 10*time.Millisecond, time.Second
+	s := DecorrelatedJitter(base, max, rand.New(rand.NewPCG(1, 2)))
+	got := take(s, 100)
+	r.Len(got, 100)
+	prev := base
+	for _, d := range got {
+		r.GreaterOrEqual(d, base)
+		r.LessOrEqual(d, max)
+		r.LessOrEqual(d, prev*3)
+		prev = d
+	}
+
+	// the same seed gives the same schedule
+	again := take(DecorrelatedJitter(base, max, rand.New(rand.NewPCG(1, 2))), 100)
+	r.Equal(got, again)
+
+	r.Len(take(DecorrelatedJitter(base, max, nil), 10), 10)
+	r.Equal([]time.Duration{base, base}, take(DecorrelatedJitter(base, base, nil), 2))
+	r.Panics(func() { DecorrelatedJitter(0, max, nil) })
+}
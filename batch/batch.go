@@ -0,0 +1,222 @@
+// Package batch groups individually submitted items into batches, for work
+// like bulk database writes and log shipping where handling items one at a
+// time is too slow.
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrClosed is returned by Submit after Close has been called
+var ErrClosed = errors.New("batch: processor is closed")
+
+// Option configures a Processor
+type Option func(*config)
+
+type config struct {
+	maxItems int
+	maxDelay time.Duration
+	workers  int
+	onError  func(error)
+}
+
+// MaxItems sets the largest number of items in a batch. A batch is flushed
+// as soon as it reaches this size. The default is 100
+func MaxItems(n int) Option {
+	return func(c *config) {
+		c.maxItems = max(n, 1)
+	}
+}
+
+// MaxDelay sets the longest an item can wait in a batch before the batch is
+// flushed, even if it hasn't reached MaxItems. The default is one second
+func MaxDelay(d time.Duration) Option {
+	return func(c *config) {
+		c.maxDelay = d
+	}
+}
+
+// Workers sets how many batches can be handled at the same time. The default
+// is 1, which means batches are handled one at a time, in the order they
+// were flushed
+func Workers(n int) Option {
+	return func(c *config) {
+		c.workers = max(n, 1)
+	}
+}
+
+// OnError sets a function that's called with every error the handler
+// returns. fn is called from the worker goroutines, so with more than one
+// worker it may be called concurrently. By default, errors are collected and
+// returned by Close
+func OnError(fn func(error)) Option {
+	return func(c *config) {
+		c.onError = fn
+	}
+}
+
+// Processor accumulates submitted items and passes them to a handler in
+// batches, flushing a batch when it reaches MaxItems or its oldest item has
+// waited MaxDelay, whichever comes first. It's safe for concurrent use.
+//
+// Example usage:
+//
+//	p := New(func(ctx context.Context, rows []Row) error {
+//		return db.BulkInsert(ctx, rows)
+//	}, MaxItems(500), MaxDelay(time.Second), Workers(4))
+//	for row := range rows {
+//		if err := p.Submit(ctx, row); err != nil {
+//			return err
+//		}
+//	}
+//	return p.Close(ctx)
+type Processor[T any] struct {
+	handler func(context.Context, []T) error
+	cfg     config
+
+	in      chan T
+	flushes chan []T
+	closing chan struct{}
+	done    chan struct{}
+	once    sync.Once
+
+	// ctx is passed to the handler, and is cancelled if Close gives up
+	// waiting for in-flight batches
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mut  sync.Mutex
+	errs []error
+}
+
+// New creates a Processor that passes batches to handler, and starts the
+// goroutines that run it. Call Close when you're done submitting items, to
+// flush what's left and stop those goroutines.
+func New[T any](handler func(context.Context, []T) error, opts ...Option) *Processor[T] {
+	cfg := config{maxItems: 100, maxDelay: time.Second, workers: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Processor[T]{
+		handler: handler,
+		cfg:     cfg,
+		in:      make(chan T),
+		flushes: make(chan []T),
+		closing: make(chan struct{}),
+		done:    make(chan struct{}),
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(cfg.workers)
+	for w := 0; w < cfg.workers; w++ {
+		go func() {
+			defer wg.Done()
+			for b := range p.flushes {
+				if err := p.handler(p.ctx, b); err != nil {
+					p.reportErr(err)
+				}
+			}
+		}()
+	}
+	go p.accumulate()
+	go func() {
+		wg.Wait()
+		close(p.done)
+	}()
+	return p
+}
+
+// Submit adds item to the current batch. It blocks if every worker is busy
+// and the current batch is full, which applies backpressure to callers
+// submitting faster than batches can be handled. Returns ctx's error if ctx
+// is done first, or ErrClosed if Close has been called
+func (p *Processor[T]) Submit(ctx context.Context, item T) error {
+	select {
+	case <-p.closing:
+		return ErrClosed
+	default:
+	}
+	select {
+	case p.in <- item:
+		return nil
+	case <-p.closing:
+		return ErrClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops accepting items, flushes the current batch, and waits for every
+// batch to be handled. It returns every error the handler returned, joined
+// with errors.Join, unless an OnError function was set.
+//
+// If ctx is done before every batch is handled, Close cancels the context
+// passed to the handler and returns ctx's error without waiting any longer.
+// Calling Close more than once is safe
+func (p *Processor[T]) Close(ctx context.Context) error {
+	p.once.Do(func() { close(p.closing) })
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		p.cancel()
+		return ctx.Err()
+	}
+	p.cancel()
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	return errors.Join(p.errs...)
+}
+
+// accumulate owns the current batch, and sends it to the workers when it's
+// full, when its timer fires or when the processor is closing
+func (p *Processor[T]) accumulate() {
+	var (
+		cur    []T
+		timer  = time.NewTimer(p.cfg.maxDelay)
+		timerC <-chan time.Time
+	)
+	timer.Stop()
+	flush := func() {
+		timer.Stop()
+		timerC = nil
+		if len(cur) > 0 {
+			p.flushes <- cur
+			cur = nil
+		}
+	}
+	for {
+		select {
+		case item := <-p.in:
+			cur = append(cur, item)
+			if len(cur) == 1 {
+				timer.Reset(p.cfg.maxDelay)
+				timerC = timer.C
+			}
+			if len(cur) >= p.cfg.maxItems {
+				flush()
+			}
+		case <-timerC:
+			flush()
+		case <-p.closing:
+			flush()
+			close(p.flushes)
+			return
+		}
+	}
+}
+
+func (p *Processor[T]) reportErr(err error) {
+	if p.cfg.onError != nil {
+		p.cfg.onError(err)
+		return
+	}
+	p.mut.Lock()
+	defer p.mut.Unlock()
+	p.errs = append(p.errs, err)
+}
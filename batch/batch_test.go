@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recorder struct {
+	mut     sync.Mutex
+	batches [][]int
+}
+
+func (r *recorder) handle(_ context.Context, b []int) error {
+	r.mut.Lock()
+	defer r.mut.Unlock()
+	r.batches = append(r.batches, b)
+	return nil
+}
+
+func TestMaxItems(t *testing.T) {
+	r := require.New(t)
+	rec := &recorder{}
+	p := New(rec.handle, MaxItems(2), MaxDelay(time.Hour))
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		r.NoError(p.Submit(ctx, i))
+	}
+	r.NoError(p.Close(ctx))
+	r.Equal([][]int{{0, 1}, {2, 3}, {4}}, rec.batches)
+	r.ErrorIs(p.Submit(ctx, 5), ErrClosed)
+	r.NoError(p.Close(ctx))
+}
+
+func TestMaxDelay(t *testing.T) {
+	r := require.New(t)
+	rec := &recorder{}
+	p := New(rec.handle, MaxItems(100), MaxDelay(10*time.Millisecond))
+	ctx := context.Background()
+	r.NoError(p.Submit(ctx, 1))
+	r.Eventually(func() bool {
+		rec.mut.Lock()
+		defer rec.mut.Unlock()
+		return len(rec.batches) == 1
+	}, time.Second, time.Millisecond)
+	r.NoError(p.Close(ctx))
+	r.Equal([][]int{{1}}, rec.batches)
+}
+
+func TestErrors(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	p := New(func(context.Context, []int) error { return boom }, MaxItems(1), Workers(3))
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		r.NoError(p.Submit(ctx, i))
+	}
+	err := p.Close(ctx)
+	r.ErrorIs(err, boom)
+}
+
+func TestCloseTimeout(t *testing.T) {
+	r := require.New(t)
+	p := New(func(ctx context.Context, _ []int) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	r.NoError(p.Submit(context.Background(), 1))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	r.ErrorIs(p.Close(ctx), context.DeadlineExceeded)
+}
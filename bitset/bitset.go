@@ -0,0 +1,165 @@
+// Package bitset provides a growable set of non-negative integers, stored
+// as one bit per integer.
+//
+// For dense integers, like indices into a slice, a BitSet takes a small
+// fraction of the memory of a map[uint]struct{}, and its set operations work
+// on 64 members at a time.
+package bitset
+
+import (
+	"iter"
+	"math/bits"
+)
+
+const wordBits = 64
+
+// BitSet is a set of non-negative integers. It grows as needed when members
+// are added, so the zero value is an empty set ready to use. A BitSet isn't
+// safe for concurrent use if any goroutine is modifying it.
+//
+// Example usage:
+//
+//	var seen bitset.BitSet
+//	for _, id := range ids {
+//		if seen.Test(id) {
+//			continue
+//		}
+//		seen.Set(id)
+//		...
+//	}
+type BitSet struct {
+	words []uint64
+}
+
+// New returns an empty BitSet with room for the integers from 0 up to, but
+// not including, capacity before it needs to grow
+func New(capacity uint) *BitSet {
+	return &BitSet{words: make([]uint64, 0, (capacity+wordBits-1)/wordBits)}
+}
+
+// Set adds i to b
+func (b *BitSet) Set(i uint) {
+	w := i / wordBits
+	if w >= uint(len(b.words)) {
+		b.words = append(b.words, make([]uint64, w+1-uint(len(b.words)))...)
+	}
+	b.words[w] |= 1 << (i % wordBits)
+}
+
+// Clear removes i from b, if it's there
+func (b *BitSet) Clear(i uint) {
+	if w := i / wordBits; w < uint(len(b.words)) {
+		b.words[w] &^= 1 << (i % wordBits)
+	}
+}
+
+// Test returns true if i is in b
+func (b *BitSet) Test(i uint) bool {
+	w := i / wordBits
+	return w < uint(len(b.words)) && b.words[w]&(1<<(i%wordBits)) != 0
+}
+
+// Count returns how many integers are in b
+func (b *BitSet) Count() int {
+	n := 0
+	for _, w := range b.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// NextSet returns the smallest integer in b that's at least from, and true,
+// or 0 and false if there isn't one.
+//
+// Example usage:
+//
+//	for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+//		...
+//	}
+func (b *BitSet) NextSet(from uint) (uint, bool) {
+	w := from / wordBits
+	if w >= uint(len(b.words)) {
+		return 0, false
+	}
+	// mask off the bits below from in its own word
+	word := b.words[w] &^ (1<<(from%wordBits) - 1)
+	for {
+		if word != 0 {
+			return w*wordBits + uint(bits.TrailingZeros64(word)), true
+		}
+		w++
+		if w >= uint(len(b.words)) {
+			return 0, false
+		}
+		word = b.words[w]
+	}
+}
+
+// All returns a sequence of the integers in b, in increasing order. b
+// mustn't be modified while the sequence is being ranged over
+func (b *BitSet) All() iter.Seq[uint] {
+	return func(yield func(uint) bool) {
+		for i, ok := b.NextSet(0); ok; i, ok = b.NextSet(i + 1) {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a copy of b
+func (b *BitSet) Clone() *BitSet {
+	return &BitSet{words: append([]uint64(nil), b.words...)}
+}
+
+// And returns a new BitSet holding the integers that are in both b and other
+func (b *BitSet) And(other *BitSet) *BitSet {
+	n := min(len(b.words), len(other.words))
+	ret := &BitSet{words: make([]uint64, n)}
+	for i := range n {
+		ret.words[i] = b.words[i] & other.words[i]
+	}
+	return ret
+}
+
+// Or returns a new BitSet holding the integers that are in b, other or both
+func (b *BitSet) Or(other *BitSet) *BitSet {
+	long, short := b.words, other.words
+	if len(short) > len(long) {
+		long, short = short, long
+	}
+	ret := &BitSet{words: append([]uint64(nil), long...)}
+	for i, w := range short {
+		ret.words[i] |= w
+	}
+	return ret
+}
+
+// AndNot returns a new BitSet holding the integers that are in b but not in
+// other
+func (b *BitSet) AndNot(other *BitSet) *BitSet {
+	ret := b.Clone()
+	for i := range min(len(ret.words), len(other.words)) {
+		ret.words[i] &^= other.words[i]
+	}
+	return ret
+}
+
+// Equal returns true if b and other hold the same integers, regardless of
+// their capacity
+func (b *BitSet) Equal(other *BitSet) bool {
+	long, short := b.words, other.words
+	if len(short) > len(long) {
+		long, short = short, long
+	}
+	for i, w := range long {
+		var o uint64
+		if i < len(short) {
+			o = short[i]
+		}
+		if w != o {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+package bitset
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func from(is ...uint) *BitSet {
+	var b BitSet
+	for _, i := range is {
+		b.Set(i)
+	}
+	return &b
+}
+
+func TestBitSet(t *testing.T) {
+	r := require.New(t)
+	var b BitSet
+	r.False(b.Test(0))
+	r.Zero(b.Count())
+	_, ok := b.NextSet(0)
+	r.False(ok)
+
+	for _, i := range []uint{0, 5, 63, 64, 200} {
+		b.Set(i)
+	}
+	b.Set(5)
+	r.True(b.Test(63))
+	r.True(b.Test(64))
+	r.False(b.Test(65))
+	r.False(b.Test(100_000))
+	r.Equal(5, b.Count())
+	r.Equal([]uint{0, 5, 63, 64, 200}, slices.Collect(b.All()))
+
+	next, ok := b.NextSet(6)
+	r.True(ok)
+	r.Equal(uint(63), next)
+	next, ok = b.NextSet(65)
+	r.True(ok)
+	r.Equal(uint(200), next)
+	_, ok = b.NextSet(201)
+	r.False(ok)
+
+	b.Clear(63)
+	b.Clear(100_000)
+	r.False(b.Test(63))
+	r.Equal(4, b.Count())
+
+	c := New(1000)
+	r.Zero(c.Count())
+	c.Set(999)
+	r.True(c.Test(999))
+}
+
+func TestBitSetOps(t *testing.T) {
+	r := require.New(t)
+	a := from(1, 2, 3, 100)
+	b := from(2, 3, 4)
+	r.Equal([]uint{2, 3}, slices.Collect(a.And(b).All()))
+	r.Equal([]uint{1, 2, 3, 4, 100}, slices.Collect(a.Or(b).All()))
+	r.Equal([]uint{1, 100}, slices.Collect(a.AndNot(b).All()))
+	r.Equal([]uint{4}, slices.Collect(b.AndNot(a).All()))
+	// the inputs aren't modified
+	r.Equal([]uint{1, 2, 3, 100}, slices.Collect(a.All()))
+
+	r.True(from(1, 2).Equal(from(2, 1)))
+	big := from(1, 500)
+	big.Clear(500)
+	r.True(big.Equal(from(1)))
+	r.False(from(1).Equal(from(1, 2)))
+
+	// random sets behave the same as maps
+	rng := rand.New(rand.NewPCG(3, 3))
+	m := map[uint]bool{}
+	var rb BitSet
+	for range 2000 {
+		i := uint(rng.IntN(1000))
+		if rng.IntN(3) == 0 {
+			rb.Clear(i)
+			delete(m, i)
+		} else {
+			rb.Set(i)
+			m[i] = true
+		}
+	}
+	r.Equal(len(m), rb.Count())
+	for i := range uint(1000) {
+		r.Equal(m[i], rb.Test(i))
+	}
+}
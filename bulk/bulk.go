@@ -0,0 +1,113 @@
+// Package bulk allocates many small values out of a few large blocks, and
+// frees them all at once, to cut the allocations, and the work for the
+// garbage collector, of pipelines that build lots of short-lived slices.
+package bulk
+
+import "sync"
+
+// Slab hands out storage for values of type T, carved from blocks that each
+// hold a fixed number of elements. Everything a Slab has handed out is freed
+// together, with Reset or Free, rather than one value at a time. It's safe
+// for concurrent use.
+//
+// A block stays alive as long as any slice or pointer into it does, so a Slab
+// suits values that all live about as long as each other, like the
+// intermediate results of one batch.
+//
+// Example usage, building the rows of each batch out of one slab:
+//
+//	slab := bulk.Alloc[Field](64 * 1024)
+//	for batch := range batches {
+//		rows, err := slice.ParMap(ctx, batch, func(_ context.Context, _ uint, line string) ([]Field, error) {
+//			return parseInto(slab.Make(numFields), line)
+//		})
+//		if err != nil {
+//			return err
+//		}
+//		write(rows)
+//		// nothing from this batch is used after here, so reuse its blocks
+//		slab.Reset()
+//	}
+type Slab[T any] struct {
+	mut       sync.Mutex
+	blockSize int
+	// blocks are the full-size blocks the slab has allocated, and used is how
+	// many of them have been handed out from, so far. blocks[used-1] is the
+	// one being carved up, and the ones after it are reused after a Reset
+	blocks [][]T
+	used   int
+	// off is how many elements of blocks[used-1] have been handed out
+	off int
+}
+
+// Alloc creates a Slab that allocates blocks of n elements at a time. It
+// panics if n <= 0
+func Alloc[T any](n int) *Slab[T] {
+	if n <= 0 {
+		panic("bulk: Alloc called with n <= 0")
+	}
+	return &Slab[T]{blockSize: n}
+}
+
+// Make returns a slice of n zero values of T, with a capacity of n, so
+// appending to it never overwrites storage handed out to someone else.
+// Requests bigger than the slab's block size get a block of their own, that
+// isn't reused. Make panics if n < 0
+func (s *Slab[T]) Make(n int) []T {
+	if n < 0 {
+		panic("bulk: Make called with n < 0")
+	}
+	if n > s.blockSize {
+		return make([]T, n)
+	}
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.used == 0 || s.off+n > s.blockSize {
+		if s.used == len(s.blocks) {
+			s.blocks = append(s.blocks, make([]T, s.blockSize))
+		}
+		s.used++
+		s.off = 0
+	}
+	block := s.blocks[s.used-1]
+	ret := block[s.off : s.off+n : s.off+n]
+	s.off += n
+	return ret
+}
+
+// New returns a pointer to a new zero value of T
+func (s *Slab[T]) New() *T {
+	return &s.Make(1)[0]
+}
+
+// Reset makes all of the slab's blocks available to hand out again, so the
+// next batch of values reuses their storage instead of allocating. Every
+// slice and pointer the slab has handed out so far must no longer be used,
+// since their storage will be zeroed and handed out again
+func (s *Slab[T]) Reset() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	for _, block := range s.blocks[:s.used] {
+		clear(block)
+	}
+	s.used, s.off = 0, 0
+}
+
+// Free drops the slab's blocks, so the garbage collector can reclaim them
+// once nothing handed out from them is still in use. Unlike after Reset,
+// values handed out before Free stay valid. The slab can still be used after
+// Free, and allocates new blocks as needed
+func (s *Slab[T]) Free() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.blocks = nil
+	s.used, s.off = 0, 0
+}
+
+// Blocks returns how many blocks the slab holds, including ones that are
+// waiting to be reused after a Reset
+func (s *Slab[T]) Blocks() int {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	return len(s.blocks)
+}
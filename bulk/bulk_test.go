@@ -0,0 +1,74 @@
+package bulk
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlab(t *testing.T) {
+	r := require.New(t)
+	r.Panics(func() { Alloc[int](0) })
+	s := Alloc[int](4)
+	r.Panics(func() { s.Make(-1) })
+
+	a := s.Make(3)
+	r.Equal([]int{0, 0, 0}, a)
+	r.Equal(3, cap(a))
+	b := s.Make(1)
+	// appending to a copies rather than overwriting b
+	a = append(a, 9)
+	r.Equal([]int{0}, b)
+	r.Equal(1, s.Blocks())
+
+	// doesn't fit in what's left of the block, so starts a new one
+	c := s.Make(2)
+	r.Equal(2, s.Blocks())
+	p := s.New()
+	*p = 5
+	// too big for a block, so it gets its own
+	r.Len(s.Make(10), 10)
+	r.Equal(2, s.Blocks())
+
+	c[0], c[1] = 1, 2
+	s.Reset()
+	r.Equal(2, s.Blocks())
+	// the blocks are zeroed and handed out again
+	d := s.Make(4)
+	r.Equal([]int{0, 0, 0, 0}, d)
+	e := s.Make(4)
+	r.Equal([]int{0, 0, 0, 0}, e)
+	r.Equal(2, s.Blocks())
+
+	d[0] = 7
+	s.Free()
+	r.Equal(0, s.Blocks())
+	r.Equal(7, d[0])
+	r.Equal([]int{0}, s.Make(1))
+	r.Equal(1, s.Blocks())
+}
+
+func TestSlabConcurrent(t *testing.T) {
+	r := require.New(t)
+	s := Alloc[int](100)
+	var wg sync.WaitGroup
+	out := make([][]int, 50)
+	for i := range out {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			slc := s.Make(7)
+			for j := range slc {
+				slc[j] = i
+			}
+			out[i] = slc
+		}()
+	}
+	wg.Wait()
+	// nothing handed out overlaps, so every slice kept its own values
+	for i, slc := range out {
+		r.Equal([]int{i, i, i, i, i, i, i}, slc)
+	}
+	r.Equal(4, s.Blocks())
+}
@@ -0,0 +1,131 @@
+// Package chans provides channel adapters that stop cleanly when their
+// context is done, so the goroutines and timers behind them never leak.
+//
+// Every channel returned by this package is closed once its context is done,
+// so it can be ranged over without a separate select on ctx.Done().
+package chans
+
+import (
+	"context"
+	"time"
+)
+
+// Tick returns a channel that receives the current time every d, until ctx
+// is done, when it's closed and the underlying ticker is stopped. Like
+// time.Ticker, it holds at most one pending tick, and drops ticks for a slow
+// receiver rather than letting them pile up. It panics if d <= 0.
+//
+// Example usage:
+//
+//	for now := range chans.Tick(ctx, time.Second) {
+//		report(now)
+//	}
+func Tick(ctx context.Context, d time.Duration) <-chan time.Time {
+	if d <= 0 {
+		panic("chans: Tick called with a non-positive duration")
+	}
+	out := make(chan time.Time, 1)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				select {
+				case out <- now:
+				default:
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
+
+// After returns a channel that receives the current time once d has passed,
+// and is then closed. If ctx is done first, the channel is closed without
+// receiving anything and the underlying timer is stopped, so a receive on it
+// can tell the two apart by its second value.
+//
+// Example usage:
+//
+//	select {
+//	case <-results:
+//	case _, ok := <-chans.After(ctx, 5*time.Second):
+//		if ok {
+//			return errTimeout
+//		}
+//		return ctx.Err()
+//	}
+func After(ctx context.Context, d time.Duration) <-chan time.Time {
+	out := make(chan time.Time, 1)
+	go func() {
+		defer close(out)
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case now := <-timer.C:
+			out <- now
+		case <-ctx.Done():
+		}
+	}()
+	return out
+}
+
+// Sample returns a channel that receives, every period of length every, the
+// most recent value received from in during that period. Periods in which
+// nothing arrived are skipped, and values that were superseded within a
+// period are dropped. When in is closed, the value from the last, partial
+// period, if any, is sent before the returned channel is closed. It's also
+// closed when ctx is done. It panics if every <= 0.
+//
+// Example usage, reporting a fast-changing gauge at most once a second:
+//
+//	for v := range chans.Sample(ctx, queueDepths, time.Second) {
+//		metrics.Gauge("queue.depth", v)
+//	}
+func Sample[T any](ctx context.Context, in <-chan T, every time.Duration) <-chan T {
+	if every <= 0 {
+		panic("chans: Sample called with a non-positive period")
+	}
+	out := make(chan T)
+	go func() {
+		defer close(out)
+		ticker := time.NewTicker(every)
+		defer ticker.Stop()
+		var (
+			latest T
+			have   bool
+		)
+		send := func() bool {
+			select {
+			case out <- latest:
+				have = false
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+		for {
+			select {
+			case t, ok := <-in:
+				if !ok {
+					if have {
+						send()
+					}
+					return
+				}
+				latest, have = t, true
+			case <-ticker.C:
+				if have && !send() {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,66 @@
+package chans
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTick(t *testing.T) {
+	r := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Tick(ctx, time.Millisecond)
+	for range 3 {
+		_, ok := <-ch
+		r.True(ok)
+	}
+	cancel()
+	// drain a pending tick, if any, then the channel is closed
+	for range ch {
+	}
+	r.Panics(func() { Tick(ctx, 0) })
+}
+
+func TestAfter(t *testing.T) {
+	r := require.New(t)
+	_, ok := <-After(context.Background(), time.Millisecond)
+	r.True(ok)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := After(ctx, time.Hour)
+	cancel()
+	_, ok = <-ch
+	r.False(ok)
+}
+
+func TestSample(t *testing.T) {
+	r := require.New(t)
+	in := make(chan int)
+	out := Sample(context.Background(), in, 10*time.Millisecond)
+	in <- 7
+	// the value comes out on the next tick, while in is still open
+	r.Equal(7, <-out)
+
+	for i := range 5 {
+		in <- i
+	}
+	close(in)
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	// depending on where the ticks fell, earlier values may or may not have
+	// been sampled, but the last one always is
+	r.NotEmpty(got)
+	r.Equal(4, got[len(got)-1])
+	r.IsIncreasing(got)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	never := make(chan int)
+	out = Sample(ctx, never, time.Millisecond)
+	cancel()
+	_, ok := <-out
+	r.False(ok)
+}
@@ -0,0 +1,151 @@
+// Package checkpoint runs batch jobs that record their progress as they go,
+// so a job that's interrupted, by a crash or a redeploy, can be run again and
+// pick up where it left off instead of starting over.
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/go-functional/core/par"
+)
+
+// Checkpointer persists the keys of the elements a job has finished.
+// Implementations must be safe to call from multiple goroutines, though Run
+// never calls Save concurrently with itself
+type Checkpointer[K comparable] interface {
+	// Load returns every key saved so far, or nothing if the job hasn't
+	// saved any progress yet
+	Load(ctx context.Context) ([]K, error)
+	// Save records done as every key finished so far. done always includes
+	// everything Load returned, so Save can replace what was saved before
+	Save(ctx context.Context, done []K) error
+}
+
+// DefaultEvery is how many elements Run finishes between saves, if Every
+// isn't passed
+const DefaultEvery = 100
+
+// Option configures Run
+type Option func(*config)
+
+type config struct {
+	every   int
+	parOpts []par.Option
+}
+
+// Every makes Run save its progress each time another n elements have
+// finished. Progress is always saved once more when Run returns. n <= 0 is
+// treated as 1.
+//
+// Every save passes all the keys finished so far, so a job of m elements
+// copies and saves O(m²/n) keys in total. Saves happen outside the lock the
+// workers share, so a slow save holds up only the worker making it, but for
+// big jobs n should still grow with m
+func Every(n int) Option {
+	return func(c *config) {
+		c.every = max(n, 1)
+	}
+}
+
+// Par passes opts, like par.Limit, through to par.Do
+func Par(opts ...par.Option) Option {
+	return func(c *config) {
+		c.parOpts = append(c.parOpts, opts...)
+	}
+}
+
+// Run calls fn, in parallel, with every element of slc whose key, from
+// keyFn, isn't among the keys cp.Load returns. As elements finish, their keys
+// are saved with cp, so calling Run again after it's interrupted skips the
+// ones that already finished. Keys must be unique, and stable between runs.
+//
+// Like par.Do, the first error from fn cancels the context passed to the
+// other calls, and Run returns it. Either way, the progress made before Run
+// returns is saved, so a failed run can be resumed too.
+//
+// Example usage, resuming an import after a restart:
+//
+//	cp := checkpoint.File[string]("import.checkpoint")
+//	err := checkpoint.Run(ctx, rows, func(_ uint, r Row) string {
+//		return r.ID
+//	}, func(ctx context.Context, _ uint, r Row) error {
+//		return db.Insert(ctx, r)
+//	}, cp, checkpoint.Every(500), checkpoint.Par(par.Limit(8)))
+func Run[T any, K comparable](
+	ctx context.Context,
+	slc []T,
+	keyFn func(uint, T) K,
+	fn func(context.Context, uint, T) error,
+	cp Checkpointer[K],
+	opts ...Option,
+) error {
+	cfg := config{every: DefaultEvery}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	done, err := cp.Load(ctx)
+	if err != nil {
+		return err
+	}
+	skip := make(map[K]struct{}, len(done))
+	for _, k := range done {
+		skip[k] = struct{}{}
+	}
+	var pending []int
+	for i, t := range slc {
+		if _, ok := skip[keyFn(uint(i), t)]; !ok {
+			pending = append(pending, i)
+		}
+	}
+
+	// mut guards done and snapped, the length of done when it was last
+	// copied to be saved
+	var mut sync.Mutex
+	snapped := len(done)
+	// saveMut is held while saving, so saves happen one at a time, and
+	// guards saved, the length of done when it was last saved. done only
+	// grows, so a copy no longer than saved is already out of date
+	var saveMut sync.Mutex
+	saved := len(done)
+	save := func(ctx context.Context, snap []K) error {
+		saveMut.Lock()
+		defer saveMut.Unlock()
+		if len(snap) <= saved {
+			return nil
+		}
+		if err := cp.Save(ctx, snap); err != nil {
+			return err
+		}
+		saved = len(snap)
+		return nil
+	}
+	runErr := par.Do(ctx, len(pending), func(ctx context.Context, p int) error {
+		i := pending[p]
+		if err := fn(ctx, uint(i), slc[i]); err != nil {
+			return err
+		}
+		mut.Lock()
+		done = append(done, keyFn(uint(i), slc[i]))
+		if len(done)-snapped < cfg.every {
+			mut.Unlock()
+			return nil
+		}
+		snap := slices.Clone(done)
+		snapped = len(done)
+		mut.Unlock()
+		return save(ctx, snap)
+	}, cfg.parOpts...)
+
+	// every worker has returned, so nothing else touches done now
+	saveMut.Lock()
+	unsaved := saved < len(done)
+	saveMut.Unlock()
+	if !unsaved {
+		return runErr
+	}
+	// save even if ctx is done, so the progress made so far isn't lost
+	return errors.Join(runErr, save(context.WithoutCancel(ctx), done))
+}
@@ -0,0 +1,133 @@
+package checkpoint
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+// countingCheckpointer counts calls to Save on top of a Memory
+type countingCheckpointer struct {
+	Memory[int]
+	saves int
+}
+
+func (c *countingCheckpointer) Save(ctx context.Context, done []int) error {
+	c.saves++
+	return c.Memory.Save(ctx, done)
+}
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	in := []int{10, 11, 12, 13, 14, 15, 16, 17}
+	key := func(_ uint, n int) int { return n }
+	var cp countingCheckpointer
+
+	// the first run fails on 14, after some of the elements have finished
+	boom := errors.New("boom")
+	var mut sync.Mutex
+	var first []int
+	err := Run(ctx, in, key, func(_ context.Context, _ uint, n int) error {
+		if n == 14 {
+			return boom
+		}
+		mut.Lock()
+		defer mut.Unlock()
+		first = append(first, n)
+		return nil
+	}, &cp, Every(2), Par(par.Limit(1)))
+	r.ErrorIs(err, boom)
+	saved, err := cp.Load(ctx)
+	r.NoError(err)
+	r.ElementsMatch(first, saved)
+	r.Equal([]int{10, 11, 12, 13}, first)
+	r.Equal(2, cp.saves)
+
+	// the second run only processes what the first didn't finish
+	var second []int
+	err = Run(ctx, in, key, func(_ context.Context, _ uint, n int) error {
+		mut.Lock()
+		defer mut.Unlock()
+		second = append(second, n)
+		return nil
+	}, &cp, Every(3))
+	r.NoError(err)
+	r.ElementsMatch([]int{14, 15, 16, 17}, second)
+	saved, err = cp.Load(ctx)
+	r.NoError(err)
+	r.ElementsMatch(in, saved)
+	// one save after three elements, and a final one for the last element
+	r.Equal(4, cp.saves)
+
+	// a finished job has nothing left to do, and nothing to save
+	err = Run(ctx, in, key, func(context.Context, uint, int) error {
+		panic("unreachable")
+	}, &cp)
+	r.NoError(err)
+	r.Equal(4, cp.saves)
+}
+
+// lenCheckpointer records how many keys each call to Save passed
+type lenCheckpointer struct {
+	Memory[int]
+	lens []int
+}
+
+func (l *lenCheckpointer) Save(ctx context.Context, done []int) error {
+	l.lens = append(l.lens, len(done))
+	return l.Memory.Save(ctx, done)
+}
+
+func TestRunSavesInOrder(t *testing.T) {
+	r := require.New(t)
+	in := make([]int, 200)
+	for i := range in {
+		in[i] = i
+	}
+	var cp lenCheckpointer
+	err := Run(context.Background(), in, func(_ uint, n int) int { return n },
+		func(context.Context, uint, int) error { return nil }, &cp, Every(1))
+	r.NoError(err)
+	// saves happen outside the workers' lock, but a save never replaces a
+	// newer one with an older one
+	r.NotEmpty(cp.lens)
+	r.IsIncreasing(cp.lens)
+	r.Equal(len(in), cp.lens[len(cp.lens)-1])
+}
+
+type failingCheckpointer struct {
+	loadErr, saveErr error
+}
+
+func (f failingCheckpointer) Load(context.Context) ([]string, error) {
+	return nil, f.loadErr
+}
+
+func (f failingCheckpointer) Save(context.Context, []string) error {
+	return f.saveErr
+}
+
+func TestRunCheckpointErrors(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	in := []string{"a", "b", "c"}
+	key := func(_ uint, s string) string { return s }
+	ok := func(context.Context, uint, string) error { return nil }
+
+	loadErr := errors.New("load")
+	err := Run(ctx, in, key, func(context.Context, uint, string) error {
+		panic("unreachable")
+	}, failingCheckpointer{loadErr: loadErr})
+	r.ErrorIs(err, loadErr)
+
+	saveErr := errors.New("save")
+	err = Run(ctx, in, key, ok, failingCheckpointer{saveErr: saveErr}, Every(1))
+	r.ErrorIs(err, saveErr)
+	err = Run(ctx, in, key, ok, failingCheckpointer{saveErr: saveErr})
+	r.ErrorIs(err, saveErr)
+}
@@ -0,0 +1,97 @@
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// Memory is a Checkpointer that keeps the saved keys in memory. It doesn't
+// survive a crash, so it's mostly useful for tests, and for retrying a job
+// within the same process. The zero value is ready to use
+type Memory[K comparable] struct {
+	mut  sync.Mutex
+	done []K
+}
+
+// Load returns a copy of the keys last saved
+func (m *Memory[K]) Load(context.Context) ([]K, error) {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return slices.Clone(m.done), nil
+}
+
+// Save replaces the saved keys with a copy of done
+func (m *Memory[K]) Save(_ context.Context, done []K) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.done = slices.Clone(done)
+	return nil
+}
+
+// File returns a Checkpointer that saves keys to the file at path, as JSON.
+// Each save writes a temporary file in the same directory and renames it over
+// path, so a crash partway through a save leaves the previous checkpoint
+// intact. That means every save rewrites and syncs every key, so its cost
+// grows with the job; see Every. Load returns no keys if path doesn't exist
+// yet.
+//
+// Example usage:
+//
+//	cp := checkpoint.File[int]("/var/lib/jobs/reindex.json")
+func File[K comparable](path string) Checkpointer[K] {
+	return &file[K]{path: path}
+}
+
+type file[K comparable] struct {
+	mut  sync.Mutex
+	path string
+}
+
+func (f *file[K]) Load(context.Context) ([]K, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	data, err := os.ReadFile(f.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var done []K
+	if err := json.Unmarshal(data, &done); err != nil {
+		return nil, err
+	}
+	return done, nil
+}
+
+func (f *file[K]) Save(_ context.Context, done []K) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	data, err := json.Marshal(done)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.path), filepath.Base(f.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path)
+}
@@ -0,0 +1,53 @@
+package checkpoint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var m Memory[string]
+	done, err := m.Load(ctx)
+	r.NoError(err)
+	r.Empty(done)
+
+	keys := []string{"a", "b"}
+	r.NoError(m.Save(ctx, keys))
+	keys[0] = "changed"
+	done, err = m.Load(ctx)
+	r.NoError(err)
+	r.Equal([]string{"a", "b"}, done)
+}
+
+func TestFile(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "job.json")
+	cp := File[int](path)
+	done, err := cp.Load(ctx)
+	r.NoError(err)
+	r.Empty(done)
+
+	r.NoError(cp.Save(ctx, []int{3, 1}))
+	r.NoError(cp.Save(ctx, []int{3, 1, 2}))
+	done, err = File[int](path).Load(ctx)
+	r.NoError(err)
+	r.Equal([]int{3, 1, 2}, done)
+	// the temporary files are cleaned up
+	entries, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Len(entries, 1)
+
+	r.NoError(os.WriteFile(path, []byte("not json"), 0o600))
+	_, err = cp.Load(ctx)
+	r.Error(err)
+
+	r.Error(File[int](filepath.Join(dir, "missing", "job.json")).Save(ctx, nil))
+}
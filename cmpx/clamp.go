@@ -0,0 +1,21 @@
+package cmpx
+
+import "cmp"
+
+// Clamp returns v if it's between lo and hi, inclusive, lo if it's less than
+// lo, and hi if it's greater than hi. It panics if lo is greater than hi.
+//
+// Example usage, keeping a computed index in bounds:
+//
+//	i := Clamp(guess, 0, len(slc)-1)
+func Clamp[T cmp.Ordered](v, lo, hi T) T {
+	if lo > hi {
+		panic("cmpx: Clamp called with lo greater than hi")
+	}
+	return min(max(v, lo), hi)
+}
+
+// InRange returns true if v is between lo and hi, inclusive
+func InRange[T cmp.Ordered](v, lo, hi T) bool {
+	return lo <= v && v <= hi
+}
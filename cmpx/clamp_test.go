@@ -0,0 +1,22 @@
+package cmpx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClamp(t *testing.T) {
+	r := require.New(t)
+	r.Equal(5, Clamp(5, 0, 10))
+	r.Equal(0, Clamp(-3, 0, 10))
+	r.Equal(10, Clamp(30, 0, 10))
+	r.Equal(1.5, Clamp(2.0, 0, 1.5))
+	r.Equal("m", Clamp("z", "a", "m"))
+	r.Panics(func() { Clamp(1, 2, 1) })
+
+	r.True(InRange(0, 0, 10))
+	r.True(InRange(10, 0, 10))
+	r.False(InRange(11, 0, 10))
+	r.False(InRange(5, 10, 0))
+}
@@ -0,0 +1,88 @@
+// Package cmpx provides combinators for building comparison functions.
+//
+// Every Comparator is a plain func(a, b T) int with the same contract as
+// cmp.Compare, so it can be passed straight to slices.SortFunc,
+// slices.BinarySearchFunc and friends.
+package cmpx
+
+import "cmp"
+
+// Comparator returns a negative number if a sorts before b, a positive number
+// if a sorts after b, and 0 if they're equivalent
+type Comparator[T any] func(a, b T) int
+
+// Natural returns a Comparator that uses the natural ordering of T
+func Natural[T cmp.Ordered]() Comparator[T] {
+	return cmp.Compare[T]
+}
+
+// By returns a Comparator that orders values by the natural ordering of the
+// key that key extracts from them.
+//
+// Example usage, sorting people by last name and then by first name:
+//
+//	byName := By(func(p Person) string { return p.Last }).
+//		ThenBy(By(func(p Person) string { return p.First }))
+//	slices.SortFunc(people, byName)
+func By[T any, K cmp.Ordered](key func(T) K) Comparator[T] {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ThenBy returns a Comparator that orders values by c and breaks ties with
+// next
+func (c Comparator[T]) ThenBy(next Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if res := c(a, b); res != 0 {
+			return res
+		}
+		return next(a, b)
+	}
+}
+
+// Reversed returns a Comparator with the opposite ordering of c
+func (c Comparator[T]) Reversed() Comparator[T] {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Less adapts c to the less function that sort.Slice and container types
+// often expect
+func (c Comparator[T]) Less(a, b T) bool {
+	return c(a, b) < 0
+}
+
+// NilsLast returns a Comparator for pointers that orders nil after every
+// non-nil pointer, and compares non-nil pointers with c on the values they
+// point to
+func NilsLast[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return 1
+		case b == nil:
+			return -1
+		}
+		return c(*a, *b)
+	}
+}
+
+// NilsFirst is the same as NilsLast, except it orders nil before every non-nil
+// pointer
+func NilsFirst[T any](c Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		switch {
+		case a == nil && b == nil:
+			return 0
+		case a == nil:
+			return -1
+		case b == nil:
+			return 1
+		}
+		return c(*a, *b)
+	}
+}
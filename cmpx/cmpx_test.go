@@ -0,0 +1,48 @@
+package cmpx
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type person struct {
+	first, last string
+	age         int
+}
+
+func TestByThenByReversed(t *testing.T) {
+	r := require.New(t)
+	people := []person{
+		{"b", "smith", 30},
+		{"a", "smith", 40},
+		{"c", "jones", 20},
+	}
+	byName := By(func(p person) string { return p.last }).
+		ThenBy(By(func(p person) string { return p.first }))
+	slices.SortFunc(people, byName)
+	r.Equal([]string{"c", "a", "b"}, firsts(people))
+
+	slices.SortFunc(people, byName.Reversed())
+	r.Equal([]string{"b", "a", "c"}, firsts(people))
+	r.True(byName.Less(people[2], people[0]))
+}
+
+func TestNils(t *testing.T) {
+	r := require.New(t)
+	one, two := 1, 2
+	ptrs := []*int{nil, &two, &one}
+	slices.SortFunc(ptrs, NilsLast(Natural[int]()))
+	r.Equal([]*int{&one, &two, nil}, ptrs)
+	slices.SortFunc(ptrs, NilsFirst(Natural[int]()).ThenBy(func(a, b *int) int { return 0 }))
+	r.Equal([]*int{nil, &one, &two}, ptrs)
+}
+
+func firsts(ps []person) []string {
+	ret := make([]string, len(ps))
+	for i, p := range ps {
+		ret[i] = p.first
+	}
+	return ret
+}
@@ -0,0 +1,88 @@
+// Package codec combines decoding raw messages with transforming them, for
+// consumers of message queues and other sources of encoded bytes.
+package codec
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-functional/core/par"
+	"github.com/go-functional/core/slice"
+)
+
+// MessageError is the error MapDecode and ParMapDecode return when a message
+// can't be decoded or transformed. Index says which message it was, so it
+// can be retried or sent to a dead-letter queue
+type MessageError struct {
+	// Index is the index of the message in the input
+	Index int
+	// Decoding is true if the message couldn't be decoded, and false if it
+	// was decoded but couldn't be transformed
+	Decoding bool
+	// Err is the error decode or fn returned
+	Err error
+}
+
+func (e *MessageError) Error() string {
+	if e.Decoding {
+		return fmt.Sprintf("codec: decoding message %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("codec: transforming message %d: %v", e.Index, e.Err)
+}
+
+func (e *MessageError) Unwrap() error {
+	return e.Err
+}
+
+// MapDecode decodes every element of raws with decode, then transforms the
+// result with fn, and returns the transformed values in order. It stops at
+// the first failure and returns nil for the slice and a *MessageError.
+//
+// Example usage:
+//
+//	orders, err := codec.MapDecode(batch, func(b []byte) (Order, error) {
+//		var o Order
+//		return o, json.Unmarshal(b, &o)
+//	}, validate)
+func MapDecode[T, U any](
+	raws [][]byte,
+	decode func([]byte) (T, error),
+	fn func(T) (U, error),
+) ([]U, error) {
+	return slice.Map(raws, func(i uint, raw []byte) (U, error) {
+		return decodeOne(int(i), raw, decode, func(t T) (U, error) { return fn(t) })
+	})
+}
+
+// ParMapDecode is the same as MapDecode, except decoding and transforming
+// each message runs in parallel using slice.ParMap. fn is passed a context
+// that's cancelled once any message fails. opts are passed to ParMap
+func ParMapDecode[T, U any](
+	ctx context.Context,
+	raws [][]byte,
+	decode func([]byte) (T, error),
+	fn func(context.Context, T) (U, error),
+	opts ...par.Option,
+) ([]U, error) {
+	return slice.ParMap(ctx, raws, func(ctx context.Context, i uint, raw []byte) (U, error) {
+		return decodeOne(int(i), raw, decode, func(t T) (U, error) { return fn(ctx, t) })
+	}, opts...)
+}
+
+func decodeOne[T, U any](
+	i int,
+	raw []byte,
+	decode func([]byte) (T, error),
+	fn func(T) (U, error),
+) (U, error) {
+	var zero U
+	t, err := decode(raw)
+	if err != nil {
+		return zero, &MessageError{Index: i, Decoding: true, Err: err}
+	}
+	u, err := fn(t)
+	if err != nil {
+		return zero, &MessageError{Index: i, Err: err}
+	}
+	return u, nil
+}
@@ -0,0 +1,72 @@
+package codec
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+type msg struct {
+	N int `json:"n"`
+}
+
+func decodeMsg(b []byte) (msg, error) {
+	var m msg
+	return m, json.Unmarshal(b, &m)
+}
+
+var errOdd = errors.New("odd")
+
+func evenOnly(m msg) (int, error) {
+	if m.N%2 != 0 {
+		return 0, errOdd
+	}
+	return m.N, nil
+}
+
+func TestMapDecode(t *testing.T) {
+	r := require.New(t)
+	raws := [][]byte{[]byte(`{"n":2}`), []byte(`{"n":4}`)}
+	got, err := MapDecode(raws, decodeMsg, evenOnly)
+	r.NoError(err)
+	r.Equal([]int{2, 4}, got)
+
+	_, err = MapDecode(append(raws, []byte(`{"n":3}`)), decodeMsg, evenOnly)
+	var me *MessageError
+	r.ErrorAs(err, &me)
+	r.Equal(2, me.Index)
+	r.False(me.Decoding)
+	r.ErrorIs(err, errOdd)
+	r.Equal("codec: transforming message 2: odd", err.Error())
+
+	got, err = MapDecode(append([][]byte{[]byte(`{`)}, raws...), decodeMsg, evenOnly)
+	r.Nil(got)
+	r.ErrorAs(err, &me)
+	r.Equal(0, me.Index)
+	r.True(me.Decoding)
+}
+
+func TestParMapDecode(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var raws [][]byte
+	for i := 0; i < 100; i++ {
+		b, _ := json.Marshal(msg{N: i * 2})
+		raws = append(raws, b)
+	}
+	fn := func(_ context.Context, m msg) (int, error) { return evenOnly(m) }
+	got, err := ParMapDecode(ctx, raws, decodeMsg, fn, par.Limit(4))
+	r.NoError(err)
+	r.Len(got, 100)
+	r.Equal(198, got[99])
+
+	raws[50] = []byte(`{"n":1}`)
+	_, err = ParMapDecode(ctx, raws, decodeMsg, fn)
+	var me *MessageError
+	r.ErrorAs(err, &me)
+	r.Equal(50, me.Index)
+}
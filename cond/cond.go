@@ -0,0 +1,92 @@
+// Package cond provides conditional expressions, so picking between values
+// doesn't require declaring a variable and assigning to it in an if/else
+// block.
+package cond
+
+// If returns a if pred is true and b otherwise. Both a and b are evaluated
+// before If is called. Use IfFunc if that's expensive or has side effects.
+//
+// Example usage:
+//
+//	workers := If(cfg.Workers > 0, cfg.Workers, runtime.NumCPU())
+func If[T any](pred bool, a, b T) T {
+	if pred {
+		return a
+	}
+	return b
+}
+
+// IfFunc is the lazy version of If. It calls and returns the result of a if
+// pred is true, and b otherwise. Only one of a or b is called
+func IfFunc[T any](pred bool, a, b func() T) T {
+	if pred {
+		return a()
+	}
+	return b()
+}
+
+// Coalesce returns the first value in vals that isn't the zero value of T,
+// or the zero value if they all are.
+//
+// Example usage:
+//
+//	addr := Coalesce(os.Getenv("ADDR"), cfg.Addr, ":8080")
+func Coalesce[T comparable](vals ...T) T {
+	var zero T
+	for _, v := range vals {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// SwitchExpr is a switch statement that evaluates to a value. Create one with
+// Switch, add cases with Case and When, and get the result with Default.
+// Cases are checked in the order they were added, and the first match wins.
+type SwitchExpr[T comparable, R any] struct {
+	val     T
+	matched bool
+	result  R
+}
+
+// Switch starts a switch expression on val. R is the type of the result, and
+// usually has to be given explicitly.
+//
+// Example usage:
+//
+//	name := Switch[int, string](code).
+//		Case(200, "ok").
+//		Case(404, "not found").
+//		When(func(c int) bool { return c >= 500 }, "server error").
+//		Default("unknown")
+func Switch[T comparable, R any](val T) *SwitchExpr[T, R] {
+	return &SwitchExpr[T, R]{val: val}
+}
+
+// Case makes the switch evaluate to result if its value equals match and no
+// earlier case matched
+func (s *SwitchExpr[T, R]) Case(match T, result R) *SwitchExpr[T, R] {
+	if !s.matched && s.val == match {
+		s.matched, s.result = true, result
+	}
+	return s
+}
+
+// When makes the switch evaluate to result if pred returns true for its value
+// and no earlier case matched. pred isn't called once a case has matched
+func (s *SwitchExpr[T, R]) When(pred func(T) bool, result R) *SwitchExpr[T, R] {
+	if !s.matched && pred(s.val) {
+		s.matched, s.result = true, result
+	}
+	return s
+}
+
+// Default returns the result of the first matching case, or result if no case
+// matched
+func (s *SwitchExpr[T, R]) Default(result R) R {
+	if s.matched {
+		return s.result
+	}
+	return result
+}
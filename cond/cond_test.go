@@ -0,0 +1,44 @@
+package cond
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIf(t *testing.T) {
+	r := require.New(t)
+	r.Equal(1, If(true, 1, 2))
+	r.Equal(2, If(false, 1, 2))
+
+	called := false
+	res := IfFunc(true, func() string { return "a" }, func() string {
+		called = true
+		return "b"
+	})
+	r.Equal("a", res)
+	r.False(called)
+}
+
+func TestCoalesce(t *testing.T) {
+	r := require.New(t)
+	r.Equal("b", Coalesce("", "b", "c"))
+	r.Equal(0, Coalesce(0, 0))
+	r.Equal("", Coalesce[string]())
+}
+
+func TestSwitch(t *testing.T) {
+	r := require.New(t)
+	name := func(code int) string {
+		return Switch[int, string](code).
+			Case(200, "ok").
+			Case(404, "not found").
+			When(func(c int) bool { return c >= 500 }, "server error").
+			Case(503, "unreachable").
+			Default("unknown")
+	}
+	r.Equal("ok", name(200))
+	r.Equal("not found", name(404))
+	r.Equal("server error", name(503))
+	r.Equal("unknown", name(302))
+}
@@ -0,0 +1,28 @@
+// Package constraints defines type constraints for the numeric helpers
+// throughout this repository.
+package constraints
+
+// Signed matches every signed integer type
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned matches every unsigned integer type
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Integer matches every integer type
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Float matches every floating point type
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number matches every integer and floating point type
+type Number interface {
+	Integer | Float
+}
@@ -0,0 +1,114 @@
+// Package cow provides copy-on-write collections, for data that's read far
+// more often than it's written, like a list of backends every worker reads
+// while a background goroutine occasionally refreshes it.
+package cow
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Slice is a slice that can be read by any number of goroutines without
+// locks, while others modify it. Every write copies the current contents,
+// changes the copy and swaps it in atomically, so a reader always sees a
+// complete version, never one that's partway through a write. Writes are
+// serialized with a mutex, so they don't lose each other's changes. The zero
+// value is an empty Slice, ready to use. A Slice must not be copied after
+// first use.
+//
+// Since every write copies the whole slice, a Slice suits data that's written
+// rarely, and is small enough to copy.
+//
+// Example usage:
+//
+//	var backends cow.Slice[string]
+//	backends.Append("10.0.0.1", "10.0.0.2")
+//	slice.ParMap(ctx, requests, func(ctx context.Context, i uint, req Request) (Response, error) {
+//		snap := backends.Snapshot()
+//		return send(ctx, snap[int(i)%len(snap)], req)
+//	})
+type Slice[T any] struct {
+	// mut serializes writers. Readers never take it
+	mut sync.Mutex
+	p   atomic.Pointer[[]T]
+}
+
+// New creates a Slice holding a copy of items
+func New[T any](items ...T) *Slice[T] {
+	s := &Slice[T]{}
+	s.store(slices.Clone(items))
+	return s
+}
+
+// store swaps in items as the current version. items must not be modified
+// after, so it's clipped to make sure appending to it copies
+func (s *Slice[T]) store(items []T) {
+	items = slices.Clip(items)
+	s.p.Store(&items)
+}
+
+// Snapshot returns the current contents of s. The returned slice is shared
+// with other readers, so it must not be modified; later writes to s don't
+// change it
+func (s *Slice[T]) Snapshot() []T {
+	if p := s.p.Load(); p != nil {
+		return *p
+	}
+	return nil
+}
+
+// Len returns the current length of s
+func (s *Slice[T]) Len() int {
+	return len(s.Snapshot())
+}
+
+// Get returns the element at index i. It panics if i is out of range
+func (s *Slice[T]) Get(i int) T {
+	return s.Snapshot()[i]
+}
+
+// Range returns a sequence of the indices and elements of s, as they were
+// when iteration started. Writes made during iteration aren't seen
+func (s *Slice[T]) Range() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, t := range s.Snapshot() {
+			if !yield(i, t) {
+				return
+			}
+		}
+	}
+}
+
+// Append adds items to the end of s
+func (s *Slice[T]) Append(items ...T) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	// Snapshot is always clipped, so this allocates a new backing array
+	s.store(append(s.Snapshot(), items...))
+}
+
+// Set replaces the element at index i with t. It panics if i is out of range
+func (s *Slice[T]) Set(i int, t T) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	next := slices.Clone(s.Snapshot())
+	next[i] = t
+	s.store(next)
+}
+
+// Update replaces the contents of s with the result of calling fn on a copy
+// of them, so fn is free to modify its argument. Other writers wait until fn
+// returns.
+//
+// Example usage, removing an element:
+//
+//	backends.Update(func(bs []string) []string {
+//		return slices.DeleteFunc(bs, func(b string) bool { return b == down })
+//	})
+func (s *Slice[T]) Update(fn func([]T) []T) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.store(fn(slices.Clone(s.Snapshot())))
+}
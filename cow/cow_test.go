@@ -0,0 +1,85 @@
+package cow
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlice(t *testing.T) {
+	r := require.New(t)
+	var s Slice[int]
+	r.Nil(s.Snapshot())
+	r.Equal(0, s.Len())
+
+	s.Append(1, 2)
+	before := s.Snapshot()
+	s.Append(3)
+	s.Set(0, 10)
+	r.Equal([]int{1, 2}, before)
+	r.Equal([]int{10, 2, 3}, s.Snapshot())
+	r.Equal(3, s.Len())
+	r.Equal(2, s.Get(1))
+	r.Panics(func() { s.Set(3, 0) })
+	r.Panics(func() { s.Get(3) })
+
+	var got []int
+	for i, n := range s.Range() {
+		if i == 0 {
+			// writes during iteration aren't seen
+			s.Append(4)
+		}
+		got = append(got, n)
+	}
+	r.Equal([]int{10, 2, 3}, got)
+
+	s.Update(func(ns []int) []int {
+		return slices.DeleteFunc(ns, func(n int) bool { return n%2 == 0 })
+	})
+	r.Equal([]int{3}, s.Snapshot())
+
+	items := []string{"a"}
+	n := New(items...)
+	items[0] = "changed"
+	r.Equal([]string{"a"}, n.Snapshot())
+}
+
+func TestSliceConcurrent(t *testing.T) {
+	r := require.New(t)
+	var s Slice[int]
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				s.Append(i*100 + j)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// appends only ever add to the end, so every snapshot extends
+			// the one before it
+			var prev []int
+			for range 100 {
+				snap := s.Snapshot()
+				r.True(slices.Equal(prev, snap[:len(prev)]))
+				prev = snap
+			}
+		}()
+	}
+	wg.Wait()
+	r.Equal(800, s.Len())
+	sorted := slices.Sorted(func(yield func(int) bool) {
+		for _, n := range s.Range() {
+			if !yield(n) {
+				return
+			}
+		}
+	})
+	for i, n := range sorted {
+		r.Equal(i, n)
+	}
+}
@@ -0,0 +1,226 @@
+// Package csvx bridges encoding/csv and the iterator and parallel helpers in
+// this repository, for ETL-style work over CSV files that are too big to
+// load at once.
+package csvx
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/go-functional/core/par"
+)
+
+// Option configures how CSV is read and written
+type Option func(*config)
+
+type config struct {
+	comma   rune
+	workers int
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{comma: ','}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Comma sets the field delimiter, for both reading and writing. The default
+// is ','
+func Comma(r rune) Option {
+	return func(c *config) {
+		c.comma = r
+	}
+}
+
+// Parallel makes MapRecords call fn from up to workers goroutines at once.
+// Records are read in chunks, each chunk is mapped in parallel, and the
+// results are written in the same order as the input. If fn fails, the
+// results for the records before the failing one are still written, the
+// same as without Parallel.
+//
+// Decoding itself stays sequential, on one goroutine: a quoted field can
+// contain newlines, so where a record starts can't be found without parsing
+// everything before it. Parallel pays off when fn is the expensive part. It
+// has no effect on Records. By default, MapRecords calls fn on one record at
+// a time
+func Parallel(workers int) Option {
+	return func(c *config) {
+		c.workers = workers
+	}
+}
+
+// chunkPerWorker is how many records MapRecords reads per worker before
+// mapping them in parallel. It's big enough that goroutine overhead doesn't
+// dominate, and small enough that a chunk doesn't take much memory
+const chunkPerWorker = 64
+
+func newReader(r io.Reader, cfg config) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = cfg.comma
+	return cr
+}
+
+// Records returns a sequence of the records in r. Records are read lazily,
+// as the sequence is consumed, so only one is held in memory at a time. If
+// reading a record fails, the sequence yields a nil record with the error,
+// and then ends.
+//
+// Example usage:
+//
+//	for rec, err := range csvx.Records(f) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Println(rec[0])
+//	}
+func Records(r io.Reader, opts ...Option) iter.Seq2[[]string, error] {
+	cfg := newConfig(opts)
+	return func(yield func([]string, error) bool) {
+		cr := newReader(r, cfg)
+		for {
+			rec, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(rec, nil) {
+				return
+			}
+		}
+	}
+}
+
+// MapRecords reads every record in r, calls fn with it, and writes the
+// record fn returns to w. If fn returns a nil record, nothing is written for
+// that input, so fn can filter as well as transform.
+//
+// MapRecords stops and returns the error if fn returns one, if reading r or
+// writing w fails, or if ctx is done, in which case it returns ctx.Err().
+// Pass Parallel to call fn concurrently; results are still written in input
+// order.
+//
+// Example usage, dropping the second column:
+//
+//	err := csvx.MapRecords(ctx, in, func(rec []string) ([]string, error) {
+//		return append(rec[:1], rec[2:]...), nil
+//	}, out, csvx.Parallel(4))
+func MapRecords(
+	ctx context.Context,
+	r io.Reader,
+	fn func([]string) ([]string, error),
+	w io.Writer,
+	opts ...Option,
+) error {
+	cfg := newConfig(opts)
+	cw := csv.NewWriter(w)
+	cw.Comma = cfg.comma
+	write := func(rec []string) error {
+		if rec == nil {
+			return nil
+		}
+		return cw.Write(rec)
+	}
+
+	var err error
+	if cfg.workers > 1 {
+		err = mapChunks(ctx, newReader(r, cfg), fn, write, cfg.workers)
+	} else {
+		err = mapEach(ctx, r, fn, write, opts)
+	}
+	cw.Flush()
+	if err != nil {
+		return err
+	}
+	return cw.Error()
+}
+
+func mapEach(
+	ctx context.Context,
+	r io.Reader,
+	fn func([]string) ([]string, error),
+	write func([]string) error,
+	opts []Option,
+) error {
+	for rec, err := range Records(r, opts...) {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		out, err := fn(rec)
+		if err != nil {
+			return err
+		}
+		if err := write(out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func mapChunks(
+	ctx context.Context,
+	cr *csv.Reader,
+	fn func([]string) ([]string, error),
+	write func([]string) error,
+	workers int,
+) error {
+	chunk := make([][]string, 0, workers*chunkPerWorker)
+	out := make([][]string, workers*chunkPerWorker)
+	// every call writes only its own index, so these need no locking
+	fnErrs := make([]error, len(out))
+	done := make([]bool, len(out))
+	for {
+		chunk = chunk[:0]
+		clear(fnErrs)
+		clear(done)
+		var readErr error
+		for len(chunk) < cap(chunk) {
+			rec, err := cr.Read()
+			if err != nil {
+				readErr = err
+				break
+			}
+			chunk = append(chunk, rec)
+		}
+
+		// a failing call doesn't cancel the rest of the chunk, so every
+		// record before the first failure has its result, and gets written,
+		// just as it would be in sequential mode
+		doErr := par.Do(ctx, len(chunk), func(_ context.Context, i int) error {
+			out[i], fnErrs[i] = fn(chunk[i])
+			done[i] = true
+			return nil
+		}, par.Limit(workers))
+		for i, rec := range out[:len(chunk)] {
+			if !done[i] {
+				return doErr
+			}
+			if fnErrs[i] != nil {
+				return fnErrs[i]
+			}
+			if err := write(rec); err != nil {
+				return err
+			}
+		}
+		if doErr != nil {
+			return doErr
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package csvx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecords(t *testing.T) {
+	r := require.New(t)
+	var got [][]string
+	for rec, err := range Records(strings.NewReader("a,b\n\"c,d\",e\n")) {
+		r.NoError(err)
+		got = append(got, rec)
+	}
+	r.Equal([][]string{{"a", "b"}, {"c,d", "e"}}, got)
+
+	got = nil
+	for rec, err := range Records(strings.NewReader("a;b\n"), Comma(';')) {
+		r.NoError(err)
+		got = append(got, rec)
+	}
+	r.Equal([][]string{{"a", "b"}}, got)
+
+	var errs []error
+	for _, err := range Records(strings.NewReader("a,b\nc\n")) {
+		errs = append(errs, err)
+	}
+	r.Len(errs, 2)
+	r.NoError(errs[0])
+	r.Error(errs[1])
+}
+
+func TestMapRecords(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	swap := func(rec []string) ([]string, error) {
+		if rec[0] == "skip" {
+			return nil, nil
+		}
+		return []string{rec[1], rec[0]}, nil
+	}
+	var out strings.Builder
+	r.NoError(MapRecords(ctx, strings.NewReader("a,b\nskip,x\nc,d\n"), swap, &out))
+	r.Equal("b,a\nd,c\n", out.String())
+
+	var in, want strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&in, "%d,x\n", i)
+		fmt.Fprintf(&want, "x,%d\n", i)
+	}
+	out.Reset()
+	r.NoError(MapRecords(ctx, strings.NewReader(in.String()), swap, &out, Parallel(4)))
+	r.Equal(want.String(), out.String())
+
+	errBad := errors.New("bad")
+	for _, opts := range [][]Option{nil, {Parallel(3)}} {
+		out.Reset()
+		err := MapRecords(ctx, strings.NewReader("a,b\nc,d\nbad,x\ne,f\nbad,y\n"), func(rec []string) ([]string, error) {
+			if rec[0] == "bad" {
+				return nil, errBad
+			}
+			return rec, nil
+		}, &out, opts...)
+		r.ErrorIs(err, errBad)
+		// both modes write the records before the first failure, and no more
+		r.Equal("a,b\nc,d\n", out.String())
+
+		err = MapRecords(ctx, strings.NewReader("a,b\nc\n"), swap, &out, opts...)
+		r.Error(err)
+	}
+}
@@ -0,0 +1,319 @@
+// Package dag runs a graph of named tasks, where each task runs as soon as
+// every task it depends on has finished, and can read their results.
+//
+// It generalizes slice.ParMap from a flat list of independent calls to work
+// with dependencies between the calls, like a build or a data pipeline whose
+// steps need the output of earlier steps.
+package dag
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"sync"
+	"time"
+
+	"github.com/go-functional/core/graph"
+)
+
+// Option configures a call to Graph.Run
+type Option func(*config)
+
+type config struct {
+	limit int
+}
+
+// Limit caps the number of tasks that run at the same time at n. n <= 0 means
+// no limit, which is the default
+func Limit(n int) Option {
+	return func(c *config) {
+		c.limit = n
+	}
+}
+
+// TaskOption configures a single task, in a call to Add
+type TaskOption func(*taskConfig)
+
+type taskConfig struct {
+	deps    []string
+	retries int
+	delays  iter.Seq[time.Duration]
+}
+
+// DependsOn declares that the task depends on the tasks with the given
+// names, so it only runs once they've all succeeded, and it can read their
+// results
+func DependsOn(names ...string) TaskOption {
+	return func(c *taskConfig) {
+		c.deps = append(c.deps, names...)
+	}
+}
+
+// Retry retries the task up to n more times if it returns an error. Before
+// each retry, it waits for the next delay in delays, such as a schedule from
+// the backoff package. If delays is nil or runs out, retries happen right
+// away. Retrying stops early if the context is done.
+//
+// Example usage:
+//
+//	dag.Retry(3, backoff.Exponential(100*time.Millisecond, 2, 5*time.Second))
+func Retry(n int, delays iter.Seq[time.Duration]) TaskOption {
+	return func(c *taskConfig) {
+		c.retries = n
+		c.delays = delays
+	}
+}
+
+// TaskError is the error Run returns when a task fails. It holds the name of
+// the task and the error it returned on its last attempt
+type TaskError struct {
+	Name string
+	Err  error
+}
+
+func (e *TaskError) Error() string {
+	return fmt.Sprintf("dag: task %q failed: %v", e.Name, e.Err)
+}
+
+func (e *TaskError) Unwrap() error {
+	return e.Err
+}
+
+type task struct {
+	name string
+	cfg  taskConfig
+	run  func(context.Context, *Results) (any, error)
+}
+
+// Graph is a set of tasks and the dependencies between them. The zero value
+// is an empty graph, ready to use. A Graph must not be modified while Run is
+// executing
+type Graph struct {
+	tasks []*task
+	index map[string]*task
+}
+
+// Task is a handle to a task in a Graph, whose result is a T. Use it to
+// declare dependencies with its Name, and to read its result with Get
+type Task[T any] struct {
+	name string
+}
+
+// Name returns the name of the task
+func (t Task[T]) Name() string {
+	return t.name
+}
+
+// Get returns the result of the task from r. Inside a task, it panics if the
+// calling task doesn't depend on t, since t might not have finished yet.
+// After Run, it returns the zero value of T if t didn't run
+func (t Task[T]) Get(r *Results) T {
+	v, _ := r.get(t.name).(T)
+	return v
+}
+
+// Add adds a task called name to g, which runs fn and whose result is a T.
+// fn is passed a Results that its dependencies' results can be read from,
+// using their Task handles. Add panics if g already has a task called name.
+//
+// Example usage:
+//
+//	var g dag.Graph
+//	users := dag.Add(&g, "users", func(ctx context.Context, _ *dag.Results) ([]User, error) {
+//		return fetchUsers(ctx)
+//	})
+//	orders := dag.Add(&g, "orders", func(ctx context.Context, _ *dag.Results) ([]Order, error) {
+//		return fetchOrders(ctx)
+//	})
+//	report := dag.Add(&g, "report", func(ctx context.Context, r *dag.Results) (Report, error) {
+//		return buildReport(users.Get(r), orders.Get(r)), nil
+//	}, dag.DependsOn(users.Name(), orders.Name()))
+//	res, err := g.Run(ctx, dag.Limit(4))
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(report.Get(res))
+func Add[T any](
+	g *Graph,
+	name string,
+	fn func(context.Context, *Results) (T, error),
+	opts ...TaskOption,
+) Task[T] {
+	if _, ok := g.index[name]; ok {
+		panic(fmt.Sprintf("dag: task %q added twice", name))
+	}
+	t := &task{
+		name: name,
+		run: func(ctx context.Context, r *Results) (any, error) {
+			return fn(ctx, r)
+		},
+	}
+	for _, opt := range opts {
+		opt(&t.cfg)
+	}
+	if g.index == nil {
+		g.index = map[string]*task{}
+	}
+	g.tasks = append(g.tasks, t)
+	g.index[name] = t
+	return Task[T]{name: name}
+}
+
+// Results holds the results of the tasks in a Graph. It's safe to read from
+// multiple goroutines
+type Results struct {
+	store *store
+	// allowed is the set of tasks the reader may read, or nil if it may read
+	// any of them
+	allowed map[string]bool
+	reader  string
+}
+
+type store struct {
+	mut  sync.RWMutex
+	vals map[string]any
+}
+
+func (r *Results) get(name string) any {
+	if r.allowed != nil && !r.allowed[name] {
+		panic(fmt.Sprintf("dag: task %q read the result of %q, which it doesn't depend on", r.reader, name))
+	}
+	r.store.mut.RLock()
+	defer r.store.mut.RUnlock()
+	return r.store.vals[name]
+}
+
+func (s *store) set(name string, v any) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	s.vals[name] = v
+}
+
+// Run runs every task in g, starting each one as soon as all of its
+// dependencies have succeeded. It returns the results of every task, or an
+// error if a dependency names a task that doesn't exist, the dependencies
+// form a cycle (a *graph.CycleError[string]), or a task fails (a *TaskError).
+//
+// The first task to fail, after its retries, cancels the context passed to
+// the others, and no more tasks are started. Run waits for the ones already
+// running to return before it does. If ctx is done before every task has
+// started, Run returns ctx.Err().
+func (g *Graph) Run(ctx context.Context, opts ...Option) (*Results, error) {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	for _, t := range g.tasks {
+		for _, dep := range t.cfg.deps {
+			if _, ok := g.index[dep]; !ok {
+				return nil, fmt.Errorf("dag: task %q depends on unknown task %q", t.name, dep)
+			}
+		}
+	}
+	names := make([]string, len(g.tasks))
+	for i, t := range g.tasks {
+		names[i] = t.name
+	}
+	if _, err := graph.TopoSort(names, func(name string) []string {
+		return g.index[name].cfg.deps
+	}); err != nil {
+		return nil, err
+	}
+
+	st := &store{vals: make(map[string]any, len(g.tasks))}
+	remaining := make(map[string]int, len(g.tasks))
+	dependents := map[string][]*task{}
+	var ready []*task
+	for _, t := range g.tasks {
+		remaining[t.name] = len(t.cfg.deps)
+		for _, dep := range t.cfg.deps {
+			dependents[dep] = append(dependents[dep], t)
+		}
+		if len(t.cfg.deps) == 0 {
+			ready = append(ready, t)
+		}
+	}
+
+	type result struct {
+		t   *task
+		val any
+		err error
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	done := make(chan result)
+	running, finished := 0, 0
+	var firstErr error
+	for finished < len(g.tasks) {
+		for firstErr == nil && len(ready) > 0 && (cfg.limit <= 0 || running < cfg.limit) {
+			if err := ctx.Err(); err != nil {
+				firstErr = err
+				break
+			}
+			t := ready[0]
+			ready = ready[1:]
+			running++
+			go func() {
+				val, err := runTask(ctx, t, st)
+				done <- result{t: t, val: val, err: err}
+			}()
+		}
+		if running == 0 {
+			// something failed, and everything that was running has returned
+			break
+		}
+		res := <-done
+		running--
+		finished++
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = &TaskError{Name: res.t.name, Err: res.err}
+				cancel()
+			}
+			continue
+		}
+		st.set(res.t.name, res.val)
+		for _, d := range dependents[res.t.name] {
+			remaining[d.name]--
+			if remaining[d.name] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return &Results{store: st}, nil
+}
+
+// runTask calls t, retrying it as its TaskOptions say
+func runTask(ctx context.Context, t *task, st *store) (any, error) {
+	view := &Results{store: st, allowed: make(map[string]bool, len(t.cfg.deps)), reader: t.name}
+	for _, dep := range t.cfg.deps {
+		view.allowed[dep] = true
+	}
+	var next func() (time.Duration, bool)
+	if t.cfg.delays != nil {
+		var stop func()
+		next, stop = iter.Pull(t.cfg.delays)
+		defer stop()
+	}
+	for attempt := 0; ; attempt++ {
+		val, err := t.run(ctx, view)
+		if err == nil || attempt >= t.cfg.retries || ctx.Err() != nil {
+			return val, err
+		}
+		if next == nil {
+			continue
+		}
+		if d, ok := next(); ok {
+			timer := time.NewTimer(d)
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return val, err
+			case <-timer.C:
+			}
+		}
+	}
+}
@@ -0,0 +1,187 @@
+package dag
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-functional/core/graph"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+	var g Graph
+	var mut sync.Mutex
+	var order []string
+	record := func(name string) {
+		mut.Lock()
+		defer mut.Unlock()
+		order = append(order, name)
+	}
+	a := Add(&g, "a", func(context.Context, *Results) (int, error) {
+		record("a")
+		return 2, nil
+	})
+	b := Add(&g, "b", func(context.Context, *Results) (string, error) {
+		record("b")
+		return "3", nil
+	})
+	c := Add(&g, "c", func(_ context.Context, res *Results) (int, error) {
+		record("c")
+		n, err := strconv.Atoi(b.Get(res))
+		return a.Get(res) * n, err
+	}, DependsOn(a.Name(), b.Name()))
+	d := Add(&g, "d", func(_ context.Context, res *Results) (int, error) {
+		record("d")
+		return c.Get(res) + 1, nil
+	}, DependsOn(c.Name()))
+
+	for _, opts := range [][]Option{nil, {Limit(1)}} {
+		order = nil
+		res, err := g.Run(context.Background(), opts...)
+		r.NoError(err)
+		r.Equal(6, c.Get(res))
+		r.Equal(7, d.Get(res))
+		r.Equal("3", b.Get(res))
+		r.ElementsMatch([]string{"a", "b"}, order[:2])
+		r.Equal([]string{"c", "d"}, order[2:])
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	r := require.New(t)
+	var g Graph
+	// the two roots only finish once both have started, so this deadlocks
+	// unless they run at the same time
+	var started sync.WaitGroup
+	started.Add(2)
+	for _, name := range []string{"a", "b"} {
+		Add(&g, name, func(context.Context, *Results) (struct{}, error) {
+			started.Done()
+			started.Wait()
+			return struct{}{}, nil
+		})
+	}
+	_, err := g.Run(context.Background())
+	r.NoError(err)
+}
+
+func TestRunLimit(t *testing.T) {
+	r := require.New(t)
+	var g Graph
+	var running, peak atomic.Int32
+	for i := range 10 {
+		Add(&g, strconv.Itoa(i), func(context.Context, *Results) (int, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+			return i, nil
+		})
+	}
+	_, err := g.Run(context.Background(), Limit(3))
+	r.NoError(err)
+	r.LessOrEqual(peak.Load(), int32(3))
+}
+
+func TestRunErrors(t *testing.T) {
+	r := require.New(t)
+	ok := func(context.Context, *Results) (int, error) { return 1, nil }
+
+	var unknown Graph
+	Add(&unknown, "a", ok, DependsOn("missing"))
+	_, err := unknown.Run(context.Background())
+	r.EqualError(err, `dag: task "a" depends on unknown task "missing"`)
+
+	var cyclic Graph
+	Add(&cyclic, "a", ok, DependsOn("b"))
+	Add(&cyclic, "b", ok, DependsOn("a"))
+	_, err = cyclic.Run(context.Background())
+	var cycleErr *graph.CycleError[string]
+	r.ErrorAs(err, &cycleErr)
+
+	r.Panics(func() { Add(&cyclic, "a", ok) })
+
+	// a failure cancels the tasks already running, and stops its dependents
+	// from starting
+	var failing Graph
+	boom := errors.New("boom")
+	Add(&failing, "fails", func(context.Context, *Results) (int, error) {
+		return 0, boom
+	})
+	Add(&failing, "waits", func(ctx context.Context, _ *Results) (int, error) {
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+	var ran atomic.Bool
+	Add(&failing, "after", func(context.Context, *Results) (int, error) {
+		ran.Store(true)
+		return 0, nil
+	}, DependsOn("fails"))
+	_, err = failing.Run(context.Background())
+	var taskErr *TaskError
+	r.ErrorAs(err, &taskErr)
+	r.Equal("fails", taskErr.Name)
+	r.ErrorIs(err, boom)
+	r.False(ran.Load())
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	var g Graph
+	Add(&g, "a", ok)
+	_, err = g.Run(cancelled)
+	r.ErrorIs(err, context.Canceled)
+}
+
+func TestRunUndeclaredDependency(t *testing.T) {
+	r := require.New(t)
+	var g Graph
+	a := Add(&g, "a", func(context.Context, *Results) (int, error) { return 1, nil })
+	Add(&g, "b", func(_ context.Context, res *Results) (int, error) {
+		r.Panics(func() { a.Get(res) })
+		return 0, nil
+	})
+	_, err := g.Run(context.Background())
+	r.NoError(err)
+}
+
+func TestRetry(t *testing.T) {
+	r := require.New(t)
+	flaky := func(failures int, calls *int) func(context.Context, *Results) (int, error) {
+		return func(context.Context, *Results) (int, error) {
+			*calls++
+			if *calls <= failures {
+				return 0, errors.New("flaky")
+			}
+			return *calls, nil
+		}
+	}
+	delays := func(yield func(time.Duration) bool) {
+		for yield(time.Millisecond) {
+		}
+	}
+
+	var g Graph
+	var calls int
+	task := Add(&g, "a", flaky(2, &calls), Retry(2, delays))
+	res, err := g.Run(context.Background())
+	r.NoError(err)
+	r.Equal(3, task.Get(res))
+
+	var exhausted Graph
+	calls = 0
+	Add(&exhausted, "a", flaky(5, &calls), Retry(2, nil))
+	_, err = exhausted.Run(context.Background())
+	r.Contains(err.Error(), "flaky")
+	r.Equal(3, calls)
+}
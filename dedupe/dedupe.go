@@ -0,0 +1,105 @@
+// Package dedupe makes batch callbacks idempotent, by remembering the result
+// for every key that's been processed and skipping the work the next time the
+// same key comes up. That makes it safe to re-run a ParMap job that failed
+// partway through, without repeating side effects like sending an email twice.
+package dedupe
+
+import (
+	"context"
+	"sync"
+)
+
+// Store records the result of every key that's been processed successfully.
+// Implementations must be safe to call from multiple goroutines
+type Store[K comparable, V any] interface {
+	// Get returns the result saved for key, and whether there was one
+	Get(ctx context.Context, key K) (V, bool, error)
+	// Put saves val as the result for key
+	Put(ctx context.Context, key K, val V) error
+}
+
+// call is a call to fn in progress, that other calls with the same key wait
+// for
+type call[U any] struct {
+	done chan struct{}
+	val  U
+	err  error
+}
+
+// Wrap returns a function with the same signature as fn, for passing to
+// slice.ParMap and friends, that calls fn only for elements whose key, from
+// keyFn, doesn't have a result in store yet. For the rest, it returns the
+// saved result without calling fn. When fn succeeds, its result is saved
+// under the element's key, so it won't be called for that key again. Errors
+// aren't saved, so a failed element is retried the next time it's seen.
+//
+// If several calls with the same key run at the same time, only one of them
+// calls fn, and the others wait for and share its result.
+//
+// Example usage, making a re-run of a notification job safe:
+//
+//	var sent dedupe.Memory[string, bool]
+//	notify := dedupe.Wrap(&sent, func(_ uint, o Order) string {
+//		return o.ID
+//	}, func(ctx context.Context, _ uint, o Order) (bool, error) {
+//		return true, mailer.Send(ctx, o.Customer, receipt(o))
+//	})
+//	_, err := slice.ParMap(ctx, orders, notify)
+func Wrap[T any, K comparable, U any](
+	store Store[K, U],
+	keyFn func(uint, T) K,
+	fn func(context.Context, uint, T) (U, error),
+) func(context.Context, uint, T) (U, error) {
+	var mut sync.Mutex
+	inFlight := map[K]*call[U]{}
+	return func(ctx context.Context, i uint, t T) (U, error) {
+		key := keyFn(i, t)
+		mut.Lock()
+		if c, ok := inFlight[key]; ok {
+			mut.Unlock()
+			select {
+			case <-c.done:
+				return c.val, c.err
+			case <-ctx.Done():
+				var zero U
+				return zero, ctx.Err()
+			}
+		}
+		c := &call[U]{done: make(chan struct{})}
+		inFlight[key] = c
+		mut.Unlock()
+
+		c.val, c.err = process(ctx, store, key, i, t, fn)
+		mut.Lock()
+		delete(inFlight, key)
+		mut.Unlock()
+		close(c.done)
+		return c.val, c.err
+	}
+}
+
+// process returns the saved result for key if there is one, and otherwise
+// calls fn and saves its result
+func process[T any, K comparable, U any](
+	ctx context.Context,
+	store Store[K, U],
+	key K,
+	i uint,
+	t T,
+	fn func(context.Context, uint, T) (U, error),
+) (U, error) {
+	var zero U
+	if u, ok, err := store.Get(ctx, key); err != nil {
+		return zero, err
+	} else if ok {
+		return u, nil
+	}
+	u, err := fn(ctx, i, t)
+	if err != nil {
+		return zero, err
+	}
+	if err := store.Put(ctx, key, u); err != nil {
+		return zero, err
+	}
+	return u, nil
+}
@@ -0,0 +1,141 @@
+package dedupe
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-functional/core/slice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrap(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var calls atomic.Int32
+	boom := errors.New("boom")
+	failOn := "c"
+	upper := func(_ context.Context, _ uint, s string) (string, error) {
+		calls.Add(1)
+		if s == failOn {
+			return "", boom
+		}
+		return strings.ToUpper(s), nil
+	}
+	key := func(_ uint, s string) string { return s }
+	var store Memory[string, string]
+	wrapped := Wrap(&store, key, upper)
+
+	_, err := slice.ParMapPartial(ctx, []string{"a", "b", "c"}, wrapped)
+	r.ErrorIs(err, boom)
+	r.Equal(int32(3), calls.Load())
+
+	// a re-run only calls fn for the element that failed
+	failOn = ""
+	out, err := slice.ParMap(ctx, []string{"a", "b", "c"}, wrapped)
+	r.NoError(err)
+	r.Equal([]string{"A", "B", "C"}, out)
+	r.Equal(int32(4), calls.Load())
+
+	// so does a new wrapper around the same store
+	out, err = slice.ParMap(ctx, []string{"c", "d"}, Wrap(&store, key, upper))
+	r.NoError(err)
+	r.Equal([]string{"C", "D"}, out)
+	r.Equal(int32(5), calls.Load())
+}
+
+// forgetfulStore never remembers anything, so only sharing an in-flight call
+// can stop fn from being called twice for the same key
+type forgetfulStore struct{}
+
+func (forgetfulStore) Get(context.Context, int) (int, bool, error) { return 0, false, nil }
+
+func (forgetfulStore) Put(context.Context, int, int) error { return nil }
+
+// waitingCtx signals on waiting whenever Done is called, which the wrapper
+// only does once a call has found another with the same key in flight and
+// is about to wait for it
+type waitingCtx struct {
+	context.Context
+	waiting chan<- struct{}
+}
+
+func (w waitingCtx) Done() <-chan struct{} {
+	w.waiting <- struct{}{}
+	return w.Context.Done()
+}
+
+func TestWrapConcurrentKeys(t *testing.T) {
+	r := require.New(t)
+	var calls atomic.Int32
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	wrapped := Wrap[int](forgetfulStore{}, func(_ uint, n int) int {
+		return n % 2
+	}, func(_ context.Context, _ uint, n int) (int, error) {
+		if calls.Add(1) == 1 {
+			close(entered)
+		}
+		<-release
+		return n * 10, nil
+	})
+
+	const dups = 4
+	var wg sync.WaitGroup
+	out := make([]int, dups+1)
+	errs := make([]error, dups+1)
+	waiting := make(chan struct{}, dups)
+	call := func(ctx context.Context, i int) {
+		defer wg.Done()
+		out[i], errs[i] = wrapped(ctx, uint(i), 2)
+	}
+	wg.Add(1)
+	go call(context.Background(), 0)
+	// the first call is now inside fn, so the rest find it in flight
+	<-entered
+	for i := 1; i <= dups; i++ {
+		wg.Add(1)
+		go call(waitingCtx{Context: context.Background(), waiting: waiting}, i)
+	}
+	for range dups {
+		<-waiting
+	}
+	close(release)
+	wg.Wait()
+
+	for _, err := range errs {
+		r.NoError(err)
+	}
+	r.Equal([]int{20, 20, 20, 20, 20}, out)
+	r.Equal(int32(1), calls.Load())
+}
+
+type failingStore struct {
+	getErr, putErr error
+}
+
+func (f failingStore) Get(context.Context, string) (int, bool, error) {
+	return 0, false, f.getErr
+}
+
+func (f failingStore) Put(context.Context, string, int) error {
+	return f.putErr
+}
+
+func TestWrapStoreErrors(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	key := func(_ uint, s string) string { return s }
+	fn := func(context.Context, uint, string) (int, error) { return 1, nil }
+
+	getErr := errors.New("get")
+	_, err := Wrap[string](failingStore{getErr: getErr}, key, fn)(ctx, 0, "a")
+	r.ErrorIs(err, getErr)
+
+	putErr := errors.New("put")
+	_, err = Wrap[string](failingStore{putErr: putErr}, key, fn)(ctx, 0, "a")
+	r.ErrorIs(err, putErr)
+}
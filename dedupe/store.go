@@ -0,0 +1,133 @@
+package dedupe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sync"
+)
+
+// Memory is a Store that keeps results in memory, so they only last as long
+// as the process. The zero value is ready to use
+type Memory[K comparable, V any] struct {
+	mut  sync.RWMutex
+	vals map[K]V
+}
+
+// Get returns the result saved for key, and whether there was one. It never
+// fails
+func (m *Memory[K, V]) Get(_ context.Context, key K) (V, bool, error) {
+	m.mut.RLock()
+	defer m.mut.RUnlock()
+	v, ok := m.vals[key]
+	return v, ok, nil
+}
+
+// Put saves val as the result for key. It never fails
+func (m *Memory[K, V]) Put(_ context.Context, key K, val V) error {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	if m.vals == nil {
+		m.vals = map[K]V{}
+	}
+	m.vals[key] = val
+	return nil
+}
+
+// File returns a Store that keeps results in memory, backed by the file at
+// path, so they survive restarts. Every Put appends the key and value to the
+// file as a line of JSON, and syncs it. The file is read the first time the
+// Store is used, and is created if it doesn't exist.
+//
+// If the process crashed partway through writing a line, the incomplete line
+// is dropped when the file is read, so that key is processed again.
+//
+// Example usage:
+//
+//	store := dedupe.File[string, Receipt]("charges.jsonl")
+//	charge := dedupe.Wrap(store, invoiceID, chargeInvoice)
+func File[K comparable, V any](path string) Store[K, V] {
+	return &file[K, V]{path: path}
+}
+
+type file[K comparable, V any] struct {
+	mut    sync.Mutex
+	path   string
+	loaded bool
+	mem    Memory[K, V]
+}
+
+// record is a line of the file
+type record[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// load reads the file into f.mem, if it hasn't been already. f.mut must be
+// held
+func (f *file[K, V]) load(ctx context.Context) error {
+	if f.loaded {
+		return nil
+	}
+	data, err := os.ReadFile(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	lines := bytes.Split(data, []byte("\n"))
+	// the last element is what comes after the last newline, which is either
+	// empty or a line that was only partly written
+	if partial := lines[len(lines)-1]; len(partial) > 0 {
+		if err := os.Truncate(f.path, int64(len(data)-len(partial))); err != nil {
+			return err
+		}
+	}
+	for _, line := range lines[:len(lines)-1] {
+		var rec record[K, V]
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return err
+		}
+		f.mem.Put(ctx, rec.Key, rec.Value)
+	}
+	f.loaded = true
+	return nil
+}
+
+func (f *file[K, V]) Get(ctx context.Context, key K) (V, bool, error) {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if err := f.load(ctx); err != nil {
+		var zero V
+		return zero, false, err
+	}
+	return f.mem.Get(ctx, key)
+}
+
+func (f *file[K, V]) Put(ctx context.Context, key K, val V) error {
+	f.mut.Lock()
+	defer f.mut.Unlock()
+	if err := f.load(ctx); err != nil {
+		return err
+	}
+	line, err := json.Marshal(record[K, V]{Key: key, Value: val})
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(append(line, '\n'))
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return f.mem.Put(ctx, key, val)
+}
@@ -0,0 +1,63 @@
+package dedupe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemory(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var m Memory[string, int]
+	_, ok, err := m.Get(ctx, "a")
+	r.NoError(err)
+	r.False(ok)
+	r.NoError(m.Put(ctx, "a", 1))
+	v, ok, err := m.Get(ctx, "a")
+	r.NoError(err)
+	r.True(ok)
+	r.Equal(1, v)
+}
+
+func TestFile(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "seen.jsonl")
+	store := File[string, int](path)
+	_, ok, err := store.Get(ctx, "a")
+	r.NoError(err)
+	r.False(ok)
+	r.NoError(store.Put(ctx, "a", 1))
+	r.NoError(store.Put(ctx, "b", 2))
+
+	// a new Store sees what the old one saved
+	reopened := File[string, int](path)
+	v, ok, err := reopened.Get(ctx, "b")
+	r.NoError(err)
+	r.True(ok)
+	r.Equal(2, v)
+
+	// a partly written last line is dropped, and later writes still parse
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	r.NoError(err)
+	_, err = f.WriteString(`{"key":"c","val`)
+	r.NoError(err)
+	r.NoError(f.Close())
+	recovered := File[string, int](path)
+	_, ok, err = recovered.Get(ctx, "c")
+	r.NoError(err)
+	r.False(ok)
+	r.NoError(recovered.Put(ctx, "c", 3))
+	v, ok, err = File[string, int](path).Get(ctx, "c")
+	r.NoError(err)
+	r.True(ok)
+	r.Equal(3, v)
+
+	r.NoError(os.WriteFile(path, []byte("not json\n"), 0o644))
+	_, _, err = File[string, int](path).Get(ctx, "a")
+	r.Error(err)
+}
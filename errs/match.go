@@ -0,0 +1,51 @@
+package errs
+
+import "errors"
+
+// IsAny returns true if errors.Is(err, target) is true for any of targets.
+//
+// Example usage:
+//
+//	if errs.IsAny(err, context.Canceled, context.DeadlineExceeded) {
+//		return nil
+//	}
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// AsOpt is a typed version of errors.As. It returns the first error in err's
+// tree that has type T, and true, or the zero value of T and false if there
+// isn't one. It saves declaring a variable just to take its address.
+//
+// Example usage:
+//
+//	if pathErr, ok := errs.AsOpt[*fs.PathError](err); ok {
+//		log.Printf("failed on %s", pathErr.Path)
+//	}
+func AsOpt[T error](err error) (T, bool) {
+	var target T
+	if errors.As(err, &target) {
+		return target, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Ignore returns nil if err matches any of targets, according to IsAny, and
+// err otherwise. It's for code that has to tolerate specific failures, like a
+// pipeline stage that treats a missing file as nothing to do.
+//
+// Example usage:
+//
+//	return errs.Ignore(os.Remove(path), fs.ErrNotExist)
+func Ignore(err error, targets ...error) error {
+	if IsAny(err, targets...) {
+		return nil
+	}
+	return err
+}
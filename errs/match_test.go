@@ -0,0 +1,45 @@
+package errs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAnyIgnore(t *testing.T) {
+	r := require.New(t)
+	wrapped := fmt.Errorf("reading: %w", io.EOF)
+	r.True(IsAny(wrapped, context.Canceled, io.EOF))
+	r.False(IsAny(wrapped, context.Canceled))
+	r.False(IsAny(wrapped))
+	r.False(IsAny(nil, io.EOF))
+
+	r.NoError(Ignore(wrapped, io.EOF))
+	r.Equal(wrapped, Ignore(wrapped, fs.ErrNotExist))
+	r.NoError(Ignore(nil, io.EOF))
+}
+
+func TestAsOpt(t *testing.T) {
+	r := require.New(t)
+	pathErr := &fs.PathError{Op: "open", Path: "x", Err: fs.ErrNotExist}
+	err := fmt.Errorf("loading: %w", pathErr)
+
+	got, ok := AsOpt[*fs.PathError](err)
+	r.True(ok)
+	r.Same(pathErr, got)
+
+	got, ok = AsOpt[*fs.PathError](errors.New("other"))
+	r.False(ok)
+	r.Nil(got)
+
+	var m Multi
+	m.Add(3, io.EOF)
+	keyed, ok := AsOpt[KeyedError](m.Err())
+	r.True(ok)
+	r.Equal(3, keyed.Key)
+}
@@ -0,0 +1,127 @@
+// Package errs provides helpers for building, inspecting and matching errors.
+package errs
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// KeyedError is an error tagged with the key, like a slice index or a map
+// key, of the element it happened on
+type KeyedError struct {
+	Key any
+	Err error
+}
+
+func (e KeyedError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Key, e.Err)
+}
+
+func (e KeyedError) Unwrap() error {
+	return e.Err
+}
+
+// Multi accumulates errors, each tagged with a key, for operations that keep
+// going after a failure and report every one at the end. The zero value is an
+// empty Multi ready to use, and a Multi is safe for concurrent use, so
+// parallel callbacks can add to the same one.
+//
+// Multi implements Unwrap() []error, returning a KeyedError for every error
+// added, so errors.Is and errors.As see through it to the errors inside.
+//
+// Example usage:
+//
+//	var m errs.Multi
+//	for i, row := range rows {
+//		m.Add(i, validate(row))
+//	}
+//	return m.Err()
+type Multi struct {
+	mut  sync.Mutex
+	errs []KeyedError
+}
+
+// Add records err under key. A nil err is ignored, so the result of a call
+// can be passed straight in
+func (m *Multi) Add(key any, err error) {
+	if err == nil {
+		return
+	}
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	m.errs = append(m.errs, KeyedError{Key: key, Err: err})
+}
+
+// Len returns how many errors have been added
+func (m *Multi) Len() int {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return len(m.errs)
+}
+
+// Errors returns a copy of every error that's been added, in the order they
+// were added
+func (m *Multi) Errors() []KeyedError {
+	m.mut.Lock()
+	defer m.mut.Unlock()
+	return append([]KeyedError(nil), m.errs...)
+}
+
+// Err returns m if any errors have been added, and nil otherwise. Return
+// Err() rather than m itself from a function that returns an error, since a
+// nil *Multi stored in an error isn't a nil error
+func (m *Multi) Err() error {
+	if m.Len() == 0 {
+		return nil
+	}
+	return m
+}
+
+// Error renders every error, grouping the keys of errors with the same
+// message, in the order each message first appeared. For example, three
+// errors might render as:
+//
+//	3 errors: 0, 2: invalid syntax; 5: value out of range
+func (m *Multi) Error() string {
+	errs := m.Errors()
+	switch len(errs) {
+	case 0:
+		return "no errors"
+	case 1:
+		return errs[0].Error()
+	}
+	var (
+		msgs []string
+		keys = map[string][]string{}
+	)
+	for _, e := range errs {
+		msg := e.Err.Error()
+		if _, ok := keys[msg]; !ok {
+			msgs = append(msgs, msg)
+		}
+		keys[msg] = append(keys[msg], fmt.Sprint(e.Key))
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d errors: ", len(errs))
+	for i, msg := range msgs {
+		if i > 0 {
+			sb.WriteString("; ")
+		}
+		sb.WriteString(strings.Join(keys[msg], ", "))
+		sb.WriteString(": ")
+		sb.WriteString(msg)
+	}
+	return sb.String()
+}
+
+// Unwrap returns a KeyedError for every error that's been added, in the
+// order they were added
+func (m *Multi) Unwrap() []error {
+	errs := m.Errors()
+	ret := make([]error, len(errs))
+	for i, e := range errs {
+		ret[i] = e
+	}
+	return ret
+}
@@ -0,0 +1,55 @@
+package errs
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMulti(t *testing.T) {
+	r := require.New(t)
+	var m Multi
+	r.NoError(m.Err())
+	m.Add(0, nil)
+	r.Equal(0, m.Len())
+
+	bad := errors.New("bad")
+	m.Add(0, bad)
+	r.Equal("0: bad", m.Error())
+	m.Add("b", io.EOF)
+	m.Add(2, bad)
+	r.Equal(3, m.Len())
+	r.Equal("3 errors: 0, 2: bad; b: EOF", m.Error())
+
+	err := m.Err()
+	r.Error(err)
+	r.ErrorIs(err, bad)
+	r.ErrorIs(err, io.EOF)
+	var keyed KeyedError
+	r.ErrorAs(err, &keyed)
+	r.Equal(0, keyed.Key)
+	var multi *Multi
+	r.ErrorAs(err, &multi)
+	r.Equal([]KeyedError{{0, bad}, {"b", io.EOF}, {2, bad}}, multi.Errors())
+}
+
+func TestMultiConcurrent(t *testing.T) {
+	r := require.New(t)
+	var (
+		m  Multi
+		wg sync.WaitGroup
+	)
+	for i := range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			m.Add(i, io.EOF)
+		}()
+	}
+	wg.Wait()
+	r.Equal(50, m.Len())
+	r.Len(m.Unwrap(), 50)
+}
@@ -0,0 +1,43 @@
+package errs
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is an error holding a panic recovered from a goroutine, for
+// concurrency helpers that carry a panic back to the code waiting on the
+// goroutine, like scope.Run and syncx.WaitGroupE, instead of letting it
+// crash the program from somewhere unrelated
+type PanicError struct {
+	// Value is the value the goroutine panicked with
+	Value any
+	// Stack is the stack trace of the goroutine when it panicked
+	Stack []byte
+}
+
+// NewPanicError creates a PanicError holding v and the current goroutine's
+// stack. Call it from the deferred function that recovered v, so the stack
+// still shows where the panic happened.
+//
+// Example usage:
+//
+//	defer func() {
+//		if v := recover(); v != nil {
+//			err = errs.NewPanicError(v)
+//		}
+//	}()
+func NewPanicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack()}
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("goroutine panicked: %v\n\n%s", p.Value, p.Stack)
+}
+
+// Unwrap returns the panic value if it's an error, so errors.Is and
+// errors.As see through the panic
+func (p *PanicError) Unwrap() error {
+	err, _ := p.Value.(error)
+	return err
+}
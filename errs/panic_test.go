@@ -0,0 +1,32 @@
+package errs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPanicError(t *testing.T) {
+	r := require.New(t)
+	recovered := func(fn func()) (err *PanicError) {
+		defer func() {
+			if v := recover(); v != nil {
+				err = NewPanicError(v)
+			}
+		}()
+		fn()
+		return nil
+	}
+
+	boom := errors.New("boom")
+	err := recovered(func() { panic(boom) })
+	r.ErrorIs(err, boom)
+	r.Equal(boom, err.Value)
+	r.Contains(string(err.Stack), "TestPanicError")
+	r.Contains(err.Error(), "goroutine panicked: boom")
+
+	err = recovered(func() { panic("not an error") })
+	r.NoError(err.Unwrap())
+	r.Contains(err.Error(), "not an error")
+}
@@ -0,0 +1,63 @@
+package extsort
+
+import (
+	"encoding/gob"
+	"encoding/json"
+	"io"
+)
+
+// Codec writes values of type T to the temporary files Sort spills runs to,
+// and reads them back
+type Codec[T any] interface {
+	// NewEncoder returns a function that writes one value to w each time it's
+	// called
+	NewEncoder(w io.Writer) func(T) error
+	// NewDecoder returns a function that reads the next value from r each
+	// time it's called, and returns io.EOF once there are none left
+	NewDecoder(r io.Reader) func() (T, error)
+}
+
+type gobCodec[T any] struct{}
+
+// Gob returns a Codec that uses encoding/gob. It's the default, and works
+// for any T that gob can encode. Gob only encodes exported struct fields, so
+// unexported ones come back as zero values after a spill
+func Gob[T any]() Codec[T] {
+	return gobCodec[T]{}
+}
+
+func (gobCodec[T]) NewEncoder(w io.Writer) func(T) error {
+	enc := gob.NewEncoder(w)
+	return func(t T) error { return enc.Encode(t) }
+}
+
+func (gobCodec[T]) NewDecoder(r io.Reader) func() (T, error) {
+	dec := gob.NewDecoder(r)
+	return func() (T, error) {
+		var t T
+		err := dec.Decode(&t)
+		return t, err
+	}
+}
+
+type jsonCodec[T any] struct{}
+
+// JSON returns a Codec that uses encoding/json, writing one value per line.
+// It's slower than Gob, but handles types with custom JSON marshalling
+func JSON[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+func (jsonCodec[T]) NewEncoder(w io.Writer) func(T) error {
+	enc := json.NewEncoder(w)
+	return func(t T) error { return enc.Encode(t) }
+}
+
+func (jsonCodec[T]) NewDecoder(r io.Reader) func() (T, error) {
+	dec := json.NewDecoder(r)
+	return func() (T, error) {
+		var t T
+		err := dec.Decode(&t)
+		return t, err
+	}
+}
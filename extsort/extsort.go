@@ -0,0 +1,374 @@
+// Package extsort sorts sequences that are too big to fit in memory.
+//
+// Sort reads its input in runs that do fit, sorts each run in memory and
+// spills it to a temporary file, then streams back a k-way merge of the
+// files. Only one run, plus one value per open run file, is held in memory
+// at a time. If there are more run files than can be open at once, they're
+// merged in several passes.
+package extsort
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/go-functional/core/seq"
+)
+
+// Option configures Sort
+type Option func(*config)
+
+type config struct {
+	runSize     int
+	maxOpenRuns int
+	dir         string
+	// codec is a Codec[T] for the T that Sort is called with, or nil for Gob
+	codec any
+}
+
+// DefaultRunSize is how many values Sort holds in memory at once, unless
+// it's given RunSize
+const DefaultRunSize = 100_000
+
+// RunSize sets how many values Sort reads into memory and sorts before
+// spilling them to a file. Bigger runs mean fewer files and a cheaper merge,
+// at the cost of memory. n < 1 means DefaultRunSize
+func RunSize(n int) Option {
+	return func(c *config) {
+		if n < 1 {
+			n = DefaultRunSize
+		}
+		c.runSize = n
+	}
+}
+
+// DefaultMaxOpenRuns is how many run files Sort merges at once, unless it's
+// given MaxOpenRuns
+const DefaultMaxOpenRuns = 64
+
+// MaxOpenRuns sets how many run files Sort keeps open and merges at once.
+// When there are more runs than that, Sort first merges them in groups of n
+// into longer runs, as many times as it takes, which costs an extra read and
+// write of the data per pass but keeps Sort under the process's open file
+// limit. n < 2 means DefaultMaxOpenRuns
+func MaxOpenRuns(n int) Option {
+	return func(c *config) {
+		if n < 2 {
+			n = DefaultMaxOpenRuns
+		}
+		c.maxOpenRuns = n
+	}
+}
+
+// TempDir sets the directory Sort creates its temporary files in. The
+// default is os.TempDir()
+func TempDir(dir string) Option {
+	return func(c *config) {
+		c.dir = dir
+	}
+}
+
+// WithCodec sets how Sort writes values to its temporary files and reads
+// them back. The default is Gob. Sort panics if codec is for a different
+// type than the one it's sorting
+func WithCodec[T any](codec Codec[T]) Option {
+	return func(c *config) {
+		c.codec = codec
+	}
+}
+
+// Sort returns a sequence of every value in in, sorted by less. The sort is
+// stable, so values that are equal according to less come out in the order
+// they went in.
+//
+// Nothing happens until the returned sequence is ranged over. Then Sort
+// reads all of in, spilling sorted runs to a temporary directory if there's
+// more than one run's worth, and yields the merged result. The temporary
+// files are removed once the sequence ends or the loop over it stops. Each
+// range over the sequence sorts in again from the start.
+//
+// If in can't be spilled or read back, or ctx is done, the sequence yields
+// the zero value of T with the error, and then ends.
+//
+// Example usage:
+//
+//	sorted := extsort.Sort(ctx, jsonlRows, func(a, b Row) bool {
+//		return a.ID < b.ID
+//	}, extsort.RunSize(1_000_000))
+//	for row, err := range sorted {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+func Sort[T any](
+	ctx context.Context,
+	in iter.Seq[T],
+	less func(a, b T) bool,
+	opts ...Option,
+) iter.Seq2[T, error] {
+	cfg := config{runSize: DefaultRunSize, maxOpenRuns: DefaultMaxOpenRuns}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	codec := Gob[T]()
+	if cfg.codec != nil {
+		c, ok := cfg.codec.(Codec[T])
+		if !ok {
+			panic(fmt.Sprintf("extsort: Sort called with a %T, which isn't a Codec for its values", cfg.codec))
+		}
+		codec = c
+	}
+	return func(yield func(T, error) bool) {
+		s := &sorter[T]{ctx: ctx, less: less, cfg: cfg, codec: codec}
+		defer s.cleanup()
+		if err := s.readRuns(in); err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		if err := s.compact(); err != nil {
+			var zero T
+			yield(zero, err)
+			return
+		}
+		s.merge(yield)
+	}
+}
+
+type sorter[T any] struct {
+	ctx   context.Context
+	less  func(a, b T) bool
+	cfg   config
+	codec Codec[T]
+
+	// dir holds the spilled runs, and is empty until the first spill
+	dir  string
+	runs []string
+	// numFiles is how many run files have been created, to name the next
+	numFiles int
+	files    []*os.File
+	// last is the final run, which is never spilled
+	last []T
+}
+
+func (s *sorter[T]) sortRun(run []T) {
+	slices.SortStableFunc(run, func(a, b T) int {
+		switch {
+		case s.less(a, b):
+			return -1
+		case s.less(b, a):
+			return 1
+		}
+		return 0
+	})
+}
+
+// readRuns reads in, sorting and spilling every full run, and keeps the
+// last, possibly partial run in memory
+func (s *sorter[T]) readRuns(in iter.Seq[T]) error {
+	run := make([]T, 0, min(s.cfg.runSize, 1024))
+	for t := range in {
+		if len(run) == s.cfg.runSize {
+			if err := s.spill(run); err != nil {
+				return err
+			}
+			run = run[:0]
+		}
+		run = append(run, t)
+	}
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	s.sortRun(run)
+	s.last = run
+	return nil
+}
+
+func (s *sorter[T]) spill(run []T) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if s.dir == "" {
+		dir, err := os.MkdirTemp(s.cfg.dir, "extsort-")
+		if err != nil {
+			return err
+		}
+		s.dir = dir
+	}
+	s.sortRun(run)
+	path, err := s.writeRun(slices.Values(run))
+	if err != nil {
+		return err
+	}
+	s.runs = append(s.runs, path)
+	return nil
+}
+
+// writeRun writes every value in run to a new file in s.dir, and returns its
+// path
+func (s *sorter[T]) writeRun(run iter.Seq[T]) (path string, err error) {
+	path = filepath.Join(s.dir, fmt.Sprintf("run-%d", s.numFiles))
+	s.numFiles++
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+	w := bufio.NewWriter(f)
+	encode := s.codec.NewEncoder(w)
+	for t := range run {
+		if err := encode(t); err != nil {
+			return "", err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// compact merges the spilled runs, in consecutive groups of MaxOpenRuns,
+// into longer runs until there are few enough left for merge to open them
+// all, and the last run along with them. Merging consecutive runs keeps
+// them in the order they were read, so the sort stays stable
+func (s *sorter[T]) compact() error {
+	for len(s.runs)+1 > s.cfg.maxOpenRuns {
+		next := make([]string, 0, len(s.runs)/s.cfg.maxOpenRuns+1)
+		for group := range slices.Chunk(s.runs, s.cfg.maxOpenRuns) {
+			if len(group) == 1 {
+				next = append(next, group[0])
+				continue
+			}
+			path, err := s.mergeRuns(group)
+			if err != nil {
+				return err
+			}
+			next = append(next, path)
+		}
+		s.runs = next
+	}
+	return nil
+}
+
+// mergeRuns merges the runs in paths into a new run, removes them, and
+// returns the new run's path
+func (s *sorter[T]) mergeRuns(paths []string) (string, error) {
+	if err := s.ctx.Err(); err != nil {
+		return "", err
+	}
+	var readErr error
+	inputs, files, err := s.openRuns(paths, &readErr)
+	defer func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}()
+	if err != nil {
+		return "", err
+	}
+	path, err := s.writeRun(seq.MergeSorted(s.less, inputs...))
+	if err == nil {
+		err = readErr
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, p := range paths {
+		if err := os.Remove(p); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// openRuns opens each of the runs in paths, and returns a sequence of the
+// values in each one, along with the open files for the caller to close.
+// The first run that fails to read back sets *readErr and ends early
+func (s *sorter[T]) openRuns(paths []string, readErr *error) ([]iter.Seq[T], []*os.File, error) {
+	inputs := make([]iter.Seq[T], 0, len(paths)+1)
+	files := make([]*os.File, 0, len(paths))
+	for _, path := range paths {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, files, err
+		}
+		files = append(files, f)
+		decode := s.codec.NewDecoder(bufio.NewReader(f))
+		inputs = append(inputs, func(yield func(T) bool) {
+			for {
+				t, err := decode()
+				if errors.Is(err, io.EOF) {
+					return
+				}
+				if err != nil {
+					if *readErr == nil {
+						*readErr = fmt.Errorf("extsort: reading back %s: %w", filepath.Base(path), err)
+					}
+					return
+				}
+				if !yield(t) {
+					return
+				}
+			}
+		})
+	}
+	return inputs, files, nil
+}
+
+// merge yields the k-way merge of every spilled run and the last run. The
+// spilled runs come first among the inputs, in the order they were read, so
+// the merge keeps the sort stable
+func (s *sorter[T]) merge(yield func(T, error) bool) {
+	// readErr is set by the first run that fails to read back, which then
+	// ends early, so it's checked before every value the merge yields
+	var readErr error
+	inputs, files, err := s.openRuns(s.runs, &readErr)
+	s.files = files
+	if err != nil {
+		var zero T
+		yield(zero, err)
+		return
+	}
+	inputs = append(inputs, slices.Values(s.last))
+
+	fail := func(err error) {
+		var zero T
+		yield(zero, err)
+	}
+	for t := range seq.MergeSorted(s.less, inputs...) {
+		if readErr != nil {
+			fail(readErr)
+			return
+		}
+		if err := s.ctx.Err(); err != nil {
+			fail(err)
+			return
+		}
+		if !yield(t, nil) {
+			return
+		}
+	}
+	if readErr != nil {
+		fail(readErr)
+	}
+}
+
+func (s *sorter[T]) cleanup() {
+	for _, f := range s.files {
+		f.Close()
+	}
+	if s.dir != "" {
+		os.RemoveAll(s.dir)
+	}
+}
@@ -0,0 +1,122 @@
+package extsort
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type rec struct {
+	Key int
+	Seq int
+}
+
+func randomRecs(n int) []rec {
+	rng := rand.New(rand.NewPCG(7, 7))
+	ret := make([]rec, n)
+	for i := range ret {
+		ret[i] = rec{Key: rng.IntN(50), Seq: i}
+	}
+	return ret
+}
+
+func collect[T any](r *require.Assertions, s func(func(T, error) bool)) []T {
+	var ret []T
+	for t, err := range s {
+		r.NoError(err)
+		ret = append(ret, t)
+	}
+	return ret
+}
+
+func byKey(a, b rec) bool { return a.Key < b.Key }
+
+func TestSort(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	in := randomRecs(1000)
+	want := slices.Clone(in)
+	slices.SortStableFunc(want, func(a, b rec) int { return a.Key - b.Key })
+
+	for _, opts := range [][]Option{
+		nil,
+		{RunSize(1)},
+		{RunSize(64)},
+		{RunSize(100), WithCodec(JSON[rec]())},
+		// 334 runs, merged in several passes of at most 4 at a time
+		{RunSize(3), MaxOpenRuns(4)},
+		{RunSize(1), MaxOpenRuns(2)},
+	} {
+		dir := t.TempDir()
+		opts = append(opts, TempDir(dir))
+		got := collect(r, Sort(ctx, slices.Values(in), byKey, opts...))
+		r.Equal(want, got)
+		left, err := os.ReadDir(dir)
+		r.NoError(err)
+		r.Empty(left)
+	}
+
+	r.Empty(collect(r, Sort(ctx, slices.Values([]rec(nil)), byKey)))
+}
+
+func TestSortEarlyStop(t *testing.T) {
+	r := require.New(t)
+	dir := t.TempDir()
+	for v, err := range Sort(context.Background(), slices.Values(randomRecs(500)), byKey, RunSize(10), TempDir(dir)) {
+		r.NoError(err)
+		r.Equal(0, v.Key)
+		break
+	}
+	left, err := os.ReadDir(dir)
+	r.NoError(err)
+	r.Empty(left)
+}
+
+func TestSortErrors(t *testing.T) {
+	r := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, err := range Sort(ctx, slices.Values(randomRecs(100)), byKey, RunSize(10)) {
+		r.ErrorIs(err, context.Canceled)
+	}
+
+	r.Panics(func() {
+		Sort(ctx, slices.Values([]int{1}), func(a, b int) bool { return a < b }, WithCodec(JSON[rec]()))
+	})
+
+	var errs []error
+	for _, err := range Sort(context.Background(), slices.Values(randomRecs(100)), byKey, RunSize(10), WithCodec[rec](badCodec{})) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.Len(errs, 1)
+	r.ErrorIs(errs[0], errBad)
+
+	// the same, when the failing read is in an earlier merge pass
+	errs = nil
+	for _, err := range Sort(context.Background(), slices.Values(randomRecs(100)), byKey, RunSize(10), MaxOpenRuns(2), WithCodec[rec](badCodec{})) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	r.Len(errs, 1)
+	r.ErrorIs(errs[0], errBad)
+}
+
+var errBad = errors.New("bad")
+
+// badCodec writes runs with Gob, but can't read them back
+type badCodec struct{}
+
+func (badCodec) NewEncoder(w io.Writer) func(rec) error { return Gob[rec]().NewEncoder(w) }
+
+func (badCodec) NewDecoder(io.Reader) func() (rec, error) {
+	return func() (rec, error) { return rec{}, errBad }
+}
@@ -0,0 +1,145 @@
+package fn
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Hooks observe calls to the functions that Instrument and InstrumentCtx
+// wrap. Every field is optional, and may be called from many goroutines at
+// once when the wrapped function is passed to a parallel combinator like
+// slice.ParMap, so hooks must be safe for concurrent use
+type Hooks struct {
+	// Start is called just before each call
+	Start func(name string)
+	// Finish is called just after each call, with how long it took and the
+	// error it returned, which is nil if it succeeded
+	Finish func(name string, elapsed time.Duration, err error)
+	// Error is called after each call that returns a non-nil error, after
+	// Finish
+	Error func(name string, err error)
+}
+
+// registry holds the hooks passed to RegisterHooks. It's replaced, rather
+// than modified, on every change, so instrumented calls can read it without
+// locking
+var (
+	registry    atomic.Pointer[[]*Hooks]
+	registryMut sync.Mutex
+)
+
+// RegisterHooks adds h to the hooks that every instrumented function calls,
+// in addition to the ones passed to Instrument, including functions that
+// were instrumented before it was registered. It returns a function that
+// removes h again. It's meant for wiring up metrics or logging once, at
+// startup, without touching every call site.
+//
+// Example usage:
+//
+//	defer fn.RegisterHooks(fn.Hooks{
+//		Finish: func(name string, elapsed time.Duration, err error) {
+//			latency.WithLabelValues(name).Observe(elapsed.Seconds())
+//		},
+//	})()
+func RegisterHooks(h Hooks) (unregister func()) {
+	registryMut.Lock()
+	defer registryMut.Unlock()
+	hp := &h
+	var next []*Hooks
+	if cur := registry.Load(); cur != nil {
+		next = slices.Clone(*cur)
+	}
+	next = append(next, hp)
+	registry.Store(&next)
+	return func() {
+		registryMut.Lock()
+		defer registryMut.Unlock()
+		cur := registry.Load()
+		next := slices.DeleteFunc(slices.Clone(*cur), func(other *Hooks) bool { return other == hp })
+		registry.Store(&next)
+	}
+}
+
+// observe runs the Start hooks, calls call, and runs the Finish and Error
+// hooks with the result
+func observe(name string, hooks []Hooks, call func() error) {
+	var registered []*Hooks
+	if cur := registry.Load(); cur != nil {
+		registered = *cur
+	}
+	each := func(fn func(h *Hooks)) {
+		for i := range hooks {
+			fn(&hooks[i])
+		}
+		for _, h := range registered {
+			fn(h)
+		}
+	}
+	each(func(h *Hooks) {
+		if h.Start != nil {
+			h.Start(name)
+		}
+	})
+	start := time.Now()
+	err := call()
+	elapsed := time.Since(start)
+	each(func(h *Hooks) {
+		if h.Finish != nil {
+			h.Finish(name, elapsed, err)
+		}
+	})
+	if err != nil {
+		each(func(h *Hooks) {
+			if h.Error != nil {
+				h.Error(name, err)
+			}
+		})
+	}
+}
+
+// Instrument wraps f, a callback in the shape that slice.Map takes, so
+// every call to it is reported to hooks, and to every Hooks passed to
+// RegisterHooks, under name. The returned function otherwise behaves exactly
+// like f.
+//
+// Example usage:
+//
+//	parsed, err := slice.Map(lines, fn.Instrument("parse", parseLine, fn.Hooks{
+//		Error: func(name string, err error) { log.Printf("%s: %v", name, err) },
+//	}))
+func Instrument[T, U any](name string, f func(uint, T) (U, error), hooks ...Hooks) func(uint, T) (U, error) {
+	return func(i uint, t T) (U, error) {
+		var (
+			u   U
+			err error
+		)
+		observe(name, hooks, func() error {
+			u, err = f(i, t)
+			return err
+		})
+		return u, err
+	}
+}
+
+// InstrumentCtx is the same as Instrument, for a callback in the shape that
+// slice.ParMap takes
+func InstrumentCtx[T, U any](
+	name string,
+	f func(context.Context, uint, T) (U, error),
+	hooks ...Hooks,
+) func(context.Context, uint, T) (U, error) {
+	return func(ctx context.Context, i uint, t T) (U, error) {
+		var (
+			u   U
+			err error
+		)
+		observe(name, hooks, func() error {
+			u, err = f(ctx, i, t)
+			return err
+		})
+		return u, err
+	}
+}
@@ -0,0 +1,107 @@
+package fn
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordedHooks struct {
+	mut                    sync.Mutex
+	starts, finishes, errs []string
+}
+
+func (rh *recordedHooks) hooks() Hooks {
+	return Hooks{
+		Start: func(name string) {
+			rh.mut.Lock()
+			defer rh.mut.Unlock()
+			rh.starts = append(rh.starts, name)
+		},
+		Finish: func(name string, elapsed time.Duration, err error) {
+			rh.mut.Lock()
+			defer rh.mut.Unlock()
+			rh.finishes = append(rh.finishes, name)
+		},
+		Error: func(name string, err error) {
+			rh.mut.Lock()
+			defer rh.mut.Unlock()
+			rh.errs = append(rh.errs, err.Error())
+		},
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	r := require.New(t)
+	var local, global recordedHooks
+	unregister := RegisterHooks(global.hooks())
+
+	atoi := Instrument("atoi", func(_ uint, s string) (int, error) {
+		return strconv.Atoi(s)
+	}, local.hooks())
+	n, err := atoi(0, "12")
+	r.NoError(err)
+	r.Equal(12, n)
+	_, err = atoi(1, "x")
+	r.Error(err)
+
+	for _, rh := range []*recordedHooks{&local, &global} {
+		r.Equal([]string{"atoi", "atoi"}, rh.starts)
+		r.Equal([]string{"atoi", "atoi"}, rh.finishes)
+		r.Len(rh.errs, 1)
+	}
+
+	unregister()
+	_, _ = atoi(2, "3")
+	r.Len(global.starts, 2)
+	r.Len(local.starts, 3)
+
+	// hooks with missing fields are fine
+	noop := Instrument("noop", func(_ uint, s string) (string, error) { return s, nil }, Hooks{})
+	s, err := noop(0, "a")
+	r.NoError(err)
+	r.Equal("a", s)
+}
+
+func TestInstrumentCtx(t *testing.T) {
+	r := require.New(t)
+	var (
+		local  recordedHooks
+		mut    sync.Mutex
+		timing []time.Duration
+	)
+	defer RegisterHooks(Hooks{Finish: func(_ string, elapsed time.Duration, _ error) {
+		mut.Lock()
+		defer mut.Unlock()
+		timing = append(timing, elapsed)
+	}})()
+	bad := errors.New("bad")
+	f := InstrumentCtx("fetch", func(_ context.Context, i uint, _ int) (int, error) {
+		time.Sleep(time.Millisecond)
+		if i == 1 {
+			return 0, bad
+		}
+		return int(i), nil
+	}, local.hooks())
+
+	var wg sync.WaitGroup
+	for i := range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = f(context.Background(), uint(i), 0)
+		}()
+	}
+	wg.Wait()
+	r.Len(local.finishes, 3)
+	r.Equal([]string{"bad"}, local.errs)
+	r.Len(timing, 3)
+	for _, d := range timing {
+		r.GreaterOrEqual(d, time.Millisecond)
+	}
+}
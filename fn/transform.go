@@ -41,3 +41,45 @@ func Curry[T, U, V any](fn func(T, U) V) func(T) func(U) V {
 		}
 	}
 }
+
+// ComposeErr is similar to Compose, except for functions that can fail. The
+// returned function calls fn1, and if it succeeds, calls fn2 with its output.
+// If fn1 returns a non-nil error, fn2 isn't called and the error is returned.
+//
+// This lets a chain of fallible transforms be built once and passed to Map or
+// ParMap as a single function.
+//
+// Example usage:
+//
+//	parseAndCheck := ComposeErr(
+//		strconv.Atoi,
+//		func(i int) (uint, error) {
+//			if i < 0 {
+//				return 0, fmt.Errorf("%d is negative", i)
+//			}
+//			return uint(i), nil
+//		},
+//	)
+//	answer, err := parseAndCheck("123")
+//	// answer will be 123 and err will be nil
+func ComposeErr[T, U, V any](fn1 func(T) (U, error), fn2 func(U) (V, error)) func(T) (V, error) {
+	return func(t T) (V, error) {
+		u, err := fn1(t)
+		if err != nil {
+			var zero V
+			return zero, err
+		}
+		return fn2(u)
+	}
+}
+
+// ComposeErr3 is the same as ComposeErr, except it chains three functions
+// together. fn3 is called with the output of fn2, which is called with the
+// output of fn1. The first non-nil error stops the chain and is returned.
+func ComposeErr3[T, U, V, W any](
+	fn1 func(T) (U, error),
+	fn2 func(U) (V, error),
+	fn3 func(V) (W, error),
+) func(T) (W, error) {
+	return ComposeErr(ComposeErr(fn1, fn2), fn3)
+}
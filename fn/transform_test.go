@@ -17,3 +17,35 @@ func TestCompose(t *testing.T) {
 	r.Equal("str-123", composedFn(123))
 
 }
+
+func TestComposeErr(t *testing.T) {
+	r := require.New(t)
+	nonNegative := func(i int) (uint, error) {
+		if i < 0 {
+			return 0, fmt.Errorf("%d is negative", i)
+		}
+		return uint(i), nil
+	}
+	parseAndCheck := ComposeErr(strconv.Atoi, nonNegative)
+
+	answer, err := parseAndCheck("123")
+	r.NoError(err)
+	r.Equal(uint(123), answer)
+	_, err = parseAndCheck("-1")
+	r.EqualError(err, "-1 is negative")
+	_, err = parseAndCheck("abc")
+	r.Error(err)
+
+	called := false
+	chain := ComposeErr3(strconv.Atoi, nonNegative, func(u uint) (string, error) {
+		called = true
+		return fmt.Sprintf("u%d", u), nil
+	})
+	s, err := chain("7")
+	r.NoError(err)
+	r.Equal("u7", s)
+	called = false
+	_, err = chain("-7")
+	r.Error(err)
+	r.False(called)
+}
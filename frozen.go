@@ -0,0 +1,113 @@
+package core
+
+import (
+	"iter"
+	"maps"
+	"slices"
+)
+
+// Frozen is a read-only slice. It's made by copying a slice once, with
+// Freeze, and after that it only has methods that read it, so it can be
+// handed to any number of goroutines, like ParMap workers, with a guarantee
+// from the compiler that none of them can change it. The zero value is an
+// empty Frozen.
+//
+// Like Tuple, a Frozen can be read but not changed. Elements are copied
+// shallowly, so if T holds pointers, slices or maps, the values they point to
+// can still be changed through them.
+//
+// Example usage:
+//
+//	rules := core.Freeze(loadRules())
+//	slice.ParMap(ctx, events, func(_ context.Context, _ uint, ev Event) (bool, error) {
+//		for _, rule := range rules.All() {
+//			if rule.Matches(ev) {
+//				return true, nil
+//			}
+//		}
+//		return false, nil
+//	})
+type Frozen[T any] struct {
+	items []T
+}
+
+// Freeze creates a Frozen holding a copy of slc
+func Freeze[T any](slc []T) Frozen[T] {
+	return Frozen[T]{items: slices.Clone(slc)}
+}
+
+// Len returns the number of elements in f
+func (f Frozen[T]) Len() int {
+	return len(f.items)
+}
+
+// At returns the element at index i. It panics if i is out of range
+func (f Frozen[T]) At(i int) T {
+	return f.items[i]
+}
+
+// All returns a sequence of the indices and elements of f, in order
+func (f Frozen[T]) All() iter.Seq2[int, T] {
+	return slices.All(f.items)
+}
+
+// Values returns a sequence of the elements of f, in order
+func (f Frozen[T]) Values() iter.Seq[T] {
+	return slices.Values(f.items)
+}
+
+// Clone returns a new slice holding the elements of f, which the caller is
+// free to modify
+func (f Frozen[T]) Clone() []T {
+	return slices.Clone(f.items)
+}
+
+// FrozenMap is a read-only map, the map counterpart of Frozen. It's made by
+// copying a map once, with FreezeMap, and after that it only has methods that
+// read it. The zero value is an empty FrozenMap
+type FrozenMap[K comparable, V any] struct {
+	m map[K]V
+}
+
+// FreezeMap creates a FrozenMap holding a copy of m
+func FreezeMap[K comparable, V any](m map[K]V) FrozenMap[K, V] {
+	return FrozenMap[K, V]{m: maps.Clone(m)}
+}
+
+// Len returns the number of entries in f
+func (f FrozenMap[K, V]) Len() int {
+	return len(f.m)
+}
+
+// Get returns the value for key k, and whether f has one
+func (f FrozenMap[K, V]) Get(k K) (V, bool) {
+	v, ok := f.m[k]
+	return v, ok
+}
+
+// Has returns whether f has a value for key k
+func (f FrozenMap[K, V]) Has(k K) bool {
+	_, ok := f.m[k]
+	return ok
+}
+
+// All returns a sequence of the entries in f, in no particular order
+func (f FrozenMap[K, V]) All() iter.Seq2[K, V] {
+	return maps.All(f.m)
+}
+
+// Keys returns a sequence of the keys in f, in no particular order
+func (f FrozenMap[K, V]) Keys() iter.Seq[K] {
+	return maps.Keys(f.m)
+}
+
+// Values returns a sequence of the values in f, in no particular order
+func (f FrozenMap[K, V]) Values() iter.Seq[V] {
+	return maps.Values(f.m)
+}
+
+// Clone returns a new map holding the entries of f, which the caller is free
+// to modify
+func (f FrozenMap[K, V]) Clone() map[K]V {
+	return maps.Clone(f.m)
+}
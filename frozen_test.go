@@ -0,0 +1,59 @@
+package core
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozen(t *testing.T) {
+	r := require.New(t)
+	var empty Frozen[int]
+	r.Equal(0, empty.Len())
+	r.Empty(slices.Collect(empty.Values()))
+
+	src := []int{1, 2, 3}
+	f := Freeze(src)
+	src[0] = 10
+	r.Equal(3, f.Len())
+	r.Equal(1, f.At(0))
+	r.Panics(func() { f.At(3) })
+	r.Equal([]int{1, 2, 3}, slices.Collect(f.Values()))
+	var idxs []int
+	for i := range f.All() {
+		idxs = append(idxs, i)
+	}
+	r.Equal([]int{0, 1, 2}, idxs)
+
+	clone := f.Clone()
+	clone[1] = 20
+	r.Equal(2, f.At(1))
+}
+
+func TestFrozenMap(t *testing.T) {
+	r := require.New(t)
+	var empty FrozenMap[string, int]
+	r.Equal(0, empty.Len())
+	_, ok := empty.Get("a")
+	r.False(ok)
+
+	src := map[string]int{"a": 1, "b": 2}
+	f := FreezeMap(src)
+	src["c"] = 3
+	r.Equal(2, f.Len())
+	v, ok := f.Get("b")
+	r.True(ok)
+	r.Equal(2, v)
+	r.True(f.Has("a"))
+	r.False(f.Has("c"))
+	r.ElementsMatch([]string{"a", "b"}, slices.Collect(f.Keys()))
+	r.ElementsMatch([]int{1, 2}, slices.Collect(f.Values()))
+	r.Equal(map[string]int{"a": 1, "b": 2}, maps.Collect(f.All()))
+
+	clone := f.Clone()
+	clone["a"] = 10
+	v, _ = f.Get("a")
+	r.Equal(1, v)
+}
@@ -0,0 +1,66 @@
+package fsx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/go-functional/core/errs"
+	"github.com/go-functional/core/par"
+	"github.com/go-functional/core/slice"
+)
+
+// ProcessFiles opens every file in paths and calls fn with its path and
+// contents, from at most workers goroutines at once. workers less than 1 is
+// treated as 1. Every file is closed once fn returns, whether or not it
+// succeeded.
+//
+// A failure on one file doesn't stop the others. ProcessFiles returns nil if
+// every file was processed, and otherwise a *errs.Multi holding every
+// failure, whether opening a file, fn or closing a file, keyed by its path,
+// in the order of paths. If ctx is done, files that haven't been opened yet
+// fail with ctx.Err().
+//
+// Example usage:
+//
+//	files, err := fsx.WalkFiles("logs", fsx.Ext(".log"))
+//	...
+//	err = fsx.ProcessFiles(ctx, files, 8, func(ctx context.Context, path string, r io.Reader) error {
+//		return index(ctx, path, r)
+//	})
+func ProcessFiles(
+	ctx context.Context,
+	paths []string,
+	workers int,
+	fn func(ctx context.Context, path string, r io.Reader) error,
+) error {
+	_, err := slice.ParMapPartial(ctx, paths, func(ctx context.Context, _ uint, path string) (struct{}, error) {
+		if err := ctx.Err(); err != nil {
+			return struct{}{}, err
+		}
+		return struct{}{}, processFile(ctx, path, fn)
+	}, par.Limit(max(workers, 1)))
+	var byIndex *errs.Multi
+	if !errors.As(err, &byIndex) {
+		return nil
+	}
+	var failed errs.Multi
+	for _, e := range byIndex.Errors() {
+		failed.Add(paths[e.Key.(uint)], e.Err)
+	}
+	return failed.Err()
+}
+
+func processFile(ctx context.Context, path string, fn func(context.Context, string, io.Reader) error) (err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); cerr != nil {
+			err = errors.Join(err, cerr)
+		}
+	}()
+	return fn(ctx, path, f)
+}
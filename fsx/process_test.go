@@ -0,0 +1,89 @@
+package fsx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-functional/core/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessFiles(t *testing.T) {
+	r := require.New(t)
+	root := makeTree(t, "a.txt", "b.txt", "c.txt", "d.txt")
+	paths, err := WalkFiles(root)
+	r.NoError(err)
+
+	var (
+		mut      sync.Mutex
+		contents = map[string]string{}
+		running  atomic.Int32
+		peak     atomic.Int32
+	)
+	err = ProcessFiles(context.Background(), paths, 2, func(_ context.Context, path string, rd io.Reader) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		b, err := io.ReadAll(rd)
+		if err != nil {
+			return err
+		}
+		mut.Lock()
+		defer mut.Unlock()
+		contents[filepath.Base(path)] = string(b)
+		return nil
+	})
+	r.NoError(err)
+	r.Equal(map[string]string{"a.txt": "a.txt", "b.txt": "b.txt", "c.txt": "c.txt", "d.txt": "d.txt"}, contents)
+	r.LessOrEqual(peak.Load(), int32(2))
+}
+
+func TestProcessFilesErrors(t *testing.T) {
+	r := require.New(t)
+	root := makeTree(t, "a.txt", "b.txt")
+	bad := errors.New("bad")
+	paths := []string{
+		filepath.Join(root, "a.txt"),
+		filepath.Join(root, "missing.txt"),
+		filepath.Join(root, "b.txt"),
+	}
+	var processed atomic.Int32
+	err := ProcessFiles(context.Background(), paths, 1, func(_ context.Context, path string, _ io.Reader) error {
+		processed.Add(1)
+		if filepath.Base(path) == "a.txt" {
+			return bad
+		}
+		return nil
+	})
+	r.Error(err)
+	r.ErrorIs(err, bad)
+	r.ErrorIs(err, fs.ErrNotExist)
+	var failed *errs.Multi
+	r.ErrorAs(err, &failed)
+	failures := failed.Errors()
+	r.Len(failures, 2)
+	r.Equal(paths[0], failures[0].Key)
+	r.Equal(paths[1], failures[1].Key)
+	r.Contains(err.Error(), "a.txt: bad")
+	r.Equal(int32(2), processed.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = ProcessFiles(ctx, paths, 1, func(context.Context, string, io.Reader) error {
+		return nil
+	})
+	r.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,171 @@
+// Package fsx integrates filesystem traversal and file processing with the
+// iterator and parallel helpers in this repository.
+package fsx
+
+import (
+	"io/fs"
+	"iter"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// WalkOption configures Walk and WalkFiles
+type WalkOption func(*walkConfig)
+
+type walkConfig struct {
+	exts     []string
+	maxDepth int
+	ignore   []string
+	onError  func(path string, err error) error
+}
+
+func newWalkConfig(opts []WalkOption) walkConfig {
+	cfg := walkConfig{
+		maxDepth: -1,
+		onError:  func(string, error) error { return nil },
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Ext limits the files that are yielded to the ones whose extension, like
+// ".go", is one of exts. Extensions are compared case-insensitively.
+// Directories are still walked, but not yielded
+func Ext(exts ...string) WalkOption {
+	return func(c *walkConfig) {
+		for _, e := range exts {
+			c.exts = append(c.exts, strings.ToLower(e))
+		}
+	}
+}
+
+// MaxDepth stops the walk from going more than n levels below the root. The
+// root's own entries are at depth 1. The default is no limit
+func MaxDepth(n int) WalkOption {
+	return func(c *walkConfig) {
+		c.maxDepth = n
+	}
+}
+
+// Ignore skips every file and directory whose base name matches any of the
+// patterns, using the syntax of filepath.Match. An ignored directory isn't
+// walked at all.
+//
+// Example usage:
+//
+//	fsx.Walk(root, fsx.Ignore(".git", "node_modules", "*.tmp"))
+func Ignore(patterns ...string) WalkOption {
+	return func(c *walkConfig) {
+		c.ignore = append(c.ignore, patterns...)
+	}
+}
+
+// OnError sets what happens when a directory can't be read. fn is called
+// with its path and the error. If fn returns nil, the directory is skipped
+// and the walk carries on; otherwise the walk stops, and WalkFiles returns
+// the error fn returned. By default, Walk skips unreadable directories and
+// WalkFiles stops at the first one
+func OnError(fn func(path string, err error) error) WalkOption {
+	return func(c *walkConfig) {
+		c.onError = fn
+	}
+}
+
+func (c walkConfig) ignored(name string) bool {
+	for _, p := range c.ignore {
+		if ok, _ := filepath.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c walkConfig) wantFile(d fs.DirEntry) bool {
+	if len(c.exts) == 0 {
+		return true
+	}
+	if d.IsDir() {
+		return false
+	}
+	return slices.Contains(c.exts, strings.ToLower(filepath.Ext(d.Name())))
+}
+
+// walk runs the walk, calling yield for every entry that passes the
+// filters, and returns the error that stopped it, if any
+func walk(root string, cfg walkConfig, yield func(string, fs.DirEntry) bool) error {
+	root = filepath.Clean(root)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if err := cfg.onError(path, err); err != nil {
+				return err
+			}
+			if d != nil && d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if path == root {
+			return nil
+		}
+		if cfg.ignored(d.Name()) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		depth := strings.Count(path[len(root):], string(filepath.Separator))
+		if root == string(filepath.Separator) || root == "." {
+			depth++
+		}
+		if cfg.maxDepth >= 0 && depth > cfg.maxDepth {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if cfg.wantFile(d) && !yield(path, d) {
+			return fs.SkipAll
+		}
+		return nil
+	})
+	return err
+}
+
+// Walk returns a sequence of the path and DirEntry of every file and
+// directory under root, in lexical order, not including root itself. The
+// tree is walked lazily, as the sequence is consumed, so stopping early
+// doesn't read the rest of it. Options filter what's yielded and walked.
+//
+// Example usage:
+//
+//	for path, d := range fsx.Walk("src", fsx.Ext(".go"), fsx.Ignore("vendor")) {
+//		fmt.Println(path, d.Type())
+//	}
+func Walk(root string, opts ...WalkOption) iter.Seq2[string, fs.DirEntry] {
+	cfg := newWalkConfig(opts)
+	return func(yield func(string, fs.DirEntry) bool) {
+		_ = walk(root, cfg, yield)
+	}
+}
+
+// WalkFiles returns the paths of every regular file under root that passes
+// the filters in opts, in lexical order, ready to hand to slice.ParMap or
+// ProcessFiles. Unless OnError says otherwise, it stops at the first
+// directory it can't read, and returns nil and the error
+func WalkFiles(root string, opts ...WalkOption) ([]string, error) {
+	cfg := newWalkConfig(append([]WalkOption{OnError(func(_ string, err error) error { return err })}, opts...))
+	var paths []string
+	err := walk(root, cfg, func(path string, d fs.DirEntry) bool {
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
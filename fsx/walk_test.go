@@ -0,0 +1,88 @@
+package fsx
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// makeTree creates files at the given slash-separated paths under a new
+// temporary directory, and returns the directory
+func makeTree(t *testing.T, paths ...string) string {
+	t.Helper()
+	root := t.TempDir()
+	for _, p := range paths {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, os.WriteFile(full, []byte(p), 0o644))
+	}
+	return root
+}
+
+func rel(t *testing.T, root string, paths []string) []string {
+	ret := make([]string, len(paths))
+	for i, p := range paths {
+		r, err := filepath.Rel(root, p)
+		require.NoError(t, err)
+		ret[i] = filepath.ToSlash(r)
+	}
+	return ret
+}
+
+func TestWalk(t *testing.T) {
+	r := require.New(t)
+	root := makeTree(t, "a.go", "b.txt", "sub/c.GO", "sub/deep/d.go", ".git/config")
+
+	var all []string
+	for p := range Walk(root) {
+		all = append(all, p)
+	}
+	r.Equal([]string{".git", ".git/config", "a.go", "b.txt", "sub", "sub/c.GO", "sub/deep", "sub/deep/d.go"}, rel(t, root, all))
+
+	var gos []string
+	for p, d := range Walk(root, Ext(".go"), Ignore(".*")) {
+		r.False(d.IsDir())
+		gos = append(gos, p)
+	}
+	r.Equal([]string{"a.go", "sub/c.GO", "sub/deep/d.go"}, rel(t, root, gos))
+
+	var shallow []string
+	for p := range Walk(root, MaxDepth(1)) {
+		shallow = append(shallow, p)
+	}
+	r.Equal([]string{".git", "a.go", "b.txt", "sub"}, rel(t, root, shallow))
+
+	count := 0
+	for range Walk(root) {
+		count++
+		break
+	}
+	r.Equal(1, count)
+}
+
+func TestWalkFiles(t *testing.T) {
+	r := require.New(t)
+	root := makeTree(t, "a.go", "sub/b.go", "sub/c.txt")
+	files, err := WalkFiles(root, Ext(".go"))
+	r.NoError(err)
+	r.Equal([]string{"a.go", "sub/b.go"}, rel(t, root, files))
+
+	files, err = WalkFiles(root)
+	r.NoError(err)
+	r.Len(files, 3)
+
+	_, err = WalkFiles(filepath.Join(root, "missing"))
+	r.True(errors.Is(err, fs.ErrNotExist))
+
+	var seen []string
+	_, err = WalkFiles(filepath.Join(root, "missing"), OnError(func(path string, err error) error {
+		seen = append(seen, path)
+		return nil
+	}))
+	r.NoError(err)
+	r.Len(seen, 1)
+}
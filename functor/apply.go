@@ -0,0 +1,98 @@
+package functor
+
+// MapAp applies the function in fns to the value in f at every key that's in
+// both of them, and returns the results at those keys. Keys that are in only
+// one of them are dropped.
+//
+// Together with MapValues, that makes MapFunctor an Apply, the applicative
+// structure minus a way to lift a single value, which a map can't have since
+// it would need a value for every possible key.
+//
+// Unlike the other operations, MapAp is only a function: as a method, its
+// parameter would be a MapFunctor of functions, whose own method would take
+// a MapFunctor of functions of functions, and so on forever, which Go
+// rejects.
+func MapAp[K comparable, V, U any](fns MapFunctor[K, func(V) U], f MapFunctor[K, V]) MapFunctor[K, U] {
+	return MapZipWith(fns, f, func(fn func(V) U, v V) U { return fn(v) })
+}
+
+// ZipWith combines f and other element-wise: at every key that's in both of
+// them, the result holds combine applied to f's value and other's value.
+// Keys that are in only one of them are dropped.
+//
+// MapZipWith is the function form, for when the two functors hold different
+// types.
+//
+// Example usage:
+//
+//	totals := stock.ZipWith(prices, func(count, price float64) float64 {
+//		return count * price
+//	})
+func (f MapFunctor[K, V]) ZipWith(other MapFunctor[K, V], combine func(V, V) V) MapFunctor[K, V] {
+	return MapZipWith(f, other, combine)
+}
+
+// MapZipWith returns a new MapFunctor holding combine applied to a's and b's
+// values, at every key that's in both a and b
+func MapZipWith[K comparable, A, B, C any](a MapFunctor[K, A], b MapFunctor[K, B], combine func(A, B) C) MapFunctor[K, C] {
+	// iterate over the smaller map, since only keys in both matter
+	ret := make(map[K]C, min(len(a.m), len(b.m)))
+	if len(a.m) <= len(b.m) {
+		for k, av := range a.m {
+			if bv, ok := b.m[k]; ok {
+				ret[k] = combine(av, bv)
+			}
+		}
+	} else {
+		for k, bv := range b.m {
+			if av, ok := a.m[k]; ok {
+				ret[k] = combine(av, bv)
+			}
+		}
+	}
+	return MapFunctor[K, C]{m: ret}
+}
+
+// SliceAp applies the functions in fns to the elements of s pairwise: the
+// first function to the first element, the second to the second, and so on,
+// stopping at the end of the shorter of the two.
+//
+// That makes Slice an Apply in the same element-wise sense as MapFunctor,
+// with indices in place of keys. Like MapAp, it's only a function, for the
+// same reason.
+//
+// Example usage:
+//
+//	adjusted := functor.SliceAp(functor.Slice[func(float64) float64]{
+//		math.Floor, math.Ceil,
+//	}, functor.Slice[float64]{1.5, 1.5})
+//	// adjusted is [1, 2]
+func SliceAp[T, U any](fns Slice[func(T) U], s Slice[T]) Slice[U] {
+	return SliceZipWith(fns, s, func(fn func(T) U, t T) U { return fn(t) })
+}
+
+// ZipWith combines s and other element-wise: the result holds combine
+// applied to the first element of each, then the second of each, and so on,
+// stopping at the end of the shorter of the two.
+//
+// SliceZipWith is the function form, for when the two slices hold different
+// types.
+//
+// Example usage:
+//
+//	totals := functor.Slice[float64](counts).ZipWith(prices, func(count, price float64) float64 {
+//		return count * price
+//	})
+func (s Slice[T]) ZipWith(other Slice[T], combine func(T, T) T) Slice[T] {
+	return SliceZipWith(s, other, combine)
+}
+
+// SliceZipWith returns a new Slice holding combine applied to the elements of
+// a and b at each index they both have
+func SliceZipWith[A, B, C any](a Slice[A], b Slice[B], combine func(A, B) C) Slice[C] {
+	ret := make(Slice[C], min(len(a), len(b)))
+	for i := range ret {
+		ret[i] = combine(a[i], b[i])
+	}
+	return ret
+}
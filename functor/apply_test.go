@@ -0,0 +1,65 @@
+package functor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAp(t *testing.T) {
+	r := require.New(t)
+	vals := LiftMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	fns := LiftMap(map[string]func(int) int{
+		"a": func(v int) int { return v + 10 },
+		"b": func(v int) int { return v * 10 },
+		"z": func(v int) int { return v },
+	})
+	r.Equal(map[string]int{"a": 11, "b": 20}, MapAp(fns, vals).ToMap())
+
+	// applying identity at every key leaves the shared keys unchanged
+	ids := MapValues(vals, func(int) func(int) int { return func(v int) int { return v } })
+	r.Equal(vals.ToMap(), MapAp(ids, vals).ToMap())
+
+	lens := MapAp(LiftMap(map[int]func(string) int{1: func(s string) int { return len(s) }}),
+		LiftMap(map[int]string{1: "four", 2: "x"}))
+	r.Equal(map[int]int{1: 4}, lens.ToMap())
+}
+
+func TestMapZipWith(t *testing.T) {
+	r := require.New(t)
+	stock := LiftMap(map[string]float64{"apple": 3, "pear": 2, "fig": 1})
+	prices := LiftMap(map[string]float64{"apple": 0.5, "pear": 1.5})
+	mul := func(a, b float64) float64 { return a * b }
+	r.Equal(map[string]float64{"apple": 1.5, "pear": 3}, stock.ZipWith(prices, mul).ToMap())
+	r.Equal(map[string]float64{"apple": 1.5, "pear": 3}, prices.ZipWith(stock, mul).ToMap())
+
+	labels := MapZipWith(LiftMap(map[int]string{1: "x"}), LiftMap(map[int]int{1: 3}), strings.Repeat)
+	r.Equal(map[int]string{1: "xxx"}, labels.ToMap())
+}
+
+func TestSliceAp(t *testing.T) {
+	r := require.New(t)
+	fns := Slice[func(int) int]{
+		func(v int) int { return v + 10 },
+		func(v int) int { return v * 10 },
+	}
+	r.Equal(Slice[int]{11, 20}, SliceAp(fns, Slice[int]{1, 2, 3}))
+	r.Equal(Slice[int]{11}, SliceAp(fns, Slice[int]{1}))
+
+	lens := SliceAp(Slice[func(string) int]{func(s string) int { return len(s) }}, Slice[string]{"four"})
+	r.Equal(Slice[int]{4}, lens)
+}
+
+func TestSliceZipWith(t *testing.T) {
+	r := require.New(t)
+	mul := func(a, b float64) float64 { return a * b }
+	counts := Slice[float64]{3, 2, 1}
+	prices := Slice[float64]{0.5, 1.5}
+	r.Equal(Slice[float64]{1.5, 3}, counts.ZipWith(prices, mul))
+	r.Equal(Slice[float64]{1.5, 3}, prices.ZipWith(counts, mul))
+	r.Empty(counts.ZipWith(nil, mul))
+
+	labels := SliceZipWith(Slice[string]{"x", "y"}, Slice[int]{3, 1}, strings.Repeat)
+	r.Equal(Slice[string]{"xxx", "y"}, labels)
+}
@@ -0,0 +1,58 @@
+package functor
+
+import (
+	"context"
+
+	"github.com/go-functional/core/par"
+)
+
+// checkpointEvery is how many elements MapChunked maps between checks of
+// its context. Checking is cheap next to a call to fn, but not free, so it
+// isn't done for every element
+const checkpointEvery = 4096
+
+// MapChunked returns a new Slice holding fn applied to every element of s.
+// s is split into one contiguous chunk per worker with par.DoChunks, and the
+// chunks are mapped in parallel. It's meant for huge slices of cheap
+// elements, where scheduling every element as its own call, like Slice.MapCtx
+// does, would cost more than the calls themselves.
+//
+// Every chunk checks ctx every few thousand elements, so even a slice of
+// tens of millions of elements stops promptly when ctx is done, in which
+// case it returns nil and ctx.Err(). opts are passed to DoChunks, so
+// par.Limit sets the number of chunks. DoChunks runs each chunk as one call
+// of par.Do, so options that act on calls, like par.ElementTimeout,
+// par.Logger and par.Adaptive, apply to whole chunks, not single elements.
+//
+// SliceMapChunked is the function form, for when fn changes the type of the
+// elements.
+//
+// Example usage:
+//
+//	scaled, err := functor.Slice[float64](samples).MapChunked(ctx, func(x float64) float64 {
+//		return x * gain
+//	})
+func (s Slice[T]) MapChunked(ctx context.Context, fn func(T) T, opts ...par.Option) (Slice[T], error) {
+	return SliceMapChunked(ctx, s, fn, opts...)
+}
+
+// SliceMapChunked returns a new Slice holding fn applied to every element of s,
+// mapping contiguous chunks of s in parallel
+func SliceMapChunked[T, U any](ctx context.Context, s Slice[T], fn func(T) U, opts ...par.Option) (Slice[U], error) {
+	ret := make(Slice[U], len(s))
+	err := par.DoChunks(ctx, len(s), func(ctx context.Context, lo, hi int) error {
+		for i := lo; i < hi; i++ {
+			if (i-lo)%checkpointEvery == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			ret[i] = fn(s[i])
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
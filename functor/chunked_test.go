@@ -0,0 +1,52 @@
+package functor
+
+import (
+	"context"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapChunked(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	in := make(Slice[int], 100_000)
+	for i := range in {
+		in[i] = i
+	}
+	doubled, err := in.MapChunked(ctx, func(i int) int { return i * 2 })
+	r.NoError(err)
+	for i, v := range doubled {
+		r.Equal(i*2, v)
+	}
+
+	strs, err := SliceMapChunked(ctx, in[:3], strconv.Itoa, par.Limit(2))
+	r.NoError(err)
+	r.Equal(Slice[string]{"0", "1", "2"}, strs)
+
+	empty, err := SliceMapChunked(ctx, Slice[int](nil), strconv.Itoa)
+	r.NoError(err)
+	r.Empty(empty)
+}
+
+func TestMapChunkedCancel(t *testing.T) {
+	r := require.New(t)
+	const n = 10_000_000
+	in := make(Slice[int], n)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var calls atomic.Int64
+	out, err := in.MapChunked(ctx, func(i int) int {
+		if calls.Add(1) == 10_000 {
+			cancel()
+		}
+		return i
+	}, par.Limit(4))
+	r.ErrorIs(err, context.Canceled)
+	r.Nil(out)
+	// every chunk stops at its next checkpoint, long before the end
+	r.Less(calls.Load(), int64(10_000+4*checkpointEvery))
+}
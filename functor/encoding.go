@@ -0,0 +1,88 @@
+package functor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// MarshalJSON encodes f as a JSON object of its entries, so K must be a type
+// encoding/json accepts as an object key, like a string or an integer. An
+// empty MapFunctor encodes as {}
+func (f MapFunctor[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.ToMap())
+}
+
+// UnmarshalJSON replaces f's entries with the ones in data, a JSON object as
+// written by MarshalJSON
+func (f *MapFunctor[K, V]) UnmarshalJSON(data []byte) error {
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	f.m = m
+	return nil
+}
+
+// MarshalBinary encodes f's entries with encoding/gob. It also makes
+// MapFunctor itself encodable with gob, which can't see its unexported map
+func (f MapFunctor[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(f.ToMap()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces f's entries with the ones in data, as written by
+// MarshalBinary
+func (f *MapFunctor[K, V]) UnmarshalBinary(data []byte) error {
+	var m map[K]V
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
+		return err
+	}
+	f.m = m
+	return nil
+}
+
+// MarshalJSON encodes s as a JSON array. Unlike a plain nil slice, a nil
+// Slice encodes as [], the same as an empty one
+func (s Slice[T]) MarshalJSON() ([]byte, error) {
+	// []T has no methods, so this doesn't call MarshalJSON again
+	if s == nil {
+		return json.Marshal([]T{})
+	}
+	return json.Marshal([]T(s))
+}
+
+// UnmarshalJSON replaces the elements of s with the ones in data, a JSON
+// array as written by MarshalJSON
+func (s *Slice[T]) UnmarshalJSON(data []byte) error {
+	var ts []T
+	if err := json.Unmarshal(data, &ts); err != nil {
+		return err
+	}
+	*s = ts
+	return nil
+}
+
+// MarshalBinary encodes the elements of s with encoding/gob
+func (s Slice[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	// gob would call MarshalBinary on a Slice, so encode the plain []T
+	if err := gob.NewEncoder(&buf).Encode([]T(s)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary replaces the elements of s with the ones in data, as
+// written by MarshalBinary
+func (s *Slice[T]) UnmarshalBinary(data []byte) error {
+	var ts []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ts); err != nil {
+		return err
+	}
+	*s = ts
+	return nil
+}
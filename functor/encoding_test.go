@@ -0,0 +1,75 @@
+package functor
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFunctorEncoding(t *testing.T) {
+	r := require.New(t)
+	f := LiftMap(map[string]int{"a": 1, "b": 2})
+
+	data, err := json.Marshal(f)
+	r.NoError(err)
+	r.JSONEq(`{"a": 1, "b": 2}`, string(data))
+	var fromJSON MapFunctor[string, int]
+	r.NoError(json.Unmarshal(data, &fromJSON))
+	r.Equal(f.ToMap(), fromJSON.ToMap())
+	r.Error(json.Unmarshal([]byte(`[1]`), &fromJSON))
+
+	data, err = json.Marshal(MapFunctor[string, int]{})
+	r.NoError(err)
+	r.Equal(`{}`, string(data))
+
+	data, err = f.MarshalBinary()
+	r.NoError(err)
+	var fromBinary MapFunctor[string, int]
+	r.NoError(fromBinary.UnmarshalBinary(data))
+	r.Equal(f.ToMap(), fromBinary.ToMap())
+	r.Error(fromBinary.UnmarshalBinary([]byte("junk")))
+
+	// MarshalBinary lets gob encode a MapFunctor inside other values
+	type cached struct {
+		Prices MapFunctor[string, float64]
+	}
+	var buf bytes.Buffer
+	in := cached{Prices: LiftMap(map[string]float64{"pear": 2})}
+	r.NoError(gob.NewEncoder(&buf).Encode(in))
+	var out cached
+	r.NoError(gob.NewDecoder(&buf).Decode(&out))
+	r.Equal(in.Prices.ToMap(), out.Prices.ToMap())
+}
+
+func TestSliceEncoding(t *testing.T) {
+	r := require.New(t)
+	s := Slice[int]{3, 1, 2}
+
+	data, err := json.Marshal(s)
+	r.NoError(err)
+	r.Equal(`[3,1,2]`, string(data))
+	var fromJSON Slice[int]
+	r.NoError(json.Unmarshal(data, &fromJSON))
+	r.Equal(s, fromJSON)
+	r.Error(json.Unmarshal([]byte(`{}`), &fromJSON))
+
+	data, err = json.Marshal(Slice[int](nil))
+	r.NoError(err)
+	r.Equal(`[]`, string(data))
+
+	data, err = s.MarshalBinary()
+	r.NoError(err)
+	var fromBinary Slice[int]
+	r.NoError(fromBinary.UnmarshalBinary(data))
+	r.Equal(s, fromBinary)
+	r.Error(fromBinary.UnmarshalBinary([]byte("junk")))
+
+	var buf bytes.Buffer
+	r.NoError(gob.NewEncoder(&buf).Encode(s))
+	var viaGob Slice[int]
+	r.NoError(gob.NewDecoder(&buf).Decode(&viaGob))
+	r.Equal(s, viaGob)
+}
@@ -0,0 +1,103 @@
+package functor
+
+import "github.com/go-functional/core/constraints"
+
+// Foldable is a container whose elements can be visited one at a time. Fold
+// calls yield with every element, in the container's order, until yield
+// returns false, so a Foldable's Fold method is an iter.Seq. Every fold,
+// like Sum or ToSlice, can be written in terms of it, so code written
+// against Foldable works with any container.
+//
+// MapFunctor, Slice, and the List and Vector types in package persistent are
+// all Foldable.
+type Foldable[T any] interface {
+	Fold(yield func(T) bool)
+	Len() int
+}
+
+// Traversable is a Foldable that can also be rebuilt by running a fallible
+// function on every element. MapErr returns a container of the same shape,
+// of type Self, holding the results, or the first error. Go has no way to
+// say "the same container type" in an interface, so Self says it instead:
+// MapFunctor[K, V] is a Traversable[V, MapFunctor[K, V]].
+type Traversable[T, Self any] interface {
+	Foldable[T]
+	MapErr(fn func(T) (T, error)) (Self, error)
+}
+
+// FoldLeft combines every element of f into an accumulator, starting with
+// init, by calling fn with the accumulator so far and the next element
+func FoldLeft[T, A any](f Foldable[T], init A, fn func(A, T) A) A {
+	acc := init
+	f.Fold(func(t T) bool {
+		acc = fn(acc, t)
+		return true
+	})
+	return acc
+}
+
+// Sum returns the sum of every element of f, or 0 if it's empty
+func Sum[N constraints.Number](f Foldable[N]) N {
+	return FoldLeft(f, N(0), func(acc, n N) N { return acc + n })
+}
+
+// ToSlice returns every element of f in a new slice, in f's order
+func ToSlice[T any](f Foldable[T]) []T {
+	ret := make([]T, 0, f.Len())
+	f.Fold(func(t T) bool {
+		ret = append(ret, t)
+		return true
+	})
+	return ret
+}
+
+// All returns true if pred returns true for every element of f, and stops
+// at the first element it returns false for. It returns true if f is empty
+func All[T any](f Foldable[T], pred func(T) bool) bool {
+	ret := true
+	f.Fold(func(t T) bool {
+		ret = pred(t)
+		return ret
+	})
+	return ret
+}
+
+// Fold calls yield with every value in f, in map iteration order, until
+// yield returns false
+func (f MapFunctor[K, V]) Fold(yield func(V) bool) {
+	for _, v := range f.m {
+		if !yield(v) {
+			return
+		}
+	}
+}
+
+// Slice is a slice that's Foldable and Traversable, so plain slices can be
+// passed to code written against those interfaces with a conversion, like
+// functor.Sum(functor.Slice[int](nums)). The conversion doesn't copy, so
+// unlike MapFunctor, a Slice isn't immutable; see the package documentation
+type Slice[T any] []T
+
+// Len returns the number of elements in s
+func (s Slice[T]) Len() int {
+	return len(s)
+}
+
+// Fold calls yield with every element of s, in order, until yield returns
+// false
+func (s Slice[T]) Fold(yield func(T) bool) {
+	for _, t := range s {
+		if !yield(t) {
+			return
+		}
+	}
+}
+
+// MapErr returns a new Slice holding fn applied to every element of s, in
+// order, or nil and the first error fn returns.
+//
+// SliceMapErr is the function form, for when fn changes the type of the
+// elements
+func (s Slice[T]) MapErr(fn func(T) (T, error)) (Slice[T], error) {
+	return SliceMapErr(s, fn)
+}
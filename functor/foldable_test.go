@@ -0,0 +1,68 @@
+package functor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/persistent"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ Traversable[int, MapFunctor[string, int]] = MapFunctor[string, int]{}
+	_ Traversable[int, Slice[int]]              = Slice[int]{}
+	_ Traversable[int, persistent.List[int]]    = persistent.List[int]{}
+	_ Traversable[int, persistent.Vector[int]]  = persistent.Vector[int]{}
+)
+
+func TestFoldable(t *testing.T) {
+	r := require.New(t)
+	containers := []Foldable[int]{
+		Slice[int]{1, 2, 3, 4},
+		LiftMap(map[string]int{"a": 1, "b": 2, "c": 3, "d": 4}),
+		persistent.NewList(1, 2, 3, 4),
+		persistent.NewVector(1, 2, 3, 4),
+	}
+	for _, c := range containers {
+		r.Equal(10, Sum(c))
+		r.ElementsMatch([]int{1, 2, 3, 4}, ToSlice(c))
+		r.True(All(c, func(i int) bool { return i > 0 }))
+		r.False(All(c, func(i int) bool { return i < 3 }))
+		r.Equal(24, FoldLeft(c, 1, func(acc, i int) int { return acc * i }))
+	}
+	r.Equal([]int{1, 2, 3, 4}, ToSlice(containers[2]))
+	r.True(All(Slice[int](nil), func(int) bool { return false }))
+	r.Equal(0, Sum[int](Slice[int](nil)))
+
+	calls := 0
+	All(Slice[int]{1, 2, 3}, func(i int) bool {
+		calls++
+		return i < 2
+	})
+	r.Equal(2, calls)
+}
+
+// positive rejects non-positive values, and works on any Traversable of ints
+func positive[S any](t Traversable[int, S]) (S, error) {
+	return t.MapErr(func(i int) (int, error) {
+		if i <= 0 {
+			return 0, errors.New("not positive")
+		}
+		return i, nil
+	})
+}
+
+func TestTraversable(t *testing.T) {
+	r := require.New(t)
+	s, err := positive(Slice[int]{1, 2})
+	r.NoError(err)
+	r.Equal(Slice[int]{1, 2}, s)
+	_, err = positive(Slice[int]{1, -2})
+	r.Error(err)
+
+	v, err := positive(persistent.NewVector(3, 4))
+	r.NoError(err)
+	r.Equal([]int{3, 4}, v.ToSlice())
+	_, err = positive(LiftMap(map[string]int{"a": 0}))
+	r.Error(err)
+}
@@ -0,0 +1,100 @@
+// Package functor provides functor containers: wrappers around collections
+// that can be mapped over, one element at a time, without changing their
+// shape.
+//
+// Every functor here obeys the functor laws: mapping the identity function
+// returns an equal container, and mapping f after g is the same as mapping
+// their composition. laws.CheckFunctor checks both.
+//
+// Every operation returns a new container and leaves the original alone.
+// MapFunctor is also immutable, since its map is unexported and copied on
+// the way in and out, so it's safe to share between goroutines. Slice isn't:
+// it's a named slice type, so that plain slices convert to it without a
+// copy, which means its elements can still be assigned through an index.
+// Share a Slice between goroutines only if nothing writes to it.
+//
+// Operations are methods on each container. Go methods can't introduce new
+// type parameters, so the ones that can change the element type also have a
+// function form, named for its container, like MapMapErr and SliceMapErr.
+package functor
+
+import (
+	"iter"
+	"maps"
+)
+
+// MapFunctor is a functor backed by a map, where mapping transforms the
+// values and leaves the keys alone. The zero value is an empty MapFunctor.
+//
+// Example usage:
+//
+//	prices := functor.LiftMap(map[string]float64{"apple": 1.25, "pear": 2})
+//	discounted := prices.
+//		FilterKeys(func(k string) bool { return k != "pear" }).
+//		MapValues(func(p float64) float64 { return p * 0.9 })
+type MapFunctor[K comparable, V any] struct {
+	m map[K]V
+}
+
+// LiftMap creates a MapFunctor holding the entries of m. m is copied, so
+// changing it afterwards doesn't change the MapFunctor
+func LiftMap[K comparable, V any](m map[K]V) MapFunctor[K, V] {
+	return MapFunctor[K, V]{m: maps.Clone(m)}
+}
+
+// Len returns the number of entries in f
+func (f MapFunctor[K, V]) Len() int {
+	return len(f.m)
+}
+
+// Get returns the value for k and true if f has an entry for k, and the zero
+// value and false otherwise
+func (f MapFunctor[K, V]) Get(k K) (V, bool) {
+	v, ok := f.m[k]
+	return v, ok
+}
+
+// MapValues returns a new MapFunctor with the same keys as f, holding fn
+// applied to each of f's values.
+//
+// MapValues is also a function, for when fn changes the type of the values;
+// Go methods can't introduce new type parameters.
+func (f MapFunctor[K, V]) MapValues(fn func(V) V) MapFunctor[K, V] {
+	return MapValues(f, fn)
+}
+
+// MapValues returns a new MapFunctor with the same keys as f, holding fn
+// applied to each of f's values
+func MapValues[K comparable, V, U any](f MapFunctor[K, V], fn func(V) U) MapFunctor[K, U] {
+	ret := make(map[K]U, len(f.m))
+	for k, v := range f.m {
+		ret[k] = fn(v)
+	}
+	return MapFunctor[K, U]{m: ret}
+}
+
+// FilterKeys returns a new MapFunctor holding only the entries of f whose
+// keys pred returns true for
+func (f MapFunctor[K, V]) FilterKeys(pred func(K) bool) MapFunctor[K, V] {
+	ret := make(map[K]V, len(f.m))
+	for k, v := range f.m {
+		if pred(k) {
+			ret[k] = v
+		}
+	}
+	return MapFunctor[K, V]{m: ret}
+}
+
+// Entries returns a sequence of every entry in f. The order is unspecified,
+// since map iteration order is
+func (f MapFunctor[K, V]) Entries() iter.Seq2[K, V] {
+	return maps.All(f.m)
+}
+
+// ToMap returns a copy of f's entries as a plain map
+func (f MapFunctor[K, V]) ToMap() map[K]V {
+	if f.m == nil {
+		return map[K]V{}
+	}
+	return maps.Clone(f.m)
+}
@@ -0,0 +1,46 @@
+package functor
+
+import (
+	"maps"
+	"strconv"
+	"testing"
+
+	"github.com/go-functional/core/gen"
+	"github.com/go-functional/core/laws"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFunctor(t *testing.T) {
+	r := require.New(t)
+	src := map[string]int{"a": 1, "b": 2, "c": 3}
+	f := LiftMap(src)
+	src["d"] = 4
+	r.Equal(3, f.Len())
+
+	doubled := f.MapValues(func(v int) int { return v * 2 })
+	r.Equal(map[string]int{"a": 2, "b": 4, "c": 6}, doubled.ToMap())
+	r.Equal(map[string]int{"a": 1, "b": 2, "c": 3}, f.ToMap())
+
+	strs := MapValues(f, strconv.Itoa)
+	v, ok := strs.Get("b")
+	r.True(ok)
+	r.Equal("2", v)
+	_, ok = strs.Get("z")
+	r.False(ok)
+
+	noB := f.FilterKeys(func(k string) bool { return k != "b" })
+	r.Equal(map[string]int{"a": 1, "c": 3}, maps.Collect(noB.Entries()))
+
+	var zero MapFunctor[string, int]
+	r.Equal(0, zero.Len())
+	r.Equal(map[string]int{}, zero.ToMap())
+	r.Equal(0, zero.MapValues(func(v int) int { return v }).Len())
+}
+
+func TestMapFunctorLaws(t *testing.T) {
+	g := gen.Map(gen.MapOf(gen.String(4), gen.Int(-100, 100), 10), LiftMap[string, int])
+	laws.CheckFunctor(t, MapFunctor[string, int].MapValues, g,
+		func(v int) int { return v * 2 },
+		func(v int) int { return v - 7 },
+	)
+}
@@ -0,0 +1,83 @@
+package functor
+
+import (
+	"context"
+
+	"github.com/go-functional/core/mapx"
+	"github.com/go-functional/core/par"
+	"github.com/go-functional/core/slice"
+)
+
+// MapCtx is the parallel counterpart of MapFunctor.MapErr. It calls fn on every value of
+// f in parallel, using mapx.ParMapMap, so it has the same cancellation
+// semantics and accepts the same options: fn is passed a context derived from
+// ctx, which is cancelled as soon as any call fails, and MapCtx returns the
+// first error along with an empty MapFunctor.
+//
+// MapMapCtx is the function form, for when fn changes the type of the
+// values.
+//
+// Example usage:
+//
+//	profiles, err := functor.MapMapCtx(ctx, users, fetchProfile, par.Limit(8))
+func (f MapFunctor[K, V]) MapCtx(
+	ctx context.Context,
+	fn func(context.Context, V) (V, error),
+	opts ...par.Option,
+) (MapFunctor[K, V], error) {
+	return MapMapCtx(ctx, f, fn, opts...)
+}
+
+// MapMapCtx returns a new MapFunctor with the same keys as f, holding fn
+// applied to each of f's values, with the calls to fn made in parallel
+func MapMapCtx[K comparable, V, U any](
+	ctx context.Context,
+	f MapFunctor[K, V],
+	fn func(context.Context, V) (U, error),
+	opts ...par.Option,
+) (MapFunctor[K, U], error) {
+	m, err := mapx.ParMapMap(ctx, f.m, func(ctx context.Context, _ K, v V) (U, error) {
+		return fn(ctx, v)
+	}, opts...)
+	if err != nil {
+		return MapFunctor[K, U]{}, err
+	}
+	return MapFunctor[K, U]{m: m}, nil
+}
+
+// MapCtx is the parallel counterpart of Slice.MapErr. It calls fn on every
+// element of s in parallel, using slice.ParMap, so it has the same
+// cancellation semantics and accepts the same options: fn is passed a
+// context derived from ctx, which is cancelled as soon as any call fails, and
+// MapCtx returns nil and the first error. The results stay in element order.
+//
+// SliceMapCtx is the function form, for when fn changes the type of the
+// elements.
+//
+// Example usage:
+//
+//	profiles, err := functor.Slice[User](users).MapCtx(ctx, refreshProfile, par.Limit(8))
+func (s Slice[T]) MapCtx(
+	ctx context.Context,
+	fn func(context.Context, T) (T, error),
+	opts ...par.Option,
+) (Slice[T], error) {
+	return SliceMapCtx(ctx, s, fn, opts...)
+}
+
+// SliceMapCtx returns a new Slice holding fn applied to every element of s,
+// in order, with the calls to fn made in parallel
+func SliceMapCtx[T, U any](
+	ctx context.Context,
+	s Slice[T],
+	fn func(context.Context, T) (U, error),
+	opts ...par.Option,
+) (Slice[U], error) {
+	ret, err := slice.ParMap(ctx, s, func(ctx context.Context, _ uint, t T) (U, error) {
+		return fn(ctx, t)
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
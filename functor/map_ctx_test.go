@@ -0,0 +1,60 @@
+package functor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapCtx(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	f := LiftMap(map[int]int{1: 1, 2: 2, 3: 3})
+	sq, err := f.MapCtx(ctx, func(_ context.Context, v int) (int, error) { return v * v, nil }, par.Limit(2))
+	r.NoError(err)
+	r.Equal(map[int]int{1: 1, 2: 4, 3: 9}, sq.ToMap())
+
+	boom := errors.New("boom")
+	out, err := MapMapCtx(ctx, f, func(ctx context.Context, v int) (string, error) {
+		if v == 2 {
+			return "", boom
+		}
+		<-ctx.Done()
+		return "", nil
+	})
+	r.ErrorIs(err, boom)
+	r.Equal(0, out.Len())
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = f.MapCtx(cancelled, func(_ context.Context, v int) (int, error) { return v, nil }, par.Limit(1))
+	r.ErrorIs(err, context.Canceled)
+}
+
+func TestSliceMapCtx(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	s := Slice[int]{1, 2, 3}
+	sq, err := s.MapCtx(ctx, func(_ context.Context, v int) (int, error) { return v * v, nil }, par.Limit(2))
+	r.NoError(err)
+	r.Equal(Slice[int]{1, 4, 9}, sq)
+
+	boom := errors.New("boom")
+	out, err := SliceMapCtx(ctx, s, func(ctx context.Context, v int) (string, error) {
+		if v == 2 {
+			return "", boom
+		}
+		<-ctx.Done()
+		return "", nil
+	})
+	r.ErrorIs(err, boom)
+	r.Nil(out)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = s.MapCtx(cancelled, func(_ context.Context, v int) (int, error) { return v, nil }, par.Limit(1))
+	r.ErrorIs(err, context.Canceled)
+}
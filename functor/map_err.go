@@ -0,0 +1,50 @@
+package functor
+
+// MapErr is the same as MapValues, except fn can fail. If fn returns an
+// error for any value, MapErr stops and returns an empty MapFunctor and that
+// error. Values are visited in map iteration order, so if fn would fail for
+// more than one of them, which error is returned is unspecified.
+//
+// MapMapErr is the function form, for when fn changes the type of the
+// values.
+//
+// Example usage:
+//
+//	ports, err := functor.MapMapErr(rawPorts, strconv.Atoi)
+func (f MapFunctor[K, V]) MapErr(fn func(V) (V, error)) (MapFunctor[K, V], error) {
+	return MapMapErr(f, fn)
+}
+
+// MapMapErr returns a new MapFunctor with the same keys as f, holding fn
+// applied to each of f's values, or an empty MapFunctor and the error if fn
+// fails for any of them
+func MapMapErr[K comparable, V, U any](f MapFunctor[K, V], fn func(V) (U, error)) (MapFunctor[K, U], error) {
+	ret := make(map[K]U, len(f.m))
+	for k, v := range f.m {
+		u, err := fn(v)
+		if err != nil {
+			return MapFunctor[K, U]{}, err
+		}
+		ret[k] = u
+	}
+	return MapFunctor[K, U]{m: ret}, nil
+}
+
+// SliceMapErr returns a new Slice holding fn applied to every element of s,
+// in order, or nil and the first error fn returns. fn isn't called on the
+// elements after the one that fails.
+//
+// Example usage:
+//
+//	ports, err := functor.SliceMapErr(functor.Slice[string](rawPorts), strconv.Atoi)
+func SliceMapErr[T, U any](s Slice[T], fn func(T) (U, error)) (Slice[U], error) {
+	ret := make(Slice[U], len(s))
+	for i, t := range s {
+		u, err := fn(t)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = u
+	}
+	return ret, nil
+}
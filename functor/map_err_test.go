@@ -0,0 +1,57 @@
+package functor
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapErr(t *testing.T) {
+	r := require.New(t)
+	raw := LiftMap(map[string]string{"http": "80", "https": "443"})
+	ports, err := MapMapErr(raw, strconv.Atoi)
+	r.NoError(err)
+	r.Equal(map[string]int{"http": 80, "https": 443}, ports.ToMap())
+
+	bad := LiftMap(map[string]string{"http": "80", "oops": "x"})
+	ports, err = MapMapErr(bad, strconv.Atoi)
+	var numErr *strconv.NumError
+	r.ErrorAs(err, &numErr)
+	r.Equal(0, ports.Len())
+
+	boom := errors.New("boom")
+	nums := LiftMap(map[string]int{"a": 1, "b": -1})
+	_, err = nums.MapErr(func(v int) (int, error) {
+		if v < 0 {
+			return 0, boom
+		}
+		return v, nil
+	})
+	r.ErrorIs(err, boom)
+	abs, err := nums.MapErr(func(v int) (int, error) { return max(v, -v), nil })
+	r.NoError(err)
+	r.Equal(map[string]int{"a": 1, "b": 1}, abs.ToMap())
+}
+
+func TestSliceMapErr(t *testing.T) {
+	r := require.New(t)
+	ports, err := SliceMapErr(Slice[string]{"80", "443"}, strconv.Atoi)
+	r.NoError(err)
+	r.Equal(Slice[int]{80, 443}, ports)
+
+	calls := 0
+	ports, err = SliceMapErr(Slice[string]{"80", "x", "443"}, func(s string) (int, error) {
+		calls++
+		return strconv.Atoi(s)
+	})
+	var numErr *strconv.NumError
+	r.ErrorAs(err, &numErr)
+	r.Nil(ports)
+	r.Equal(2, calls)
+
+	doubled, err := Slice[int]{1, 2}.MapErr(func(v int) (int, error) { return v * 2, nil })
+	r.NoError(err)
+	r.Equal(Slice[int]{2, 4}, doubled)
+}
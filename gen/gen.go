@@ -0,0 +1,149 @@
+// Package gen generates random values for property-based and fuzz tests of
+// code built on this repository.
+//
+// A Gen is a function from a source of randomness to a value, so generators
+// compose by calling each other, and plug straight into the checkers in
+// package laws.
+//
+// Example usage:
+//
+//	users := gen.SliceOf(gen.Map(gen.String(12), newUser), 0, 50)
+//	laws.CheckMonoid(t, rosterMonoid, users)
+package gen
+
+import (
+	"crypto/sha256"
+	"math/rand/v2"
+
+	core "github.com/go-functional/core"
+	"github.com/go-functional/core/constraints"
+)
+
+// Gen generates random values of type T
+type Gen[T any] func(*rand.Rand) T
+
+// Sample returns n values from g, generated with a fixed seed, which is
+// handy for checking what a generator produces
+func Sample[T any](g Gen[T], n int, seed uint64) []T {
+	r := rand.New(rand.NewPCG(seed, seed))
+	ret := make([]T, n)
+	for i := range ret {
+		ret[i] = g(r)
+	}
+	return ret
+}
+
+// FromBytes returns a source of randomness seeded by data, so fuzz tests can
+// turn the bytes the fuzzer gives them into structured values. The same data
+// always produces the same values
+func FromBytes(data []byte) *rand.Rand {
+	return rand.New(rand.NewChaCha8(sha256.Sum256(data)))
+}
+
+// Const returns a Gen that always generates t
+func Const[T any](t T) Gen[T] {
+	return func(*rand.Rand) T { return t }
+}
+
+// Int returns a Gen of integers from lo up to, but not including, hi. It
+// panics if hi <= lo
+func Int[N constraints.Integer](lo, hi N) Gen[N] {
+	if hi <= lo {
+		panic("gen: Int called with an empty range")
+	}
+	// work in uint64, whose wraparound gives the two's-complement difference,
+	// so ranges wider than N's max, like [-100, 100) for int8, don't overflow
+	span := uint64(hi) - uint64(lo)
+	return func(r *rand.Rand) N {
+		return N(uint64(lo) + r.Uint64N(span))
+	}
+}
+
+// Float returns a Gen of floats from lo up to, but not including, hi
+func Float[F constraints.Float](lo, hi F) Gen[F] {
+	return func(r *rand.Rand) F {
+		return lo + F(r.Float64())*(hi-lo)
+	}
+}
+
+// Bool returns a Gen of booleans, each equally likely
+func Bool() Gen[bool] {
+	return func(r *rand.Rand) bool { return r.IntN(2) == 0 }
+}
+
+// alphabet is the set of runes String picks from. It includes multi-byte
+// runes, so code that assumes one byte per character gets caught
+var alphabet = []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789 _-éß日本")
+
+// String returns a Gen of strings of up to maxLen runes
+func String(maxLen int) Gen[string] {
+	return func(r *rand.Rand) string {
+		runes := make([]rune, r.IntN(max(maxLen, 0)+1))
+		for i := range runes {
+			runes[i] = alphabet[r.IntN(len(alphabet))]
+		}
+		return string(runes)
+	}
+}
+
+// OneOf returns a Gen that picks one of vals, each equally likely. It panics
+// if vals is empty
+func OneOf[T any](vals ...T) Gen[T] {
+	if len(vals) == 0 {
+		panic("gen: OneOf called with no values")
+	}
+	return func(r *rand.Rand) T { return vals[r.IntN(len(vals))] }
+}
+
+// Map returns a Gen that generates a value with g and transforms it with fn
+func Map[T, U any](g Gen[T], fn func(T) U) Gen[U] {
+	return func(r *rand.Rand) U { return fn(g(r)) }
+}
+
+// SliceOf returns a Gen of slices with between minLen and maxLen elements,
+// inclusive, each generated with elem. Empty slices are non-nil
+func SliceOf[T any](elem Gen[T], minLen, maxLen int) Gen[[]T] {
+	minLen = max(minLen, 0)
+	maxLen = max(maxLen, minLen)
+	return func(r *rand.Rand) []T {
+		ret := make([]T, minLen+r.IntN(maxLen-minLen+1))
+		for i := range ret {
+			ret[i] = elem(r)
+		}
+		return ret
+	}
+}
+
+// MapOf returns a Gen of maps with up to maxLen entries, with keys from key
+// and values from val. Generated keys can collide, so a map can have fewer
+// than the number of entries generated for it
+func MapOf[K comparable, V any](key Gen[K], val Gen[V], maxLen int) Gen[map[K]V] {
+	return func(r *rand.Rand) map[K]V {
+		n := r.IntN(max(maxLen, 0) + 1)
+		ret := make(map[K]V, n)
+		for i := 0; i < n; i++ {
+			ret[key(r)] = val(r)
+		}
+		return ret
+	}
+}
+
+// PairOf returns a Gen of Tuples whose elements come from a and b
+func PairOf[A, B any](a Gen[A], b Gen[B]) Gen[core.Tuple[A, B]] {
+	return func(r *rand.Rand) core.Tuple[A, B] {
+		return core.Tup(a(r), b(r))
+	}
+}
+
+// PtrOf returns a Gen of optional values, represented as pointers, the same
+// way package ptr does. Each value is nil with probability nilProb, and
+// points to a value from g otherwise
+func PtrOf[T any](g Gen[T], nilProb float64) Gen[*T] {
+	return func(r *rand.Rand) *T {
+		if r.Float64() < nilProb {
+			return nil
+		}
+		t := g(r)
+		return &t
+	}
+}
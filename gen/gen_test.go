@@ -0,0 +1,72 @@
+package gen
+
+import (
+	"math"
+	"testing"
+	"unicode/utf8"
+
+	core "github.com/go-functional/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScalars(t *testing.T) {
+	r := require.New(t)
+	for _, i := range Sample(Int(-3, 3), 200, 1) {
+		r.GreaterOrEqual(i, -3)
+		r.Less(i, 3)
+	}
+	// spans wider than the type's max, which overflow if computed in the type
+	wide := Sample(Int[int8](-100, 100), 500, 1)
+	for _, i := range wide {
+		r.GreaterOrEqual(i, int8(-100))
+		r.Less(i, int8(100))
+	}
+	r.Contains(wide, int8(-100))
+	for _, i := range Sample(Int[int64](math.MinInt64, math.MaxInt64), 200, 1) {
+		r.Less(i, int64(math.MaxInt64))
+	}
+	for _, f := range Sample(Float(1.0, 2.0), 200, 1) {
+		r.GreaterOrEqual(f, 1.0)
+		r.Less(f, 2.0)
+	}
+	bools := Sample(Bool(), 200, 1)
+	r.Contains(bools, true)
+	r.Contains(bools, false)
+	for _, s := range Sample(String(5), 200, 1) {
+		r.True(utf8.ValidString(s))
+		r.LessOrEqual(utf8.RuneCountInString(s), 5)
+	}
+	r.Equal([]int{7, 7}, Sample(Const(7), 2, 1))
+	for _, s := range Sample(OneOf("a", "b"), 20, 1) {
+		r.Contains([]string{"a", "b"}, s)
+	}
+	r.Equal(Sample(Int(0, 100), 10, 42), Sample(Int(0, 100), 10, 42))
+	r.Panics(func() { Int(1, 1) })
+	r.Panics(func() { OneOf[int]() })
+}
+
+func TestContainers(t *testing.T) {
+	r := require.New(t)
+	for _, s := range Sample(SliceOf(Int(0, 10), 2, 4), 100, 1) {
+		r.NotNil(s)
+		r.GreaterOrEqual(len(s), 2)
+		r.LessOrEqual(len(s), 4)
+	}
+	for _, m := range Sample(MapOf(Int(0, 1000), String(3), 5), 100, 1) {
+		r.LessOrEqual(len(m), 5)
+	}
+	for _, p := range Sample(PairOf(Int(0, 10), Map(Int(0, 10), func(i int) int { return -i })), 20, 1) {
+		r.GreaterOrEqual(core.First(p), 0)
+		r.LessOrEqual(core.Second(p), 0)
+	}
+	ptrs := Sample(PtrOf(Int(0, 10), 0.5), 200, 1)
+	r.Contains(ptrs, (*int)(nil))
+	r.Equal(200, len(ptrs))
+	r.Nil(Sample(PtrOf(Int(0, 10), 1), 1, 1)[0])
+}
+
+func TestFromBytes(t *testing.T) {
+	r := require.New(t)
+	g := SliceOf(Int(0, 1000), 0, 10)
+	r.Equal(g(FromBytes([]byte("seed"))), g(FromBytes([]byte("seed"))))
+}
@@ -0,0 +1,135 @@
+// Package graph provides algorithms over directed graphs whose edges are
+// described by a function, so callers don't need to build an adjacency
+// structure first.
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CycleError is returned by TopoSort and TopoLayers when the graph they're
+// given has a cycle
+type CycleError[T comparable] struct {
+	// Cycle lists the nodes in the cycle, where each node depends on the
+	// next. The first node is repeated at the end, so a cycle between a and b
+	// is [a, b, a]
+	Cycle []T
+}
+
+func (e *CycleError[T]) Error() string {
+	strs := make([]string, len(e.Cycle))
+	for i, n := range e.Cycle {
+		strs[i] = fmt.Sprint(n)
+	}
+	return "graph: dependency cycle: " + strings.Join(strs, " -> ")
+}
+
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+// TopoSort returns nodes, plus any nodes reachable through deps, in an order
+// where every node comes after all of its dependencies. deps returns the
+// nodes that the given node depends on.
+//
+// If the graph has a cycle, TopoSort returns nil and a *CycleError describing
+// it.
+//
+// Example usage:
+//
+//	deps := map[string][]string{
+//		"app": {"db", "cache"},
+//		"cache": {"db"},
+//	}
+//	order, err := TopoSort([]string{"app"}, func(n string) []string {
+//		return deps[n]
+//	})
+//	// order will be ["db", "cache", "app"]
+func TopoSort[T comparable](nodes []T, deps func(T) []T) ([]T, error) {
+	state := map[T]int{}
+	ret := make([]T, 0, len(nodes))
+	var path []T
+
+	var visit func(T) error
+	visit = func(n T) error {
+		switch state[n] {
+		case visited:
+			return nil
+		case visiting:
+			return &CycleError[T]{Cycle: cycleFrom(path, n)}
+		}
+		state[n] = visiting
+		path = append(path, n)
+		for _, d := range deps(n) {
+			if err := visit(d); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[n] = visited
+		ret = append(ret, n)
+		return nil
+	}
+
+	for _, n := range nodes {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+// cycleFrom returns the part of path that starts at n, with n appended to
+// close the loop. Each node in path depends on the one after it, so the
+// result is already in dependency order
+func cycleFrom[T comparable](path []T, n T) []T {
+	start := len(path) - 1
+	for path[start] != n {
+		start--
+	}
+	cycle := make([]T, 0, len(path)-start+1)
+	cycle = append(cycle, path[start:]...)
+	return append(cycle, n)
+}
+
+// TopoLayers is similar to TopoSort, except it groups nodes into layers.
+// Every node in a layer depends only on nodes in earlier layers, so all the
+// nodes in a layer can be processed at the same time once the previous layers
+// are done. Nodes within a layer are in the order TopoSort would return them.
+//
+// Example usage, processing each layer concurrently:
+//
+//	layers, err := TopoLayers(nodes, deps)
+//	if err != nil {
+//		return err
+//	}
+//	for _, layer := range layers {
+//		if _, err := slice.ParMap(ctx, layer, build); err != nil {
+//			return err
+//		}
+//	}
+func TopoLayers[T comparable](nodes []T, deps func(T) []T) ([][]T, error) {
+	order, err := TopoSort(nodes, deps)
+	if err != nil {
+		return nil, err
+	}
+	// since order is topologically sorted, a node's dependencies always have
+	// their depth computed before the node itself
+	depth := make(map[T]int, len(order))
+	var layers [][]T
+	for _, n := range order {
+		d := 0
+		for _, dep := range deps(n) {
+			d = max(d, depth[dep]+1)
+		}
+		depth[n] = d
+		if d == len(layers) {
+			layers = append(layers, nil)
+		}
+		layers[d] = append(layers[d], n)
+	}
+	return layers, nil
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func depsOf(m map[string][]string) func(string) []string {
+	return func(n string) []string { return m[n] }
+}
+
+func TestTopoSort(t *testing.T) {
+	r := require.New(t)
+	deps := depsOf(map[string][]string{
+		"app":   {"db", "cache"},
+		"cache": {"db"},
+		"db":    {"disk"},
+	})
+	order, err := TopoSort([]string{"app"}, deps)
+	r.NoError(err)
+	r.Equal([]string{"disk", "db", "cache", "app"}, order)
+
+	layers, err := TopoLayers([]string{"app", "logs"}, deps)
+	r.NoError(err)
+	r.Equal([][]string{{"disk", "logs"}, {"db"}, {"cache"}, {"app"}}, layers)
+}
+
+func TestTopoSortCycle(t *testing.T) {
+	r := require.New(t)
+	deps := depsOf(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+	_, err := TopoSort([]string{"a"}, deps)
+	var cerr *CycleError[string]
+	r.True(errors.As(err, &cerr))
+	r.Equal([]string{"a", "b", "c", "a"}, cerr.Cycle)
+	r.Equal("graph: dependency cycle: a -> b -> c -> a", err.Error())
+
+	_, err = TopoLayers([]string{"a"}, deps)
+	r.Error(err)
+}
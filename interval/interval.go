@@ -0,0 +1,67 @@
+// Package interval provides half-open ranges of ordered values, sets of them
+// and an interval tree, for scheduling, time-window and IP-range logic.
+//
+// An Interval includes its lower bound and excludes its upper bound, so
+// [9:00, 10:00) and [10:00, 11:00) are adjacent but don't overlap. For
+// integers, the closed range from a to b is the Interval {a, b+1}.
+package interval
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Interval is the range of values at least Lo and less than Hi. It's empty
+// if Hi <= Lo
+type Interval[T cmp.Ordered] struct {
+	Lo, Hi T
+}
+
+// New returns the Interval from lo up to hi
+func New[T cmp.Ordered](lo, hi T) Interval[T] {
+	return Interval[T]{Lo: lo, Hi: hi}
+}
+
+func (iv Interval[T]) String() string {
+	return fmt.Sprintf("[%v, %v)", iv.Lo, iv.Hi)
+}
+
+// Empty returns true if iv contains no values
+func (iv Interval[T]) Empty() bool {
+	return iv.Hi <= iv.Lo
+}
+
+// Contains returns true if p is in iv
+func (iv Interval[T]) Contains(p T) bool {
+	return iv.Lo <= p && p < iv.Hi
+}
+
+// Overlaps returns true if there's at least one value in both iv and other
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return !iv.Empty() && !other.Empty() && iv.Lo < other.Hi && other.Lo < iv.Hi
+}
+
+// Intersect returns the Interval of values in both iv and other, and true,
+// or the zero Interval and false if they don't overlap
+func (iv Interval[T]) Intersect(other Interval[T]) (Interval[T], bool) {
+	if !iv.Overlaps(other) {
+		return Interval[T]{}, false
+	}
+	return Interval[T]{Lo: max(iv.Lo, other.Lo), Hi: min(iv.Hi, other.Hi)}, true
+}
+
+// Union returns the Interval of values in iv, other or both, and true, as
+// long as that's a single Interval, which it is if they overlap or are
+// adjacent. Otherwise it returns the zero Interval and false. The union of
+// an empty Interval and another is the other one
+func (iv Interval[T]) Union(other Interval[T]) (Interval[T], bool) {
+	switch {
+	case iv.Empty():
+		return other, true
+	case other.Empty():
+		return iv, true
+	case iv.Lo > other.Hi || other.Lo > iv.Hi:
+		return Interval[T]{}, false
+	}
+	return Interval[T]{Lo: min(iv.Lo, other.Lo), Hi: max(iv.Hi, other.Hi)}, true
+}
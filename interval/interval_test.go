@@ -0,0 +1,101 @@
+package interval
+
+import (
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterval(t *testing.T) {
+	r := require.New(t)
+	a := New(1, 5)
+	r.True(a.Contains(1))
+	r.False(a.Contains(5))
+	r.True(New(3, 3).Empty())
+	r.Equal("[1, 5)", a.String())
+
+	r.True(a.Overlaps(New(4, 10)))
+	r.False(a.Overlaps(New(5, 10)))
+	r.False(a.Overlaps(New(2, 2)))
+
+	got, ok := a.Intersect(New(3, 10))
+	r.True(ok)
+	r.Equal(New(3, 5), got)
+	_, ok = a.Intersect(New(5, 10))
+	r.False(ok)
+
+	got, ok = a.Union(New(5, 10))
+	r.True(ok)
+	r.Equal(New(1, 10), got)
+	_, ok = a.Union(New(6, 10))
+	r.False(ok)
+	got, ok = a.Union(New(9, 9))
+	r.True(ok)
+	r.Equal(a, got)
+}
+
+func TestSet(t *testing.T) {
+	r := require.New(t)
+	var s Set[int]
+	r.False(s.Contains(0))
+	s.Add(New(10, 20))
+	s.Add(New(30, 40))
+	s.Add(New(0, 5))
+	s.Add(New(7, 7))
+	r.Equal([]Interval[int]{New(0, 5), New(10, 20), New(30, 40)}, s.Intervals())
+
+	// merges overlapping and adjacent intervals
+	s.Add(New(5, 12))
+	r.Equal([]Interval[int]{New(0, 20), New(30, 40)}, s.Intervals())
+	s.Add(New(15, 35))
+	r.Equal([]Interval[int]{New(0, 40)}, s.Intervals())
+
+	s.Remove(New(10, 20))
+	r.Equal([]Interval[int]{New(0, 10), New(20, 40)}, s.Intervals())
+	s.Remove(New(-5, 0))
+	s.Remove(New(40, 50))
+	r.Equal(2, s.Len())
+	s.Remove(New(5, 25))
+	r.Equal([]Interval[int]{New(0, 5), New(25, 40)}, s.Intervals())
+
+	iv, ok := s.Stab(25)
+	r.True(ok)
+	r.Equal(New(25, 40), iv)
+	_, ok = s.Stab(5)
+	r.False(ok)
+	r.True(s.Contains(4))
+	r.False(s.Contains(40))
+
+	r.Equal([]Interval[int]{New(0, 5), New(25, 40)}, s.Overlapping(New(4, 26)))
+	r.Empty(s.Overlapping(New(5, 25)))
+}
+
+func TestSetRandom(t *testing.T) {
+	r := require.New(t)
+	rng := rand.New(rand.NewPCG(5, 5))
+	var (
+		s   Set[int]
+		ref [200]bool
+	)
+	for range 500 {
+		lo := rng.IntN(200)
+		iv := New(lo, min(lo+rng.IntN(20), 200))
+		add := rng.IntN(3) > 0
+		if add {
+			s.Add(iv)
+		} else {
+			s.Remove(iv)
+		}
+		for p := iv.Lo; p < iv.Hi; p++ {
+			ref[p] = add
+		}
+	}
+	for p, want := range ref {
+		r.Equal(want, s.Contains(p), "point %d", p)
+	}
+	ivs := s.Intervals()
+	for i := 1; i < len(ivs); i++ {
+		r.Less(ivs[i-1].Hi, ivs[i].Lo)
+	}
+}
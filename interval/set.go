@@ -0,0 +1,122 @@
+package interval
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Set is a set of values made up of Intervals. It keeps its Intervals
+// sorted, and merges any that overlap or are adjacent as they're added, so
+// it always holds the fewest Intervals that cover its values. The zero value
+// is an empty Set ready to use.
+//
+// Point queries and adds take O(log n) time to find their place, plus the
+// time to shift or merge Intervals. To keep overlapping Intervals apart, each
+// with its own value, use a Tree instead.
+//
+// Example usage:
+//
+//	var busy interval.Set[int]
+//	busy.Add(interval.New(900, 1030))
+//	busy.Add(interval.New(1000, 1100))
+//	busy.Intervals() // [[900, 1100)]
+//	busy.Contains(1045) // true
+type Set[T cmp.Ordered] struct {
+	ivs []Interval[T]
+}
+
+// search returns the index of the first Interval in s whose Hi is at least p
+func (s *Set[T]) search(p T) int {
+	i, _ := slices.BinarySearchFunc(s.ivs, p, func(iv Interval[T], p T) int {
+		return cmp.Compare(iv.Hi, p)
+	})
+	return i
+}
+
+// Add adds every value in iv to s. Adding an empty Interval does nothing
+func (s *Set[T]) Add(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	// every Interval from lo up to hi overlaps or touches iv, so they're
+	// all merged into it
+	lo := s.search(iv.Lo)
+	hi := lo
+	for hi < len(s.ivs) && s.ivs[hi].Lo <= iv.Hi {
+		iv, _ = iv.Union(s.ivs[hi])
+		hi++
+	}
+	s.ivs = slices.Replace(s.ivs, lo, hi, iv)
+}
+
+// Remove removes every value in iv from s, splitting an Interval in two if
+// iv falls in the middle of it
+func (s *Set[T]) Remove(iv Interval[T]) {
+	if iv.Empty() {
+		return
+	}
+	var keep []Interval[T]
+	lo := s.search(iv.Lo)
+	// an Interval that ends exactly at iv.Lo doesn't overlap it
+	if lo < len(s.ivs) && s.ivs[lo].Hi == iv.Lo {
+		lo++
+	}
+	hi := lo
+	for hi < len(s.ivs) && s.ivs[hi].Lo < iv.Hi {
+		cur := s.ivs[hi]
+		if cur.Lo < iv.Lo {
+			keep = append(keep, Interval[T]{Lo: cur.Lo, Hi: iv.Lo})
+		}
+		if cur.Hi > iv.Hi {
+			keep = append(keep, Interval[T]{Lo: iv.Hi, Hi: cur.Hi})
+		}
+		hi++
+	}
+	s.ivs = slices.Replace(s.ivs, lo, hi, keep...)
+}
+
+// Contains returns true if p is in s
+func (s *Set[T]) Contains(p T) bool {
+	_, ok := s.Stab(p)
+	return ok
+}
+
+// Stab returns the Interval in s that contains p, and true, or the zero
+// Interval and false if p isn't in s
+func (s *Set[T]) Stab(p T) (Interval[T], bool) {
+	i := s.search(p)
+	// the Interval found ends at or after p, but if it ends exactly at p, p
+	// is in the next one, if anywhere
+	if i < len(s.ivs) && s.ivs[i].Hi == p {
+		i++
+	}
+	if i < len(s.ivs) && s.ivs[i].Contains(p) {
+		return s.ivs[i], true
+	}
+	return Interval[T]{}, false
+}
+
+// Overlapping returns the Intervals in s that overlap iv, in order
+func (s *Set[T]) Overlapping(iv Interval[T]) []Interval[T] {
+	if iv.Empty() {
+		return nil
+	}
+	var ret []Interval[T]
+	for i := s.search(iv.Lo); i < len(s.ivs) && s.ivs[i].Lo < iv.Hi; i++ {
+		if s.ivs[i].Overlaps(iv) {
+			ret = append(ret, s.ivs[i])
+		}
+	}
+	return ret
+}
+
+// Intervals returns a copy of the Intervals in s, sorted, with none of them
+// overlapping or adjacent
+func (s *Set[T]) Intervals() []Interval[T] {
+	return slices.Clone(s.ivs)
+}
+
+// Len returns how many Intervals s is made up of
+func (s *Set[T]) Len() int {
+	return len(s.ivs)
+}
@@ -0,0 +1,212 @@
+package interval
+
+import (
+	"cmp"
+	"math/rand/v2"
+)
+
+// Entry is an Interval stored in a Tree, along with its value
+type Entry[T cmp.Ordered, V any] struct {
+	Interval Interval[T]
+	Value    V
+}
+
+// Tree is an interval tree: it holds Intervals, each with a value, and finds
+// every one that contains a point or overlaps a range in O(log n + k) time,
+// where k is the number of results. Unlike a Set, it keeps every Interval
+// it's given separate, even when they overlap or are identical. The zero
+// value is an empty Tree ready to use.
+//
+// It's a treap, a binary search tree ordered by the Intervals' bounds and
+// kept balanced with random priorities, in which every node also holds the
+// highest upper bound in its subtree, so searches can skip subtrees that end
+// before the query starts.
+//
+// Example usage, with times as Unix seconds, since time.Time isn't ordered
+// by the < operator:
+//
+//	var bookings interval.Tree[int64, string]
+//	bookings.Insert(interval.New(start.Unix(), end.Unix()), "alice")
+//	for _, e := range bookings.Stab(time.Now().Unix()) {
+//		fmt.Println(e.Value, "is in the room")
+//	}
+type Tree[T cmp.Ordered, V any] struct {
+	root *treeNode[T, V]
+	len  int
+}
+
+type treeNode[T cmp.Ordered, V any] struct {
+	entry       Entry[T, V]
+	prio        uint64
+	maxHi       T
+	left, right *treeNode[T, V]
+}
+
+func (n *treeNode[T, V]) update() {
+	n.maxHi = n.entry.Interval.Hi
+	if n.left != nil {
+		n.maxHi = max(n.maxHi, n.left.maxHi)
+	}
+	if n.right != nil {
+		n.maxHi = max(n.maxHi, n.right.maxHi)
+	}
+}
+
+func compareIntervals[T cmp.Ordered](a, b Interval[T]) int {
+	if c := cmp.Compare(a.Lo, b.Lo); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Hi, b.Hi)
+}
+
+// Len returns how many Intervals are in t
+func (t *Tree[T, V]) Len() int {
+	return t.len
+}
+
+// Insert adds iv to t, with val as its value. Empty Intervals can be
+// inserted, but no query ever returns them
+func (t *Tree[T, V]) Insert(iv Interval[T], val V) {
+	n := &treeNode[T, V]{entry: Entry[T, V]{Interval: iv, Value: val}, prio: rand.Uint64()}
+	n.update()
+	t.root = insertNode(t.root, n)
+	t.len++
+}
+
+func insertNode[T cmp.Ordered, V any](root, n *treeNode[T, V]) *treeNode[T, V] {
+	if root == nil {
+		return n
+	}
+	if compareIntervals(n.entry.Interval, root.entry.Interval) < 0 {
+		root.left = insertNode(root.left, n)
+		if root.left.prio > root.prio {
+			root = rotateRight(root)
+		}
+	} else {
+		root.right = insertNode(root.right, n)
+		if root.right.prio > root.prio {
+			root = rotateLeft(root)
+		}
+	}
+	root.update()
+	return root
+}
+
+func rotateRight[T cmp.Ordered, V any](n *treeNode[T, V]) *treeNode[T, V] {
+	l := n.left
+	n.left = l.right
+	n.update()
+	l.right = n
+	l.update()
+	return l
+}
+
+func rotateLeft[T cmp.Ordered, V any](n *treeNode[T, V]) *treeNode[T, V] {
+	r := n.right
+	n.right = r.left
+	n.update()
+	r.left = n
+	r.update()
+	return r
+}
+
+// Delete removes an Interval equal to iv from t, and returns its value and
+// true, or the zero value of V and false if there isn't one. If iv was
+// inserted more than once, only one of them is removed, and which one is
+// unspecified
+func (t *Tree[T, V]) Delete(iv Interval[T]) (V, bool) {
+	var (
+		val V
+		ok  bool
+	)
+	t.root = deleteNode(t.root, iv, &val, &ok)
+	if ok {
+		t.len--
+	}
+	return val, ok
+}
+
+func deleteNode[T cmp.Ordered, V any](n *treeNode[T, V], iv Interval[T], val *V, ok *bool) *treeNode[T, V] {
+	if n == nil {
+		return nil
+	}
+	switch c := compareIntervals(iv, n.entry.Interval); {
+	case c < 0:
+		n.left = deleteNode(n.left, iv, val, ok)
+	case c > 0:
+		n.right = deleteNode(n.right, iv, val, ok)
+	default:
+		*val, *ok = n.entry.Value, true
+		return mergeNodes(n.left, n.right)
+	}
+	n.update()
+	return n
+}
+
+// mergeNodes joins two treaps, where every Interval in a sorts at or before
+// every Interval in b
+func mergeNodes[T cmp.Ordered, V any](a, b *treeNode[T, V]) *treeNode[T, V] {
+	switch {
+	case a == nil:
+		return b
+	case b == nil:
+		return a
+	case a.prio > b.prio:
+		a.right = mergeNodes(a.right, b)
+		a.update()
+		return a
+	default:
+		b.left = mergeNodes(a, b.left)
+		b.update()
+		return b
+	}
+}
+
+// Stab returns every entry in t whose Interval contains p, sorted by their
+// Intervals' bounds
+func (t *Tree[T, V]) Stab(p T) []Entry[T, V] {
+	var ret []Entry[T, V]
+	var visit func(n *treeNode[T, V])
+	visit = func(n *treeNode[T, V]) {
+		// nothing in this subtree ends after p
+		if n == nil || n.maxHi <= p {
+			return
+		}
+		visit(n.left)
+		// everything from here on starts after p
+		if n.entry.Interval.Lo > p {
+			return
+		}
+		if n.entry.Interval.Contains(p) {
+			ret = append(ret, n.entry)
+		}
+		visit(n.right)
+	}
+	visit(t.root)
+	return ret
+}
+
+// Overlapping returns every entry in t whose Interval overlaps q, sorted by
+// their Intervals' bounds
+func (t *Tree[T, V]) Overlapping(q Interval[T]) []Entry[T, V] {
+	if q.Empty() {
+		return nil
+	}
+	var ret []Entry[T, V]
+	var visit func(n *treeNode[T, V])
+	visit = func(n *treeNode[T, V]) {
+		if n == nil || n.maxHi <= q.Lo {
+			return
+		}
+		visit(n.left)
+		if n.entry.Interval.Lo >= q.Hi {
+			return
+		}
+		if n.entry.Interval.Overlaps(q) {
+			ret = append(ret, n.entry)
+		}
+		visit(n.right)
+	}
+	visit(t.root)
+	return ret
+}
@@ -0,0 +1,92 @@
+package interval
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTree(t *testing.T) {
+	r := require.New(t)
+	var tr Tree[int, string]
+	r.Empty(tr.Stab(0))
+	tr.Insert(New(0, 10), "a")
+	tr.Insert(New(5, 15), "b")
+	tr.Insert(New(5, 15), "b2")
+	tr.Insert(New(20, 30), "c")
+	tr.Insert(New(7, 7), "empty")
+	r.Equal(5, tr.Len())
+
+	values := func(es []Entry[int, string]) []string {
+		var ret []string
+		for _, e := range es {
+			ret = append(ret, e.Value)
+		}
+		slices.Sort(ret)
+		return ret
+	}
+	r.Equal([]string{"a", "b", "b2"}, values(tr.Stab(7)))
+	r.Equal([]string{"b", "b2"}, values(tr.Stab(10)))
+	r.Empty(tr.Stab(15))
+	r.Equal([]string{"b", "b2", "c"}, values(tr.Overlapping(New(12, 21))))
+	r.Empty(tr.Overlapping(New(15, 20)))
+
+	v, ok := tr.Delete(New(0, 10))
+	r.True(ok)
+	r.Equal("a", v)
+	_, ok = tr.Delete(New(0, 10))
+	r.False(ok)
+	_, ok = tr.Delete(New(5, 15))
+	r.True(ok)
+	r.Len(tr.Stab(7), 1)
+	r.Equal(3, tr.Len())
+}
+
+func TestTreeRandom(t *testing.T) {
+	r := require.New(t)
+	rng := rand.New(rand.NewPCG(9, 9))
+	var (
+		tr   Tree[int, int]
+		flat []Interval[int]
+	)
+	for i := range 1000 {
+		lo := rng.IntN(1000)
+		iv := New(lo, lo+rng.IntN(50))
+		tr.Insert(iv, i)
+		flat = append(flat, iv)
+		if rng.IntN(4) == 0 {
+			victim := flat[rng.IntN(len(flat))]
+			_, ok := tr.Delete(victim)
+			r.True(ok)
+			flat = slices.Delete(flat, slices.Index(flat, victim), slices.Index(flat, victim)+1)
+		}
+	}
+	r.Equal(len(flat), tr.Len())
+
+	for range 200 {
+		lo := rng.IntN(1100)
+		q := New(lo, lo+rng.IntN(30)+1)
+		var want []Interval[int]
+		for _, iv := range flat {
+			if iv.Overlaps(q) {
+				want = append(want, iv)
+			}
+		}
+		var got []Interval[int]
+		for _, e := range tr.Overlapping(q) {
+			got = append(got, e.Interval)
+		}
+		slices.SortFunc(want, compareIntervals)
+		r.Equal(want, got)
+
+		var stabbed int
+		for _, iv := range flat {
+			if iv.Contains(q.Lo) {
+				stabbed++
+			}
+		}
+		r.Len(tr.Stab(q.Lo), stabbed)
+	}
+}
@@ -0,0 +1,78 @@
+package intmath
+
+import "github.com/go-functional/core/constraints"
+
+// Abs returns the absolute value of n. Like in two's complement arithmetic
+// generally, the absolute value of MinOf for a signed type doesn't fit in
+// it, so Abs returns MinOf unchanged
+func Abs[T constraints.Integer](n T) T {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// GCD returns the greatest common divisor of a and b, which is non-negative,
+// with one exception: for a signed type, GCD(MinOf, 0) and GCD(MinOf, MinOf)
+// are the absolute value of MinOf, which doesn't fit in the type, so like Abs
+// they return MinOf. GCD(0, 0) is 0
+func GCD[T constraints.Integer](a, b T) T {
+	a, b = Abs(a), Abs(b)
+	for b != 0 {
+		a, b = b, a%b
+	}
+	// a is only negative here if an input was MinOf, whose Abs stays
+	// negative, and so does the remainder of dividing it
+	return Abs(a)
+}
+
+// LCM returns the least common multiple of a and b, which is non-negative,
+// with one exception: for a signed type, if either input is MinOf and the
+// result is the absolute value of MinOf, such as LCM(MinOf, 1), it doesn't
+// fit in the type, so like Abs it's MinOf. It's 0 if either of them is 0.
+// The result can overflow for large inputs, the same as a*b can
+func LCM[T constraints.Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return Abs(a / GCD(a, b) * b)
+}
+
+// Pow returns base raised to the power exp, computed by repeated squaring.
+// It wraps around on overflow, like the * operator; use MulSat in a loop if
+// that's not what you want. Pow(0, 0) is 1
+func Pow[T constraints.Integer](base T, exp uint) T {
+	ret := T(1)
+	for exp > 0 {
+		if exp&1 == 1 {
+			ret *= base
+		}
+		base *= base
+		exp >>= 1
+	}
+	return ret
+}
+
+// DivCeil returns a / b rounded up towards positive infinity, where Go's /
+// operator rounds towards zero. It panics if b is 0, like the / operator.
+//
+// Example usage, the number of pages needed for n items:
+//
+//	pages := DivCeil(n, perPage)
+func DivCeil[T constraints.Integer](a, b T) T {
+	q := a / b
+	// rounding towards zero already rounded up if the exact quotient is
+	// negative, so only positive quotients with a remainder need adjusting
+	if r := a % b; r != 0 && (r > 0) == (b > 0) {
+		q++
+	}
+	return q
+}
+
+// RoundUpToMultiple returns the smallest multiple of m that's greater than
+// or equal to n. The multiples of m and -m are the same, so the sign of m
+// doesn't matter. It panics if m is 0
+func RoundUpToMultiple[T constraints.Integer](n, m T) T {
+	m = Abs(m)
+	return DivCeil(n, m) * m
+}
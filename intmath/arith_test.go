@@ -0,0 +1,62 @@
+package intmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbs(t *testing.T) {
+	r := require.New(t)
+	r.Equal(3, Abs(-3))
+	r.Equal(3, Abs(3))
+	r.Equal(uint(3), Abs(uint(3)))
+	r.Equal(int8(math.MinInt8), Abs(int8(math.MinInt8)))
+}
+
+func TestGCDLCM(t *testing.T) {
+	r := require.New(t)
+	r.Equal(6, GCD(12, 18))
+	r.Equal(6, GCD(-12, 18))
+	r.Equal(5, GCD(0, -5))
+	r.Equal(0, GCD(0, 0))
+	r.Equal(int8(2), GCD[int8](math.MinInt8, 6))
+	r.Equal(int8(2), GCD[int8](6, math.MinInt8))
+	r.Equal(math.MinInt, GCD(math.MinInt, 0))
+	r.Equal(math.MinInt, GCD(math.MinInt, math.MinInt))
+	r.Equal(uint64(4), GCD[uint64](8, 12))
+
+	r.Equal(36, LCM(12, 18))
+	r.Equal(36, LCM(-12, 18))
+	r.Equal(0, LCM(0, 7))
+	r.Equal(math.MinInt, LCM(math.MinInt, 1))
+}
+
+func TestPow(t *testing.T) {
+	r := require.New(t)
+	r.Equal(1024, Pow(2, 10))
+	r.Equal(-27, Pow(-3, 3))
+	r.Equal(1, Pow(0, 0))
+	r.Equal(0, Pow(0, 5))
+	r.Equal(uint8(0), Pow[uint8](2, 8))
+}
+
+func TestDivCeil(t *testing.T) {
+	r := require.New(t)
+	r.Equal(3, DivCeil(7, 3))
+	r.Equal(2, DivCeil(6, 3))
+	r.Equal(-2, DivCeil(-7, 3))
+	r.Equal(-2, DivCeil(7, -3))
+	r.Equal(3, DivCeil(-7, -3))
+	r.Equal(0, DivCeil(0, 3))
+	r.Equal(uint(4), DivCeil[uint](10, 3))
+	r.Panics(func() { DivCeil(1, 0) })
+
+	r.Equal(12, RoundUpToMultiple(10, 4))
+	r.Equal(8, RoundUpToMultiple(8, 4))
+	r.Equal(-8, RoundUpToMultiple(-10, 4))
+	r.Equal(12, RoundUpToMultiple(10, -4))
+	r.Equal(-8, RoundUpToMultiple(-10, -4))
+	r.Panics(func() { RoundUpToMultiple(1, 0) })
+}
@@ -0,0 +1,75 @@
+// Package intmath provides integer math helpers that work with any integer
+// type.
+package intmath
+
+import (
+	"unsafe"
+
+	"github.com/go-functional/core/constraints"
+)
+
+// signed returns true if T is a signed integer type
+func signed[T constraints.Integer]() bool {
+	return T(0)-1 < 0
+}
+
+// MaxOf returns the largest value T can hold
+func MaxOf[T constraints.Integer]() T {
+	if signed[T]() {
+		return T(1)<<(unsafe.Sizeof(T(0))*8-1) - 1
+	}
+	return ^T(0)
+}
+
+// MinOf returns the smallest value T can hold
+func MinOf[T constraints.Integer]() T {
+	if signed[T]() {
+		return -MaxOf[T]() - 1
+	}
+	return 0
+}
+
+// AddSat returns a + b, saturated to the range T can hold: instead of
+// wrapping around on overflow, the result sticks at MaxOf or MinOf.
+//
+// Example usage:
+//
+//	AddSat[uint8](250, 10) // 255
+//	AddSat[int8](-100, -100) // -128
+func AddSat[T constraints.Integer](a, b T) T {
+	sum := a + b
+	switch {
+	case signed[T]() && b < 0:
+		if sum > a {
+			return MinOf[T]()
+		}
+	case sum < a:
+		return MaxOf[T]()
+	}
+	return sum
+}
+
+// MulSat returns a * b, saturated to the range T can hold, the same way as
+// AddSat
+func MulSat[T constraints.Integer](a, b T) T {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	// the sign the product should have, if it didn't overflow
+	positive := (a > 0) == (b > 0)
+	prod := a * b
+	overflow := prod/b != a
+	if signed[T]() {
+		// MinOf * -1 overflows back to MinOf, and so does MinOf / -1, so
+		// the division check can't catch it
+		lo, negOne := MinOf[T](), T(0)-1
+		overflow = overflow || (a == negOne && b == lo) || (b == negOne && a == lo)
+	}
+	if !overflow {
+		return prod
+	}
+	if positive {
+		return MaxOf[T]()
+	}
+	return MinOf[T]()
+}
@@ -0,0 +1,51 @@
+package intmath
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBounds(t *testing.T) {
+	r := require.New(t)
+	r.Equal(int8(math.MaxInt8), MaxOf[int8]())
+	r.Equal(int8(math.MinInt8), MinOf[int8]())
+	r.Equal(int64(math.MaxInt64), MaxOf[int64]())
+	r.Equal(uint16(math.MaxUint16), MaxOf[uint16]())
+	r.Equal(uint(0), MinOf[uint]())
+}
+
+func TestAddSat(t *testing.T) {
+	r := require.New(t)
+	r.Equal(uint8(255), AddSat[uint8](250, 10))
+	r.Equal(uint8(20), AddSat[uint8](10, 10))
+	r.Equal(int8(127), AddSat[int8](100, 100))
+	r.Equal(int8(-128), AddSat[int8](-100, -100))
+	r.Equal(int8(0), AddSat[int8](-100, 100))
+	r.Equal(math.MaxInt, AddSat(math.MaxInt, 1))
+	r.Equal(math.MinInt, AddSat(math.MinInt, -1))
+}
+
+func TestMulSat(t *testing.T) {
+	r := require.New(t)
+	r.Equal(uint8(255), MulSat[uint8](16, 16))
+	r.Equal(uint8(225), MulSat[uint8](15, 15))
+	r.Equal(int8(127), MulSat[int8](-16, -16))
+	r.Equal(int8(-128), MulSat[int8](16, -16))
+	r.Equal(int8(-120), MulSat[int8](12, -10))
+	r.Equal(int8(127), MulSat[int8](-1, -128))
+	r.Equal(int8(127), MulSat[int8](-128, -1))
+	r.Equal(int8(-128), MulSat[int8](-128, 1))
+	r.Equal(int8(0), MulSat[int8](0, -128))
+
+	// check every int8 pair against the exact product
+	for a := math.MinInt8; a <= math.MaxInt8; a++ {
+		for b := math.MinInt8; b <= math.MaxInt8; b++ {
+			want := min(max(a*b, math.MinInt8), math.MaxInt8)
+			r.Equal(int8(want), MulSat(int8(a), int8(b)), "%d * %d", a, b)
+			want = min(max(a+b, math.MinInt8), math.MaxInt8)
+			r.Equal(int8(want), AddSat(int8(a), int8(b)), "%d + %d", a, b)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Package jsonl reads and writes JSON Lines (also known as NDJSON), where
+// every line is a JSON value. Values are decoded and encoded one at a time,
+// so datasets much bigger than memory can be processed as sequences.
+package jsonl
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+
+	"github.com/go-functional/core/par"
+	"github.com/go-functional/core/slice"
+)
+
+// Decode returns a sequence of the values in r, each decoded into a T.
+// Values are decoded lazily, as the sequence is consumed. If a value can't be
+// read or decoded, the sequence yields the zero value of T with the error,
+// and then ends, since there's no reliable way to find the start of the next
+// value.
+//
+// Example usage:
+//
+//	for ev, err := range jsonl.Decode[Event](f) {
+//		if err != nil {
+//			return err
+//		}
+//		handle(ev)
+//	}
+func Decode[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		dec := json.NewDecoder(r)
+		for {
+			var t T
+			err := dec.Decode(&t)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// Encode writes every value in seq to w as JSON, one value per line. It stops
+// and returns the error if a value can't be encoded or written
+func Encode[T any](w io.Writer, seq iter.Seq[T]) error {
+	enc := json.NewEncoder(w)
+	for t := range seq {
+		if err := enc.Encode(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// transformChunk is how many values Transform decodes before mapping them in
+// parallel
+const transformChunk = 256
+
+// Transform decodes every value in r, maps it with fn using slice.ParMap, and
+// writes the results to w in input order, one per line. Values are read in
+// chunks, so only a chunk's worth of values is held in memory at a time. opts
+// are passed to ParMap, so par.Limit caps how many calls to fn run at once.
+//
+// Transform stops and returns the first error from decoding, fn or encoding.
+// Results for earlier chunks stay written.
+//
+// Example usage:
+//
+//	err := jsonl.Transform(ctx, in, func(ctx context.Context, u User) (Profile, error) {
+//		return lookup(ctx, u.ID)
+//	}, out, par.Limit(16))
+func Transform[T, U any](
+	ctx context.Context,
+	r io.Reader,
+	fn func(context.Context, T) (U, error),
+	w io.Writer,
+	opts ...par.Option,
+) error {
+	enc := json.NewEncoder(w)
+	chunk := make([]T, 0, transformChunk)
+	flush := func() error {
+		out, err := slice.ParMap(ctx, chunk, func(ctx context.Context, _ uint, t T) (U, error) {
+			return fn(ctx, t)
+		}, opts...)
+		if err != nil {
+			return err
+		}
+		for _, u := range out {
+			if err := enc.Encode(u); err != nil {
+				return err
+			}
+		}
+		chunk = chunk[:0]
+		return nil
+	}
+
+	for t, err := range Decode[T](r) {
+		if err != nil {
+			return err
+		}
+		chunk = append(chunk, t)
+		if len(chunk) == cap(chunk) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	return flush()
+}
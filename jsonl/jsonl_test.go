@@ -0,0 +1,73 @@
+package jsonl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+type rec struct {
+	N int `json:"n"`
+}
+
+func TestDecode(t *testing.T) {
+	r := require.New(t)
+	var got []rec
+	for v, err := range Decode[rec](strings.NewReader("{\"n\":1}\n{\"n\":2}\n\n{\"n\":3}")) {
+		r.NoError(err)
+		got = append(got, v)
+	}
+	r.Equal([]rec{{1}, {2}, {3}}, got)
+
+	var errs []error
+	for _, err := range Decode[rec](strings.NewReader("{\"n\":1}\n{oops\n{\"n\":3}\n")) {
+		errs = append(errs, err)
+	}
+	r.Len(errs, 2)
+	r.NoError(errs[0])
+	r.Error(errs[1])
+}
+
+func TestEncode(t *testing.T) {
+	r := require.New(t)
+	var out strings.Builder
+	r.NoError(Encode(&out, slices.Values([]rec{{1}, {2}})))
+	r.Equal("{\"n\":1}\n{\"n\":2}\n", out.String())
+
+	r.Error(Encode(&out, slices.Values([]func(){func() {}})))
+}
+
+func TestTransform(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var in, want strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&in, "{\"n\":%d}\n", i)
+		fmt.Fprintf(&want, "%d\n", i*2)
+	}
+	double := func(_ context.Context, v rec) (int, error) { return v.N * 2, nil }
+	var out strings.Builder
+	r.NoError(Transform(ctx, strings.NewReader(in.String()), double, &out, par.Limit(4)))
+	r.Equal(want.String(), out.String())
+
+	out.Reset()
+	r.NoError(Transform(ctx, strings.NewReader(""), double, &out))
+	r.Empty(out.String())
+
+	errBad := errors.New("bad")
+	err := Transform(ctx, strings.NewReader(in.String()), func(_ context.Context, v rec) (int, error) {
+		if v.N == 500 {
+			return 0, errBad
+		}
+		return v.N, nil
+	}, &out)
+	r.ErrorIs(err, errBad)
+
+	r.Error(Transform(ctx, strings.NewReader("{oops"), double, &out))
+}
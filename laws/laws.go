@@ -0,0 +1,198 @@
+// Package laws checks that implementations obey the algebraic laws this
+// repository documents, like the Monoid laws in package monoid, by testing
+// them against many randomly generated values.
+//
+// Go can't express a functor or monad as an interface, so the checkers take
+// the operations that make up the abstraction as functions. That means they
+// work for types in this repository and for downstream types alike.
+//
+// Values are compared with reflect.DeepEqual, so a nil slice and an empty
+// one are different. The checkers call t.Errorf with the first
+// counterexample they find for each law, and the seed that produced it.
+package laws
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"testing"
+
+	"github.com/go-functional/core/monoid"
+)
+
+// Option configures a law checker
+type Option func(*config)
+
+type config struct {
+	iterations int
+	seed       uint64
+	seeded     bool
+}
+
+func newConfig(opts []Option) config {
+	cfg := config{iterations: 100}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !cfg.seeded {
+		cfg.seed = rand.Uint64()
+	}
+	return cfg
+}
+
+func (c config) rand() *rand.Rand {
+	return rand.New(rand.NewPCG(c.seed, c.seed))
+}
+
+// Iterations sets how many sets of random values each law is checked
+// against. The default is 100
+func Iterations(n int) Option {
+	return func(c *config) {
+		c.iterations = max(n, 1)
+	}
+}
+
+// Seed sets the seed for the random values, to reproduce a failure. By
+// default, every run uses a different seed
+func Seed(seed uint64) Option {
+	return func(c *config) {
+		c.seed = seed
+		c.seeded = true
+	}
+}
+
+// check runs law until it returns false, or the iterations run out. If law
+// returns false, it reports the failure with the values it returned
+func check(t testing.TB, cfg config, name string, law func(*rand.Rand) (bool, []any)) {
+	t.Helper()
+	rnd := cfg.rand()
+	for i := 0; i < cfg.iterations; i++ {
+		if ok, vals := law(rnd); !ok {
+			t.Errorf("laws: %s doesn't hold for %v (seed %d)", name, vals, cfg.seed)
+			return
+		}
+	}
+}
+
+// CheckSemigroup checks that s.Combine is associative, for values from gen
+func CheckSemigroup[T any](t testing.TB, s monoid.Semigroup[T], gen func(*rand.Rand) T, opts ...Option) {
+	t.Helper()
+	cfg := newConfig(opts)
+	check(t, cfg, "associativity", func(rnd *rand.Rand) (bool, []any) {
+		a, b, c := gen(rnd), gen(rnd), gen(rnd)
+		lhs := s.Combine(s.Combine(a, b), c)
+		rhs := s.Combine(a, s.Combine(b, c))
+		return reflect.DeepEqual(lhs, rhs), []any{a, b, c}
+	})
+}
+
+// CheckMonoid checks that m obeys the Semigroup law, and that m.Empty is an
+// identity on both sides, for values from gen.
+//
+// Example usage:
+//
+//	func TestTotal(t *testing.T) {
+//		laws.CheckMonoid(t, TotalMonoid(), func(r *rand.Rand) Total {
+//			return Total{Count: r.IntN(100)}
+//		})
+//	}
+func CheckMonoid[T any](t testing.TB, m monoid.Monoid[T], gen func(*rand.Rand) T, opts ...Option) {
+	t.Helper()
+	CheckSemigroup[T](t, m, gen, opts...)
+	cfg := newConfig(opts)
+	check(t, cfg, "left identity", func(rnd *rand.Rand) (bool, []any) {
+		a := gen(rnd)
+		return reflect.DeepEqual(m.Combine(m.Empty(), a), a), []any{a}
+	})
+	check(t, cfg, "right identity", func(rnd *rand.Rand) (bool, []any) {
+		a := gen(rnd)
+		return reflect.DeepEqual(m.Combine(a, m.Empty()), a), []any{a}
+	})
+}
+
+// CheckFunctor checks the functor laws for a container type F holding values
+// of type T, where fmap applies a function to every value in the container.
+// Identity, that fmap(x, id) == x, is checked for containers from gen.
+// Composition, that fmap(x, f∘g) == fmap(fmap(x, g), f), is checked for
+// every pair of functions in fns.
+//
+// Example usage:
+//
+//	laws.CheckFunctor(t, func(t tree.Tree[int], fn func(int) int) tree.Tree[int] {
+//		return tree.Map(t, fn)
+//	}, genTree, double, inc)
+func CheckFunctor[F, T any](
+	t testing.TB,
+	fmap func(F, func(T) T) F,
+	gen func(*rand.Rand) F,
+	fns ...func(T) T,
+) {
+	t.Helper()
+	CheckFunctorWith(t, fmap, gen, fns)
+}
+
+// CheckFunctorWith is the same as CheckFunctor, but takes Options
+func CheckFunctorWith[F, T any](
+	t testing.TB,
+	fmap func(F, func(T) T) F,
+	gen func(*rand.Rand) F,
+	fns []func(T) T,
+	opts ...Option,
+) {
+	t.Helper()
+	cfg := newConfig(opts)
+	check(t, cfg, "functor identity", func(rnd *rand.Rand) (bool, []any) {
+		x := gen(rnd)
+		return reflect.DeepEqual(fmap(x, func(t T) T { return t }), x), []any{x}
+	})
+	for _, f := range fns {
+		for _, g := range fns {
+			check(t, cfg, "functor composition", func(rnd *rand.Rand) (bool, []any) {
+				x := gen(rnd)
+				lhs := fmap(x, func(t T) T { return f(g(t)) })
+				rhs := fmap(fmap(x, g), f)
+				return reflect.DeepEqual(lhs, rhs), []any{x}
+			})
+		}
+	}
+}
+
+// CheckMonad checks the monad laws for a type M holding values of type T,
+// where unit wraps a single value and bind feeds every value in an M to a
+// function that returns a new M, and combines the results:
+//
+//   - left identity: bind(unit(a), f) == f(a)
+//   - right identity: bind(m, unit) == m
+//   - associativity: bind(bind(m, f), g) == bind(m, func(x) { bind(f(x), g) })
+//
+// Values are drawn from genT, monads from genM, and f and g from every pair
+// of functions in fns
+func CheckMonad[M, T any](
+	t testing.TB,
+	unit func(T) M,
+	bind func(M, func(T) M) M,
+	genT func(*rand.Rand) T,
+	genM func(*rand.Rand) M,
+	fns []func(T) M,
+	opts ...Option,
+) {
+	t.Helper()
+	cfg := newConfig(opts)
+	check(t, cfg, "monad right identity", func(rnd *rand.Rand) (bool, []any) {
+		m := genM(rnd)
+		return reflect.DeepEqual(bind(m, unit), m), []any{m}
+	})
+	for _, f := range fns {
+		check(t, cfg, "monad left identity", func(rnd *rand.Rand) (bool, []any) {
+			a := genT(rnd)
+			return reflect.DeepEqual(bind(unit(a), f), f(a)), []any{a}
+		})
+		for _, g := range fns {
+			check(t, cfg, "monad associativity", func(rnd *rand.Rand) (bool, []any) {
+				m := genM(rnd)
+				lhs := bind(bind(m, f), g)
+				rhs := bind(m, func(x T) M { return bind(f(x), g) })
+				return reflect.DeepEqual(lhs, rhs), []any{m}
+			})
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package laws
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-functional/core/monoid"
+	"github.com/go-functional/core/slice"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder is a testing.TB that records failures instead of failing the
+// test, so the checkers can be tested against broken implementations
+type recorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func genInt(r *rand.Rand) int { return r.IntN(1000) - 500 }
+
+func genSlice(r *rand.Rand) []int {
+	ret := make([]int, r.IntN(5))
+	for i := range ret {
+		ret[i] = genInt(r)
+	}
+	return ret
+}
+
+func mapSlice(slc []int, fn func(int) int) []int {
+	ret, _ := slice.Map(slc, func(_ uint, i int) (int, error) { return fn(i), nil })
+	return ret
+}
+
+func TestCheckMonoid(t *testing.T) {
+	r := require.New(t)
+	CheckMonoid(t, monoid.Sum[int](), genInt)
+	CheckMonoid(t, monoid.String(), func(r *rand.Rand) string {
+		return strings.Repeat("x", r.IntN(3))
+	}, Iterations(10))
+
+	rec := &recorder{TB: t}
+	minus := monoid.New(func() int { return 0 }, func(a, b int) int { return a - b })
+	CheckMonoid(rec, minus, genInt, Seed(1))
+	r.Len(rec.failures, 2)
+	r.Contains(rec.failures[0], "associativity")
+	r.Contains(rec.failures[1], "left identity")
+	r.Contains(rec.failures[1], "seed 1")
+}
+
+func TestCheckFunctor(t *testing.T) {
+	r := require.New(t)
+	double := func(i int) int { return i * 2 }
+	inc := func(i int) int { return i + 1 }
+	CheckFunctor(t, mapSlice, genSlice, double, inc)
+
+	rec := &recorder{TB: t}
+	reversing := func(slc []int, fn func(int) int) []int {
+		ret := mapSlice(slc, fn)
+		slices.Reverse(ret)
+		return ret
+	}
+	CheckFunctorWith(rec, reversing, func(*rand.Rand) []int { return []int{1, 2} }, nil)
+	r.Len(rec.failures, 1)
+	r.Contains(rec.failures[0], "functor identity")
+}
+
+func TestCheckMonad(t *testing.T) {
+	r := require.New(t)
+	unit := func(i int) []int { return []int{i} }
+	bind := func(m []int, fn func(int) []int) []int { return slice.FlatMap(m, fn) }
+	fns := []func(int) []int{
+		func(i int) []int { return []int{i, i} },
+		func(i int) []int { return []int{-i} },
+	}
+	// FlatMap returns an empty, non-nil slice for no results, so generate
+	// those rather than nil
+	genM := func(r *rand.Rand) []int { return append([]int{}, genSlice(r)...) }
+	CheckMonad(t, unit, bind, genInt, genM, fns)
+
+	rec := &recorder{TB: t}
+	dropFirst := func(m []int, fn func(int) []int) []int {
+		ret := bind(m, fn)
+		if len(ret) > 0 {
+			return ret[1:]
+		}
+		return ret
+	}
+	CheckMonad(rec, unit, dropFirst, genInt, func(*rand.Rand) []int { return []int{1} }, fns[:1])
+	r.NotEmpty(rec.failures)
+	r.Contains(rec.failures[0], "right identity")
+}
@@ -0,0 +1,60 @@
+package mapx
+
+// MapKeysAndValues creates a new map by calling fn on every entry in m and
+// storing the value it returns at the key it returns. If fn returns the same
+// key for more than one entry, which value ends up in the new map is
+// unspecified, since map iteration order is.
+//
+// Example usage:
+//
+//	byName := map[string]int{"a": 1, "b": 2}
+//	byID := MapKeysAndValues(byName, func(name string, id int) (int, string) {
+//		return id, name
+//	})
+//	// byID will be map[int]string{1: "a", 2: "b"}
+func MapKeysAndValues[K1, K2 comparable, V1, V2 any](
+	m map[K1]V1,
+	fn func(K1, V1) (K2, V2),
+) map[K2]V2 {
+	ret := make(map[K2]V2, len(m))
+	for k, v := range m {
+		k2, v2 := fn(k, v)
+		ret[k2] = v2
+	}
+	return ret
+}
+
+// ToSlice creates a new slice holding the result of calling fn on every
+// entry in m. The order of the returned slice is unspecified, since map
+// iteration order is, so sort it if order matters.
+//
+// Example usage:
+//
+//	lines := ToSlice(counts, func(word string, n int) string {
+//		return fmt.Sprintf("%s: %d", word, n)
+//	})
+func ToSlice[K comparable, V, T any](m map[K]V, fn func(K, V) T) []T {
+	ret := make([]T, 0, len(m))
+	for k, v := range m {
+		ret = append(ret, fn(k, v))
+	}
+	return ret
+}
+
+// FromSlice creates a new map from the key/value pairs that fn returns for
+// every element in slc. If fn returns the same key for more than one element,
+// the value for the last of those elements wins.
+//
+// Example usage:
+//
+//	byID := FromSlice(users, func(u User) (int, User) {
+//		return u.ID, u
+//	})
+func FromSlice[T any, K comparable, V any](slc []T, fn func(T) (K, V)) map[K]V {
+	ret := make(map[K]V, len(slc))
+	for _, t := range slc {
+		k, v := fn(t)
+		ret[k] = v
+	}
+	return ret
+}
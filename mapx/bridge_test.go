@@ -0,0 +1,31 @@
+package mapx
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapKeysAndValues(t *testing.T) {
+	r := require.New(t)
+	byID := MapKeysAndValues(map[string]int{"a": 1, "b": 2}, func(name string, id int) (int, string) {
+		return id, name
+	})
+	r.Equal(map[int]string{1: "a", 2: "b"}, byID)
+}
+
+func TestToAndFromSlice(t *testing.T) {
+	r := require.New(t)
+	lines := ToSlice(map[string]int{"a": 1, "b": 2}, func(k string, v int) string {
+		return fmt.Sprintf("%s=%d", k, v)
+	})
+	sort.Strings(lines)
+	r.Equal([]string{"a=1", "b=2"}, lines)
+
+	lens := FromSlice([]string{"a", "bb", "cc"}, func(s string) (int, string) {
+		return len(s), s
+	})
+	r.Equal(map[int]string{1: "a", 2: "cc"}, lens)
+}
@@ -0,0 +1,23 @@
+package mapx
+
+// Clone returns a shallow copy of m: a new map with the same entries, so
+// adding to or deleting from one doesn't affect the other. Values that hold
+// pointers, slices or maps still share what they point to; use CloneBy to
+// copy those too. Returns nil if m is nil
+func Clone[K comparable, V any](m map[K]V) map[K]V {
+	return CloneBy(m, func(v V) V { return v })
+}
+
+// CloneBy returns a new map with the same keys as m, holding cloneVal
+// applied to each of its values, for a deep copy that shares nothing with m.
+// Returns nil if m is nil
+func CloneBy[K comparable, V any](m map[K]V, cloneVal func(V) V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	ret := make(map[K]V, len(m))
+	for k, v := range m {
+		ret[k] = cloneVal(v)
+	}
+	return ret
+}
@@ -0,0 +1,24 @@
+package mapx
+
+import (
+	"testing"
+
+	"github.com/go-functional/core/slice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	r := require.New(t)
+	in := map[string]int{"a": 1}
+	out := Clone(in)
+	r.Equal(in, out)
+	out["b"] = 2
+	r.Len(in, 1)
+	r.Nil(Clone(map[string]int(nil)))
+
+	deep := map[string][]int{"a": {1, 2}}
+	deepOut := CloneBy(deep, slice.Clone[int])
+	deepOut["a"][0] = 9
+	r.Equal(1, deep["a"][0])
+	r.Nil(CloneBy(map[string][]int(nil), slice.Clone[int]))
+}
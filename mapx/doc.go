@@ -0,0 +1,4 @@
+// Package mapx provides fundamental operations on maps, and bridges between
+// maps and slices, so pipelines can flow from one to the other without a
+// hand-written loop at each boundary.
+package mapx
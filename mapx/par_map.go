@@ -0,0 +1,51 @@
+package mapx
+
+import (
+	"context"
+
+	"github.com/go-functional/core/par"
+)
+
+// ParMapMap calls fn on every entry in m in parallel, and returns a new map
+// with the same keys holding the values fn returned. It has the same error
+// semantics as slice.ParMap: fn is passed a context derived from ctx, and if
+// any call returns an error, the first error is returned along with a nil
+// map.
+//
+// Per-key work often means calling a remote service, so consider passing
+// par.Limit to bound how many calls run at once.
+//
+// Example usage:
+//
+//	details, err := ParMapMap(ctx, ids, func(ctx context.Context, name string, id int) (Detail, error) {
+//		return client.GetDetail(ctx, id)
+//	}, par.Limit(10))
+func ParMapMap[K comparable, V, U any](
+	ctx context.Context,
+	m map[K]V,
+	fn func(context.Context, K, V) (U, error),
+	opts ...par.Option,
+) (map[K]U, error) {
+	// collecting the values alongside the keys, rather than looking them up
+	// again, keeps NaN keys, which never equal themselves, working
+	keys := make([]K, 0, len(m))
+	vals := make([]V, 0, len(m))
+	for k, v := range m {
+		keys = append(keys, k)
+		vals = append(vals, v)
+	}
+	results := make([]U, len(keys))
+	err := par.Do(ctx, len(keys), func(ctx context.Context, i int) error {
+		u, err := fn(ctx, keys[i], vals[i])
+		results[i] = u
+		return err
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	ret := make(map[K]U, len(keys))
+	for i, k := range keys {
+		ret[k] = results[i]
+	}
+	return ret, nil
+}
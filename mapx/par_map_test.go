@@ -0,0 +1,46 @@
+package mapx
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParMapMap(t *testing.T) {
+	r := require.New(t)
+	m := map[string]int{"a": 1, "b": 2, "c": 3}
+	ret, err := ParMapMap(context.Background(), m, func(_ context.Context, k string, v int) (string, error) {
+		return k + strconv.Itoa(v), nil
+	}, par.Limit(2))
+	r.NoError(err)
+	r.Equal(map[string]string{"a": "a1", "b": "b2", "c": "c3"}, ret)
+
+	boom := errors.New("boom")
+	ints, err := ParMapMap(context.Background(), m, func(_ context.Context, k string, v int) (int, error) {
+		if k == "b" {
+			return 0, boom
+		}
+		return v, nil
+	})
+	r.ErrorIs(err, boom)
+	r.Nil(ints)
+
+	// a NaN key can't be looked up, but fn still gets its value
+	nan := map[float64]int{math.NaN(): 7, 1: 1}
+	doubled, err := ParMapMap(context.Background(), nan, func(_ context.Context, _ float64, v int) (int, error) {
+		return v * 2, nil
+	})
+	r.NoError(err)
+	r.Len(doubled, 2)
+	r.Equal(2, doubled[1])
+	for k, v := range doubled {
+		if math.IsNaN(k) {
+			r.Equal(14, v)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Package memo memoizes functions, caching their results by argument.
+package memo
+
+import "sync"
+
+// Fix ties the knot for a recursive function, memoizing every call. fn is
+// the body of the function, written to call self for its recursive calls
+// rather than calling itself by name. Fix returns the function itself, in
+// which every result, including those of recursive calls, is computed once
+// per key and then served from a cache that lives as long as the returned
+// function does.
+//
+// That lets dynamic-programming recurrences be written the way they're
+// defined, without the exponential blowup of naive recursion or the
+// bookkeeping of a hand-written table.
+//
+// The returned function is safe for concurrent use. Calls that race on the
+// same uncached key may each compute it, but they all get the same answer
+// as long as fn depends only on its arguments. fn must not call
+// self with k itself, or it will recurse forever, just as the unmemoized
+// function would.
+//
+// Example usage:
+//
+//	fib := memo.Fix(func(self func(int) int, n int) int {
+//		if n < 2 {
+//			return n
+//		}
+//		return self(n-1) + self(n-2)
+//	})
+//	fib(90) // 2880067194370816120, in 91 calls to the body
+func Fix[K comparable, V any](fn func(self func(K) V, k K) V) func(K) V {
+	var (
+		mut   sync.Mutex
+		cache = map[K]V{}
+		self  func(K) V
+	)
+	self = func(k K) V {
+		mut.Lock()
+		v, ok := cache[k]
+		mut.Unlock()
+		if ok {
+			return v
+		}
+		// the lock isn't held while fn runs, since fn calls back into self
+		v = fn(self, k)
+		mut.Lock()
+		cache[k] = v
+		mut.Unlock()
+		return v
+	}
+	return self
+}
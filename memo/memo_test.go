@@ -0,0 +1,60 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+
+	core "github.com/go-functional/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFix(t *testing.T) {
+	r := require.New(t)
+	calls := 0
+	fib := Fix(func(self func(int) int, n int) int {
+		calls++
+		if n < 2 {
+			return n
+		}
+		return self(n-1) + self(n-2)
+	})
+	r.Equal(2880067194370816120, fib(90))
+	r.Equal(91, calls)
+	r.Equal(55, fib(10))
+	r.Equal(91, calls)
+
+	// a two-argument recurrence, keyed by a Tuple: the edit distance between
+	// prefixes of a and b
+	a, b := "kitten", "sitting"
+	dist := Fix(func(self func(core.Tuple[int, int]) int, k core.Tuple[int, int]) int {
+		i, j := core.First(k), core.Second(k)
+		switch {
+		case i == 0:
+			return j
+		case j == 0:
+			return i
+		}
+		cost := 1
+		if a[i-1] == b[j-1] {
+			cost = 0
+		}
+		return min(self(core.Tup(i-1, j))+1, self(core.Tup(i, j-1))+1, self(core.Tup(i-1, j-1))+cost)
+	})
+	r.Equal(3, dist(core.Tup(len(a), len(b))))
+}
+
+func TestFixConcurrent(t *testing.T) {
+	r := require.New(t)
+	sq := Fix(func(_ func(int) int, n int) int { return n * n })
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				r.Equal(j*j, sq(j))
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,160 @@
+// Package monoid provides the Semigroup and Monoid abstractions, some stock
+// instances of each, and folds that use them.
+//
+// A Semigroup is a type with an associative Combine operation. A Monoid is a
+// Semigroup that also has an Empty value, which leaves any value unchanged
+// when combined with it. Because Combine is associative, a fold over a
+// Monoid gives the same answer no matter how the input is split up, which
+// makes monoids a good fit for parallel aggregation.
+package monoid
+
+import (
+	"cmp"
+	"maps"
+
+	"github.com/go-functional/core/constraints"
+)
+
+// Semigroup is a type with an associative binary operation. Implementations
+// must guarantee that Combine(Combine(a, b), c) == Combine(a, Combine(b, c))
+type Semigroup[T any] interface {
+	Combine(a, b T) T
+}
+
+// Monoid is a Semigroup with an identity element. Implementations must
+// guarantee that Combine(Empty(), a) == Combine(a, Empty()) == a
+type Monoid[T any] interface {
+	Semigroup[T]
+	Empty() T
+}
+
+type funcs[T any] struct {
+	empty   func() T
+	combine func(a, b T) T
+}
+
+func (f funcs[T]) Empty() T         { return f.empty() }
+func (f funcs[T]) Combine(a, b T) T { return f.combine(a, b) }
+
+// New creates a Monoid from an identity function and combine operation. It's
+// up to the caller to make sure they obey the Monoid laws
+func New[T any](empty func() T, combine func(a, b T) T) Monoid[T] {
+	return funcs[T]{empty: empty, combine: combine}
+}
+
+// Sum returns a Monoid that adds numbers, with 0 as the identity
+func Sum[N constraints.Number]() Monoid[N] {
+	return New(
+		func() N { return 0 },
+		func(a, b N) N { return a + b },
+	)
+}
+
+// Product returns a Monoid that multiplies numbers, with 1 as the identity
+func Product[N constraints.Number]() Monoid[N] {
+	return New(
+		func() N { return 1 },
+		func(a, b N) N { return a * b },
+	)
+}
+
+// Min returns a Monoid that picks the smaller of two values. top is the
+// identity, so it should be greater than or equal to every value you'll
+// combine, for example math.MaxInt. It's also what folding an empty slice
+// returns
+func Min[T cmp.Ordered](top T) Monoid[T] {
+	return New(
+		func() T { return top },
+		func(a, b T) T { return min(a, b) },
+	)
+}
+
+// Max returns a Monoid that picks the larger of two values. bottom is the
+// identity, so it should be less than or equal to every value you'll combine,
+// for example math.MinInt. It's also what folding an empty slice returns
+func Max[T cmp.Ordered](bottom T) Monoid[T] {
+	return New(
+		func() T { return bottom },
+		func(a, b T) T { return max(a, b) },
+	)
+}
+
+// String returns a Monoid that concatenates strings, with "" as the identity
+func String() Monoid[string] {
+	return New(
+		func() string { return "" },
+		func(a, b string) string { return a + b },
+	)
+}
+
+// Slice returns a Monoid that appends slices, with an empty slice as the
+// identity. Combine always returns a new slice, so neither input is modified
+func Slice[T any]() Monoid[[]T] {
+	return New(
+		func() []T { return nil },
+		func(a, b []T) []T {
+			ret := make([]T, 0, len(a)+len(b))
+			ret = append(ret, a...)
+			return append(ret, b...)
+		},
+	)
+}
+
+// MapMerge returns a Monoid that merges maps, with an empty map as the
+// identity. When both maps have the same key, the values are merged with
+// values. Combine always returns a new map, so neither input is modified.
+//
+// Example usage, merging word counts:
+//
+//	counts := MapMerge[string](Sum[int]())
+//	merged := counts.Combine(
+//		map[string]int{"a": 1, "b": 2},
+//		map[string]int{"b": 3},
+//	)
+//	// merged will be map[string]int{"a": 1, "b": 5}
+func MapMerge[K comparable, V any](values Semigroup[V]) Monoid[map[K]V] {
+	return New(
+		func() map[K]V { return map[K]V{} },
+		func(a, b map[K]V) map[K]V {
+			ret := maps.Clone(a)
+			if ret == nil {
+				ret = make(map[K]V, len(b))
+			}
+			for k, bv := range b {
+				if av, ok := ret[k]; ok {
+					ret[k] = values.Combine(av, bv)
+				} else {
+					ret[k] = bv
+				}
+			}
+			return ret
+		},
+	)
+}
+
+// Fold combines every element in slc, in order, starting from m.Empty().
+// Returns m.Empty() if slc is empty
+func Fold[T any](slc []T, m Monoid[T]) T {
+	acc := m.Empty()
+	for _, t := range slc {
+		acc = m.Combine(acc, t)
+	}
+	return acc
+}
+
+// FoldMap calls fn on every element in slc and combines the results in order,
+// starting from m.Empty(). Returns m.Empty() if slc is empty.
+//
+// Example usage:
+//
+//	totalLen := FoldMap([]string{"a", "bb", "ccc"}, Sum[int](), func(s string) int {
+//		return len(s)
+//	})
+//	// totalLen will be 6
+func FoldMap[T, M any](slc []T, m Monoid[M], fn func(T) M) M {
+	acc := m.Empty()
+	for _, t := range slc {
+		acc = m.Combine(acc, fn(t))
+	}
+	return acc
+}
@@ -0,0 +1,42 @@
+package monoid
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStockInstances(t *testing.T) {
+	r := require.New(t)
+	ints := []int{3, 1, 4, 1, 5}
+	r.Equal(14, Fold(ints, Sum[int]()))
+	r.Equal(60, Fold(ints, Product[int]()))
+	r.Equal(1, Fold(ints, Min(math.MaxInt)))
+	r.Equal(5, Fold(ints, Max(math.MinInt)))
+	r.Equal(math.MaxInt, Fold(nil, Min(math.MaxInt)))
+	r.Equal("abc", Fold([]string{"a", "b", "c"}, String()))
+
+	a := []int{1, 2}
+	joined := Slice[int]().Combine(a[:1], []int{3})
+	r.Equal([]int{1, 3}, joined)
+	r.Equal([]int{1, 2}, a)
+}
+
+func TestMapMerge(t *testing.T) {
+	r := require.New(t)
+	counts := MapMerge[string](Sum[int]())
+	a := map[string]int{"a": 1, "b": 2}
+	merged := counts.Combine(a, map[string]int{"b": 3})
+	r.Equal(map[string]int{"a": 1, "b": 5}, merged)
+	r.Equal(map[string]int{"a": 1, "b": 2}, a)
+	r.Equal(map[string]int{"x": 1}, counts.Combine(nil, map[string]int{"x": 1}))
+}
+
+func TestFoldMap(t *testing.T) {
+	r := require.New(t)
+	totalLen := FoldMap([]string{"a", "bb", "ccc"}, Sum[int](), func(s string) int {
+		return len(s)
+	})
+	r.Equal(6, totalLen)
+}
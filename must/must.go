@@ -0,0 +1,52 @@
+// Package must provides helpers that unwrap a value or panic.
+//
+// They're meant for test code and program initialization, where a failure
+// can't be recovered from anyway and checking every error by hand obscures
+// what the code is doing. Don't use them on errors that can happen in normal
+// operation.
+package must
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Get returns v if err is nil, and panics with an error wrapping err
+// otherwise. The panic value is always an error, so callers that recover it
+// can still use errors.Is and errors.As.
+//
+// Example usage:
+//
+//	var re = Get(regexp.Compile(`^[a-z]+$`))
+func Get[T any](v T, err error) T {
+	if err != nil {
+		panic(fmt.Errorf("must: %w", err))
+	}
+	return v
+}
+
+// OK returns v if ok is true, and panics otherwise. It's the Get equivalent
+// for functions that use the "comma ok" form, like map lookups and type
+// assertions
+func OK[T any](v T, ok bool) T {
+	if !ok {
+		panic(errors.New("must: got a value that wasn't ok"))
+	}
+	return v
+}
+
+// Expect is the same as Get, except the panic's error includes msg, for more
+// context about what went wrong. Go doesn't allow passing a multi-value call
+// alongside another argument, so the value and error have to be assigned
+// first.
+//
+// Example usage:
+//
+//	cfg, err := LoadConfig(path)
+//	cfg = Expect(cfg, err, "loading config")
+func Expect[T any](v T, err error, msg string) T {
+	if err != nil {
+		panic(fmt.Errorf("must: %s: %w", msg, err))
+	}
+	return v
+}
@@ -0,0 +1,49 @@
+package must
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func recovered(fn func()) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err, _ = p.(error)
+		}
+	}()
+	fn()
+	return nil
+}
+
+func TestGet(t *testing.T) {
+	r := require.New(t)
+	r.Equal(12, Get(strconv.Atoi("12")))
+
+	err := recovered(func() { Get(strconv.Atoi("x")) })
+	var numErr *strconv.NumError
+	r.True(errors.As(err, &numErr))
+	r.Contains(err.Error(), "must: ")
+}
+
+func TestOK(t *testing.T) {
+	r := require.New(t)
+	m := map[string]int{"a": 1}
+	lookup := func(k string) (int, bool) {
+		v, ok := m[k]
+		return v, ok
+	}
+	r.Equal(1, OK(lookup("a")))
+	r.Panics(func() { OK(lookup("b")) })
+}
+
+func TestExpect(t *testing.T) {
+	r := require.New(t)
+	sentinel := errors.New("boom")
+	err := recovered(func() { Expect(0, sentinel, "loading config") })
+	r.ErrorIs(err, sentinel)
+	r.EqualError(err, "must: loading config: boom")
+	r.NoError(recovered(func() { Expect(0, nil, "unused") }))
+}
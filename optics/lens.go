@@ -0,0 +1,104 @@
+// Package optics provides lenses, which focus on one part of a larger value so
+// it can be read or updated without mutating the original.
+//
+// Lenses compose, so a nested update like "set the city of the address of
+// the user" can be written once and reused, rather than as a pyramid of
+// copies and reassignments.
+package optics
+
+import (
+	"maps"
+	"slices"
+)
+
+// Lens focuses on a value of type A inside a value of type S
+type Lens[S, A any] struct {
+	get func(S) A
+	set func(S, A) S
+}
+
+// New creates a Lens from a getter and a setter. set must return an updated
+// copy of its first argument, and must not modify it.
+//
+// Example usage:
+//
+//	cityLens := New(
+//		func(a Address) string { return a.City },
+//		func(a Address, city string) Address {
+//			a.City = city
+//			return a
+//		},
+//	)
+func New[S, A any](get func(S) A, set func(S, A) S) Lens[S, A] {
+	return Lens[S, A]{get: get, set: set}
+}
+
+// Get returns the value that l focuses on inside s
+func (l Lens[S, A]) Get(s S) A {
+	return l.get(s)
+}
+
+// Set returns a copy of s with the value that l focuses on replaced by a
+func (l Lens[S, A]) Set(s S, a A) S {
+	return l.set(s, a)
+}
+
+// Modify returns a copy of s with the value that l focuses on replaced by the
+// result of calling fn on it
+func (l Lens[S, A]) Modify(s S, fn func(A) A) S {
+	return l.set(s, fn(l.get(s)))
+}
+
+// Compose returns a Lens that focuses on the value inner focuses on inside
+// the value outer focuses on.
+//
+// Example usage:
+//
+//	userCity := Compose(userAddress, addressCity)
+//	moved := userCity.Set(user, "Portland")
+//	// user is unchanged, and moved.Address.City is "Portland"
+func Compose[S, A, B any](outer Lens[S, A], inner Lens[A, B]) Lens[S, B] {
+	return Lens[S, B]{
+		get: func(s S) B {
+			return inner.get(outer.get(s))
+		},
+		set: func(s S, b B) S {
+			return outer.set(s, inner.set(outer.get(s), b))
+		},
+	}
+}
+
+// Index returns a Lens that focuses on the element at index i of a slice.
+// Set returns a copy of the slice, so the original is never modified. Like
+// indexing a slice, Get and Set panic if i is out of range
+func Index[T any](i int) Lens[[]T, T] {
+	return Lens[[]T, T]{
+		get: func(slc []T) T {
+			return slc[i]
+		},
+		set: func(slc []T, t T) []T {
+			ret := slices.Clone(slc)
+			ret[i] = t
+			return ret
+		},
+	}
+}
+
+// Key returns a Lens that focuses on the value at key k of a map. Get returns
+// the zero value of V if k isn't in the map, and Set returns a copy of the
+// map, so the original is never modified
+func Key[K comparable, V any](k K) Lens[map[K]V, V] {
+	return Lens[map[K]V, V]{
+		get: func(m map[K]V) V {
+			return m[k]
+		},
+		set: func(m map[K]V, v V) map[K]V {
+			ret := maps.Clone(m)
+			if ret == nil {
+				ret = map[K]V{}
+			}
+			ret[k] = v
+			return ret
+		},
+	}
+}
@@ -0,0 +1,71 @@
+package optics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type address struct {
+	City string
+}
+
+type user struct {
+	Name    string
+	Address address
+	Tags    []string
+}
+
+var (
+	userAddress = New(
+		func(u user) address { return u.Address },
+		func(u user, a address) user {
+			u.Address = a
+			return u
+		},
+	)
+	addressCity = New(
+		func(a address) string { return a.City },
+		func(a address, city string) address {
+			a.City = city
+			return a
+		},
+	)
+	userTags = New(
+		func(u user) []string { return u.Tags },
+		func(u user, tags []string) user {
+			u.Tags = tags
+			return u
+		},
+	)
+)
+
+func TestCompose(t *testing.T) {
+	r := require.New(t)
+	u := user{Name: "a", Address: address{City: "Boston"}}
+	userCity := Compose(userAddress, addressCity)
+	r.Equal("Boston", userCity.Get(u))
+
+	moved := userCity.Set(u, "Portland")
+	r.Equal("Portland", moved.Address.City)
+	r.Equal("Boston", u.Address.City)
+
+	shouted := userCity.Modify(u, func(s string) string { return s + "!" })
+	r.Equal("Boston!", shouted.Address.City)
+}
+
+func TestIndexAndKey(t *testing.T) {
+	r := require.New(t)
+	u := user{Tags: []string{"x", "y"}}
+	second := Compose(userTags, Index[string](1))
+	updated := second.Set(u, "z")
+	r.Equal([]string{"x", "z"}, updated.Tags)
+	r.Equal([]string{"x", "y"}, u.Tags)
+
+	m := map[string]int{"a": 1}
+	b := Key[string, int]("b")
+	r.Equal(0, b.Get(m))
+	r.Equal(map[string]int{"a": 1, "b": 2}, b.Set(m, 2))
+	r.Equal(map[string]int{"a": 1}, m)
+	r.Equal(map[string]int{"b": 2}, b.Set(nil, 2))
+}
@@ -0,0 +1,90 @@
+// Package otelx traces the parallel combinators in this repository with
+// OpenTelemetry.
+//
+// It's a separate module, so the rest of the repository doesn't depend on
+// OpenTelemetry.
+package otelx
+
+import (
+	"context"
+
+	"github.com/go-functional/core/par"
+	"github.com/go-functional/core/slice"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentation is the name of the tracer this package uses when it's not
+// given one
+const instrumentation = "github.com/go-functional/core/otelx"
+
+// Option configures ParMapTraced
+type Option func(*config)
+
+type config struct {
+	tracer  trace.Tracer
+	parOpts []par.Option
+}
+
+// Tracer sets the tracer spans are started with. The default is the tracer
+// named after this package, from the global TracerProvider
+func Tracer(t trace.Tracer) Option {
+	return func(c *config) {
+		c.tracer = t
+	}
+}
+
+// Par passes opts, like par.Limit, through to ParMap
+func Par(opts ...par.Option) Option {
+	return func(c *config) {
+		c.parOpts = append(c.parOpts, opts...)
+	}
+}
+
+// ParMapTraced is the same as slice.ParMap, except the whole call runs in a
+// span called name, and every call to fn in a child span of it, called name
+// followed by "/element", with the element's index as the "index" attribute.
+// fn's ctx carries its span, so spans fn starts are children of it. A call
+// that returns an error records it and marks its span, and the parent span,
+// as failed, so traces show how a batch fanned out and which element broke
+// it.
+//
+// Example usage:
+//
+//	pages, err := otelx.ParMapTraced(ctx, "fetch", urls, fetch, otelx.Par(par.Limit(8)))
+func ParMapTraced[T, U any](
+	ctx context.Context,
+	name string,
+	slc []T,
+	fn func(context.Context, uint, T) (U, error),
+	opts ...Option,
+) ([]U, error) {
+	cfg := config{}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.tracer == nil {
+		cfg.tracer = otel.Tracer(instrumentation)
+	}
+
+	ctx, span := cfg.tracer.Start(ctx, name, trace.WithAttributes(attribute.Int("elements", len(slc))))
+	defer span.End()
+	elementName := name + "/element"
+	ret, err := slice.ParMap(ctx, slc, func(ctx context.Context, i uint, t T) (U, error) {
+		ctx, span := cfg.tracer.Start(ctx, elementName, trace.WithAttributes(attribute.Int("index", int(i))))
+		defer span.End()
+		u, err := fn(ctx, i, t)
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		return u, err
+	}, cfg.parOpts...)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return ret, err
+}
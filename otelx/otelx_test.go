@@ -0,0 +1,53 @@
+package otelx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestParMapTraced(t *testing.T) {
+	r := require.New(t)
+	rec := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	tracer := tp.Tracer("test")
+
+	out, err := ParMapTraced(context.Background(), "double", []int{1, 2, 3}, func(_ context.Context, _ uint, v int) (int, error) {
+		return v * 2, nil
+	}, Tracer(tracer), Par(par.Limit(2)))
+	r.NoError(err)
+	r.Equal([]int{2, 4, 6}, out)
+
+	spans := rec.Ended()
+	r.Len(spans, 4)
+	parent := spans[len(spans)-1]
+	r.Equal("double", parent.Name())
+	indices := map[int64]bool{}
+	for _, s := range spans[:3] {
+		r.Equal("double/element", s.Name())
+		r.Equal(parent.SpanContext().SpanID(), s.Parent().SpanID())
+		for _, kv := range s.Attributes() {
+			if kv.Key == "index" {
+				indices[kv.Value.AsInt64()] = true
+			}
+		}
+	}
+	r.Equal(map[int64]bool{0: true, 1: true, 2: true}, indices)
+
+	bad := errors.New("bad")
+	rec = tracetest.NewSpanRecorder()
+	tp = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec))
+	_, err = ParMapTraced(context.Background(), "fail", []int{1}, func(context.Context, uint, int) (int, error) {
+		return 0, bad
+	}, Tracer(tp.Tracer("test")))
+	r.ErrorIs(err, bad)
+	for _, s := range rec.Ended() {
+		r.Equal(codes.Error, s.Status().Code)
+	}
+}
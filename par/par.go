@@ -0,0 +1,369 @@
+// Package par runs work in parallel. It's the engine behind the parallel
+// combinators in this repository, like slice.ParMap and mapx.ParMapMap, and
+// holds the options that configure all of them.
+//
+// Because every parallel combinator runs through Do, an Option means the same
+// thing wherever it's passed.
+package par
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Option configures how Do, and the parallel combinators built on it, run
+type Option func(*config)
+
+type config struct {
+	limit int
+	// seed is non-nil in deterministic mode
+	seed *uint64
+	// adaptive is the threshold for Adaptive, or 0 if it's not set
+	adaptive time.Duration
+	logger   *slog.Logger
+	// timeout is the ElementTimeout, or 0 if it's not set
+	timeout time.Duration
+	// grain is the chunk size for WorkStealing, 0 for its default, or -1 if
+	// it's not set
+	grain int
+}
+
+// defaultSeed is the seed set by SetDeterministic, or nil
+var defaultSeed atomic.Pointer[uint64]
+
+func newConfig(opts []Option) config {
+	cfg := config{grain: -1}
+	cfg.seed = defaultSeed.Load()
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Limit caps the number of calls to fn that run at the same time at n. By
+// default, every call gets its own goroutine and they all run at once. n <= 0
+// means no limit.
+//
+// Example usage, fetching at most 8 URLs at a time:
+//
+//	pages, err := slice.ParMap(ctx, urls, fetch, par.Limit(8))
+func Limit(n int) Option {
+	return func(c *config) {
+		c.limit = n
+	}
+}
+
+// DefaultAdaptiveThreshold is the threshold Adaptive uses when it's passed
+// a threshold <= 0. It's a few times the cost of starting and waiting for a
+// goroutine, so calls that take less than this are cheaper to run in a loop
+const DefaultAdaptiveThreshold = 20 * time.Microsecond
+
+// Adaptive makes Do decide for itself whether running calls in parallel is
+// worth it. It times the first few calls, running them one at a time, and if
+// they took less than threshold each on average, it runs the rest in a loop
+// on the calling goroutine, the same as a plain Map would. Otherwise it runs
+// the rest in parallel, respecting Limit. A threshold <= 0 means
+// DefaultAdaptiveThreshold.
+//
+// Example usage, for a callback whose cost depends on the input:
+//
+//	out, err := slice.ParMap(ctx, docs, render, par.Adaptive(0))
+func Adaptive(threshold time.Duration) Option {
+	if threshold <= 0 {
+		threshold = DefaultAdaptiveThreshold
+	}
+	return func(c *config) {
+		c.adaptive = threshold
+	}
+}
+
+// Deterministic runs every call to fn one at a time, on the calling
+// goroutine, in an order picked by shuffling the indices with seed. The same
+// seed always gives the same order, so a test that exercises parallel code
+// with it gets the same interleaving on every run, and a flaky failure can be
+// reproduced and bisected by trying seeds. Limit has no effect in this mode.
+//
+// It's meant for tests. The calls don't run in parallel, so code that only
+// works if they do, like calls that wait for each other, will deadlock.
+//
+// Example usage:
+//
+//	for seed := range uint64(20) {
+//		out, err := slice.ParMap(ctx, in, fn, par.Deterministic(seed))
+//		...
+//	}
+func Deterministic(seed uint64) Option {
+	return func(c *config) {
+		c.seed = &seed
+	}
+}
+
+// ElementTimeout gives every call to fn its own deadline, d after the call
+// starts, on top of any deadline ctx already has. A call that's still
+// running when its deadline passes sees its context cancelled, and if it
+// then fails, the error says which call timed out and wraps the call's own
+// error, which is usually context.DeadlineExceeded. That turns one stuck
+// element into an error, rather than a batch that never finishes, as long as
+// fn respects its context. d <= 0 means no per-call deadline.
+//
+// Example usage:
+//
+//	pages, err := slice.ParMap(ctx, urls, fetch, par.Limit(8), par.ElementTimeout(5*time.Second))
+func ElementTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.timeout = max(d, 0)
+	}
+}
+
+// WorkStealing makes DoChunks split the work into many small chunks of grain
+// indices each, rather than one big chunk per worker, and has the workers
+// claim the next unstarted chunk whenever they finish one. When some
+// elements cost far more than others, fixed chunks can leave one worker
+// with all the slow ones while the rest sit idle; with small chunks, the
+// idle workers take up the slack. Smaller grains balance better, at the cost
+// of more overhead per chunk. grain <= 0 picks a grain that gives each
+// worker about 16 chunks.
+//
+// It has no effect on Do, which already hands out one index at a time.
+//
+// Example usage:
+//
+//	out, err := functor.SliceMapChunked(ctx, docs, render, par.WorkStealing(256))
+func WorkStealing(grain int) Option {
+	return func(c *config) {
+		c.grain = max(grain, 0)
+	}
+}
+
+// stealingChunksPerWorker is how many chunks each worker gets, on average,
+// under WorkStealing with the default grain
+const stealingChunksPerWorker = 16
+
+// Logger makes Do log a debug record to l when each call to fn starts and
+// finishes, with the call's index, how long it took and the error it
+// returned, if any. Whether l has debug logging enabled is checked once per
+// call to Do, so leaving Logger in place costs next to nothing when it's
+// disabled. A nil l turns logging off.
+//
+// Example usage:
+//
+//	out, err := slice.ParMap(ctx, jobs, run, par.Logger(slog.Default()))
+func Logger(l *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = l
+	}
+}
+
+// SetDeterministic makes every call to Do that follows run in deterministic
+// mode with seed, as if it had been passed Deterministic(seed), including
+// calls made deep inside code under test. It returns a function that
+// restores the previous mode, meant to be deferred:
+//
+//	defer par.SetDeterministic(42)()
+//
+// Since it changes global state, tests that use it can't run in parallel
+// with each other
+func SetDeterministic(seed uint64) (restore func()) {
+	prev := defaultSeed.Swap(&seed)
+	return func() { defaultSeed.Store(prev) }
+}
+
+// Do calls fn once for every index from 0 up to, but not including, n, in
+// parallel. Each call is passed a context derived from ctx, which is
+// cancelled as soon as any call returns a non-nil error. Do waits for every
+// call that started to return, then returns the first non-nil error, if any.
+//
+// When a Limit is set, Do stops starting new calls once the context is
+// cancelled, and returns the context's error if no call returned one.
+//
+// Example usage:
+//
+//	results := make([]Result, len(jobs))
+//	err := Do(ctx, len(jobs), func(ctx context.Context, i int) error {
+//		r, err := run(ctx, jobs[i])
+//		results[i] = r
+//		return err
+//	}, Limit(4))
+func Do(ctx context.Context, n int, fn func(ctx context.Context, i int) error, opts ...Option) error {
+	cfg := newConfig(opts)
+	if cfg.timeout > 0 {
+		fn = withTimeout(cfg.timeout, fn)
+	}
+	if cfg.logger != nil && cfg.logger.Enabled(ctx, slog.LevelDebug) {
+		fn = logged(cfg.logger, fn)
+	}
+	if cfg.seed != nil {
+		order := rand.New(rand.NewPCG(*cfg.seed, *cfg.seed)).Perm(n)
+		return doSequential(ctx, 0, n, func(ctx context.Context, k int) error {
+			return fn(ctx, order[k])
+		})
+	}
+	if cfg.adaptive > 0 {
+		return doAdaptive(ctx, n, fn, cfg)
+	}
+	return doParallel(ctx, 0, n, fn, cfg.limit)
+}
+
+// DoChunks splits the indices from 0 up to, but not including, n into
+// contiguous chunks, and calls fn once for each chunk, with its bounds, in
+// parallel, the same way Do calls fn for each index. There's one chunk per
+// call that can run at once: the Limit if one is set, or GOMAXPROCS
+// otherwise. Pass WorkStealing to use many smaller chunks instead, when
+// elements vary a lot in cost.
+//
+// It's for work whose per-element cost is too small to give every element
+// its own call. Since a chunk can be long, fn should check ctx every so often
+// and return ctx.Err() once it's done, so the work can be aborted promptly.
+//
+// Example usage:
+//
+//	err := par.DoChunks(ctx, len(out), func(ctx context.Context, lo, hi int) error {
+//		for i := lo; i < hi; i++ {
+//			if (i-lo)%4096 == 0 && ctx.Err() != nil {
+//				return ctx.Err()
+//			}
+//			out[i] = square(in[i])
+//		}
+//		return nil
+//	})
+func DoChunks(ctx context.Context, n int, fn func(ctx context.Context, lo, hi int) error, opts ...Option) error {
+	cfg := newConfig(opts)
+	workers := cfg.limit
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if cfg.grain >= 0 {
+		grain := cfg.grain
+		if grain == 0 {
+			grain = max(n/(workers*stealingChunksPerWorker), 1)
+		}
+		// written so a huge grain can't overflow
+		chunks := n / grain
+		if n%grain != 0 {
+			chunks++
+		}
+		// with a Limit, Do runs workers that each claim the next chunk from
+		// a shared counter, which is the stealing. opts is clipped so
+		// appending never writes into the caller's backing array
+		return Do(ctx, chunks, func(ctx context.Context, c int) error {
+			lo := c * grain
+			return fn(ctx, lo, lo+min(grain, n-lo))
+		}, append(slices.Clip(opts), Limit(workers))...)
+	}
+	chunks := max(min(workers, n), 1)
+	return Do(ctx, chunks, func(ctx context.Context, c int) error {
+		return fn(ctx, c*n/chunks, (c+1)*n/chunks)
+	}, opts...)
+}
+
+// doParallel calls fn for every index from lo up to n in parallel, with at
+// most limit calls at once if limit > 0
+func doParallel(ctx context.Context, lo, n int, fn func(context.Context, int) error, limit int) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	if limit <= 0 || limit >= n-lo {
+		for i := lo; i < n; i++ {
+			g.Go(func() error {
+				return fn(ctx, i)
+			})
+		}
+		return g.Wait()
+	}
+
+	// with a limit, start that many workers that each claim the next
+	// unprocessed index until there are none left
+	var next atomic.Int64
+	next.Store(int64(lo))
+	for w := 0; w < limit; w++ {
+		g.Go(func() error {
+			for {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				i := int(next.Add(1) - 1)
+				if i >= n {
+					return nil
+				}
+				if err := fn(ctx, i); err != nil {
+					return err
+				}
+			}
+		})
+	}
+	return g.Wait()
+}
+
+// doSequential calls fn for every index from lo up to n, one at a time, on
+// the calling goroutine, stopping at the first error
+func doSequential(ctx context.Context, lo, n int, fn func(context.Context, int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for i := lo; i < n; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(ctx, i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// adaptiveProbes is how many calls Adaptive times before deciding whether to
+// fan out
+const adaptiveProbes = 3
+
+func doAdaptive(ctx context.Context, n int, fn func(context.Context, int) error, cfg config) error {
+	probes := min(n, adaptiveProbes)
+	start := time.Now()
+	if err := doSequential(ctx, 0, probes, fn); err != nil {
+		return err
+	}
+	if probes == n {
+		return nil
+	}
+	if perCall := time.Since(start) / time.Duration(probes); perCall < cfg.adaptive {
+		return doSequential(ctx, probes, n, fn)
+	}
+	return doParallel(ctx, probes, n, fn, cfg.limit)
+}
+
+// logged wraps fn so every call to it is logged to l
+func logged(l *slog.Logger, fn func(context.Context, int) error) func(context.Context, int) error {
+	return func(ctx context.Context, i int) error {
+		l.DebugContext(ctx, "par: call started", slog.Int("index", i))
+		start := time.Now()
+		err := fn(ctx, i)
+		elapsed := time.Since(start)
+		if err != nil {
+			l.DebugContext(ctx, "par: call failed", slog.Int("index", i), slog.Duration("duration", elapsed), slog.Any("error", err))
+			return err
+		}
+		l.DebugContext(ctx, "par: call finished", slog.Int("index", i), slog.Duration("duration", elapsed))
+		return nil
+	}
+}
+
+// withTimeout wraps fn so every call to it gets a context with a deadline d
+// after it starts
+func withTimeout(d time.Duration, fn func(context.Context, int) error) func(context.Context, int) error {
+	return func(ctx context.Context, i int) error {
+		callCtx, cancel := context.WithTimeout(ctx, d)
+		defer cancel()
+		err := fn(callCtx, i)
+		// only blame the per-call deadline if it's what ended the call, not
+		// ctx itself
+		if err != nil && ctx.Err() == nil && callCtx.Err() != nil {
+			return fmt.Errorf("par: call %d timed out after %v: %w", i, d, err)
+		}
+		return err
+	}
+}
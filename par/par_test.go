@@ -0,0 +1,314 @@
+package par
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDo(t *testing.T) {
+	r := require.New(t)
+	results := make([]int, 100)
+	err := Do(context.Background(), len(results), func(_ context.Context, i int) error {
+		results[i] = i * 2
+		return nil
+	})
+	r.NoError(err)
+	for i, v := range results {
+		r.Equal(i*2, v)
+	}
+}
+
+func TestDoLimit(t *testing.T) {
+	r := require.New(t)
+	var running, peak atomic.Int64
+	err := Do(context.Background(), 50, func(_ context.Context, i int) error {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for {
+			old := peak.Load()
+			if cur <= old || peak.CompareAndSwap(old, cur) {
+				break
+			}
+		}
+		return nil
+	}, Limit(3))
+	r.NoError(err)
+	r.LessOrEqual(peak.Load(), int64(3))
+}
+
+func TestDoError(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	for _, opts := range [][]Option{nil, {Limit(2)}} {
+		err := Do(context.Background(), 1000, func(ctx context.Context, i int) error {
+			if i == 0 {
+				return boom
+			}
+			<-ctx.Done()
+			return nil
+		}, opts...)
+		r.ErrorIs(err, boom)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := Do(ctx, 10, func(context.Context, int) error { return nil }, Limit(2))
+	r.ErrorIs(err, context.Canceled)
+}
+
+func TestDeterministic(t *testing.T) {
+	r := require.New(t)
+	order := func(opts ...Option) []int {
+		var (
+			mut sync.Mutex
+			ret []int
+		)
+		err := Do(context.Background(), 20, func(_ context.Context, i int) error {
+			mut.Lock()
+			defer mut.Unlock()
+			ret = append(ret, i)
+			return nil
+		}, opts...)
+		r.NoError(err)
+		return ret
+	}
+	first := order(Deterministic(1))
+	r.Len(first, 20)
+	r.ElementsMatch(first, order())
+	r.Equal(first, order(Deterministic(1), Limit(4)))
+	r.NotEqual(first, order(Deterministic(2)))
+
+	restore := SetDeterministic(1)
+	r.Equal(first, order())
+	restore()
+	r.Nil(defaultSeed.Load())
+
+	boom := errors.New("boom")
+	calls := 0
+	err := Do(context.Background(), 20, func(context.Context, int) error {
+		calls++
+		return boom
+	}, Deterministic(3))
+	r.ErrorIs(err, boom)
+	r.Equal(1, calls)
+}
+
+func TestAdaptive(t *testing.T) {
+	r := require.New(t)
+	// run reports how many calls there were, and whether any two of them
+	// overlapped
+	run := func(n int, cost time.Duration) (calls int, parallel bool) {
+		var running, peak atomic.Int64
+		var total atomic.Int64
+		err := Do(context.Background(), n, func(context.Context, int) error {
+			cur := running.Add(1)
+			defer running.Add(-1)
+			for old := peak.Load(); cur > old && !peak.CompareAndSwap(old, cur); old = peak.Load() {
+			}
+			total.Add(1)
+			time.Sleep(cost)
+			return nil
+		}, Adaptive(time.Millisecond))
+		r.NoError(err)
+		return int(total.Load()), peak.Load() > 1
+	}
+	calls, parallel := run(50, 0)
+	r.Equal(50, calls)
+	r.False(parallel)
+
+	calls, parallel = run(50, 2*time.Millisecond)
+	r.Equal(50, calls)
+	r.True(parallel)
+
+	calls, _ = run(2, 0)
+	r.Equal(2, calls)
+
+	boom := errors.New("boom")
+	err := Do(context.Background(), 10, func(_ context.Context, i int) error {
+		if i == 1 || i == 7 {
+			return boom
+		}
+		return nil
+	}, Adaptive(0))
+	r.ErrorIs(err, boom)
+}
+
+func TestLogger(t *testing.T) {
+	r := require.New(t)
+	var buf syncBuffer
+	debug := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bad := errors.New("bad")
+	err := Do(context.Background(), 3, func(_ context.Context, i int) error {
+		if i == 1 {
+			return bad
+		}
+		return nil
+	}, Logger(debug), Limit(1))
+	r.ErrorIs(err, bad)
+	out := buf.String()
+	r.Contains(out, `msg="par: call started" index=0`)
+	r.Contains(out, `msg="par: call finished" index=0 duration=`)
+	r.Contains(out, `msg="par: call failed" index=1 duration=`)
+	r.Contains(out, "error=bad")
+
+	// nothing is logged when debug is disabled
+	buf = syncBuffer{}
+	info := slog.New(slog.NewTextHandler(&buf, nil))
+	r.NoError(Do(context.Background(), 3, func(context.Context, int) error { return nil }, Logger(info)))
+	r.Empty(buf.String())
+}
+
+type syncBuffer struct {
+	mut sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	return b.buf.String()
+}
+
+func TestElementTimeout(t *testing.T) {
+	r := require.New(t)
+	var finished atomic.Int32
+	err := Do(context.Background(), 4, func(ctx context.Context, i int) error {
+		if i == 2 {
+			// a stuck call that respects its context
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		finished.Add(1)
+		return nil
+	}, ElementTimeout(10*time.Millisecond), Limit(1))
+	r.ErrorIs(err, context.DeadlineExceeded)
+	r.Contains(err.Error(), "par: call 2 timed out after 10ms")
+	r.Equal(int32(2), finished.Load())
+
+	// fast calls aren't affected, and each call gets its own deadline
+	r.NoError(Do(context.Background(), 20, func(ctx context.Context, i int) error {
+		time.Sleep(2 * time.Millisecond)
+		return ctx.Err()
+	}, ElementTimeout(50*time.Millisecond), Limit(1)))
+
+	// a cancelled parent isn't reported as a timeout
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = Do(ctx, 1, func(ctx context.Context, _ int) error { return ctx.Err() }, ElementTimeout(time.Hour))
+	r.ErrorIs(err, context.Canceled)
+	r.NotContains(err.Error(), "timed out")
+}
+
+func TestDoChunks(t *testing.T) {
+	r := require.New(t)
+	for _, n := range []int{0, 1, 7, 1000} {
+		for _, opts := range [][]Option{nil, {Limit(3)}, {Deterministic(1)}} {
+			var (
+				mut    sync.Mutex
+				chunks int
+			)
+			seen := make([]int, n)
+			err := DoChunks(context.Background(), n, func(_ context.Context, lo, hi int) error {
+				mut.Lock()
+				defer mut.Unlock()
+				chunks++
+				for i := lo; i < hi; i++ {
+					seen[i]++
+				}
+				return nil
+			}, opts...)
+			r.NoError(err)
+			for _, c := range seen {
+				r.Equal(1, c)
+			}
+			r.LessOrEqual(chunks, max(n, 1))
+		}
+	}
+
+	var chunks atomic.Int32
+	r.NoError(DoChunks(context.Background(), 1000, func(context.Context, int, int) error {
+		chunks.Add(1)
+		return nil
+	}, Limit(3)))
+	r.Equal(int32(3), chunks.Load())
+}
+
+func TestWorkStealing(t *testing.T) {
+	r := require.New(t)
+	for _, grain := range []int{0, 1, 7, 1000, math.MaxInt} {
+		const n = 500
+		var (
+			mut   sync.Mutex
+			sizes []int
+		)
+		seen := make([]int, n)
+		err := DoChunks(context.Background(), n, func(_ context.Context, lo, hi int) error {
+			mut.Lock()
+			defer mut.Unlock()
+			sizes = append(sizes, hi-lo)
+			for i := lo; i < hi; i++ {
+				seen[i]++
+			}
+			return nil
+		}, Limit(2), WorkStealing(grain))
+		r.NoError(err)
+		for _, c := range seen {
+			r.Equal(1, c)
+		}
+		if grain > 0 {
+			for _, s := range sizes {
+				r.LessOrEqual(s, grain)
+			}
+		} else {
+			// about stealingChunksPerWorker chunks for each of the 2 workers
+			grain := n / (2 * stealingChunksPerWorker)
+			r.Len(sizes, (n+grain-1)/grain)
+		}
+	}
+
+	// appending Limit doesn't write into the caller's options
+	opts := make([]Option, 1, 2)
+	opts[0] = WorkStealing(0)
+	spare := opts[:2]
+	r.Nil(spare[1])
+	r.NoError(DoChunks(context.Background(), 10, func(context.Context, int, int) error { return nil }, opts...))
+	r.Nil(spare[1])
+
+	// one slow chunk doesn't hold up the rest: the other worker handles
+	// everything else while it runs
+	var (
+		mut     sync.Mutex
+		byFirst []int
+	)
+	start := time.Now()
+	err := DoChunks(context.Background(), 100, func(_ context.Context, lo, hi int) error {
+		if lo == 0 {
+			time.Sleep(50 * time.Millisecond)
+		} else {
+			time.Sleep(time.Millisecond)
+		}
+		mut.Lock()
+		defer mut.Unlock()
+		byFirst = append(byFirst, lo)
+		return nil
+	}, Limit(2), WorkStealing(10))
+	r.NoError(err)
+	r.Len(byFirst, 10)
+	r.Equal(0, byFirst[len(byFirst)-1])
+	r.Less(time.Since(start), 200*time.Millisecond)
+}
@@ -0,0 +1,8 @@
+// Package persistent provides immutable collections that share structure
+// between versions.
+//
+// Every "modifying" operation on a collection in this package returns a new
+// version and leaves the original untouched. Because versions never change
+// after they're created, you can hand them to many goroutines without copying
+// or locking.
+package persistent
@@ -0,0 +1,47 @@
+package persistent
+
+// Fold calls yield with every element of l, from front to back, until yield
+// returns false. It makes l a functor.Foldable
+func (l List[T]) Fold(yield func(T) bool) {
+	l.All()(yield)
+}
+
+// MapErr returns a new List holding fn applied to every element of l, in the
+// same order, or an empty List and the first error fn returns. It makes l a
+// functor.Traversable
+func (l List[T]) MapErr(fn func(T) (T, error)) (List[T], error) {
+	vals := make([]T, 0, l.size)
+	for v := range l.All() {
+		u, err := fn(v)
+		if err != nil {
+			return List[T]{}, err
+		}
+		vals = append(vals, u)
+	}
+	return NewList(vals...), nil
+}
+
+// Fold calls yield with every element of v, in order, until yield returns
+// false. It makes v a functor.Foldable
+func (v Vector[T]) Fold(yield func(T) bool) {
+	for _, val := range v.All() {
+		if !yield(val) {
+			return
+		}
+	}
+}
+
+// MapErr returns a new Vector holding fn applied to every element of v, in
+// the same order, or an empty Vector and the first error fn returns. It makes
+// v a functor.Traversable
+func (v Vector[T]) MapErr(fn func(T) (T, error)) (Vector[T], error) {
+	vals := make([]T, 0, v.size)
+	for _, val := range v.All() {
+		u, err := fn(val)
+		if err != nil {
+			return Vector[T]{}, err
+		}
+		vals = append(vals, u)
+	}
+	return NewVector(vals...), nil
+}
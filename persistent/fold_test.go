@@ -0,0 +1,45 @@
+package persistent
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]int{1, 2, 3}, slices.Collect(NewList(1, 2, 3).Fold))
+	r.Equal([]int{1, 2, 3}, slices.Collect(NewVector(1, 2, 3).Fold))
+	var first []int
+	for v := range NewVector(4, 5, 6).Fold {
+		first = append(first, v)
+		break
+	}
+	r.Equal([]int{4}, first)
+}
+
+func TestMapErr(t *testing.T) {
+	r := require.New(t)
+	double := func(v int) (int, error) { return v * 2, nil }
+	l, err := NewList(1, 2, 3).MapErr(double)
+	r.NoError(err)
+	r.Equal([]int{2, 4, 6}, l.ToSlice())
+	v, err := NewVector(1, 2, 3).MapErr(double)
+	r.NoError(err)
+	r.Equal([]int{2, 4, 6}, v.ToSlice())
+
+	boom := errors.New("boom")
+	fail := func(v int) (int, error) {
+		if v == 2 {
+			return 0, boom
+		}
+		return v, nil
+	}
+	l, err = NewList(1, 2, 3).MapErr(fail)
+	r.ErrorIs(err, boom)
+	r.Equal(0, l.Len())
+	_, err = NewVector(1, 2, 3).MapErr(fail)
+	r.ErrorIs(err, boom)
+}
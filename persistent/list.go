@@ -0,0 +1,76 @@
+package persistent
+
+import "iter"
+
+type cell[T any] struct {
+	val  T
+	next *cell[T]
+}
+
+// List is an immutable singly-linked (cons) list. The zero value is an empty
+// list ready to use.
+//
+// Cons and Tail are O(1) and the returned list shares all of its cells with
+// the original, so it's cheap to keep old versions of a list around.
+type List[T any] struct {
+	head *cell[T]
+	size int
+}
+
+// NewList creates a new List holding vals, in the same order
+func NewList[T any](vals ...T) List[T] {
+	var lst List[T]
+	for i := len(vals) - 1; i >= 0; i-- {
+		lst = lst.Cons(vals[i])
+	}
+	return lst
+}
+
+// Len returns the number of elements in l
+func (l List[T]) Len() int {
+	return l.size
+}
+
+// Cons returns a new list with v at the front of l
+func (l List[T]) Cons(v T) List[T] {
+	return List[T]{head: &cell[T]{val: v, next: l.head}, size: l.size + 1}
+}
+
+// Head returns the first element in l and true, or the zero value of T and
+// false if l is empty
+func (l List[T]) Head() (T, bool) {
+	if l.head == nil {
+		var zero T
+		return zero, false
+	}
+	return l.head.val, true
+}
+
+// Tail returns everything but the first element of l. The tail of an empty
+// list is an empty list
+func (l List[T]) Tail() List[T] {
+	if l.head == nil {
+		return l
+	}
+	return List[T]{head: l.head.next, size: l.size - 1}
+}
+
+// All returns an iterator over the elements of l, from front to back
+func (l List[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for c := l.head; c != nil; c = c.next {
+			if !yield(c.val) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice copies the elements of l into a new slice
+func (l List[T]) ToSlice() []T {
+	ret := make([]T, 0, l.size)
+	for v := range l.All() {
+		ret = append(ret, v)
+	}
+	return ret
+}
@@ -0,0 +1,95 @@
+package persistent
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestList(t *testing.T) {
+	r := require.New(t)
+	lst := NewList(2, 3)
+	lst2 := lst.Cons(1)
+	r.Equal([]int{2, 3}, lst.ToSlice())
+	r.Equal([]int{1, 2, 3}, lst2.ToSlice())
+	r.Equal(3, lst2.Len())
+
+	h, ok := lst2.Head()
+	r.True(ok)
+	r.Equal(1, h)
+	r.Equal(lst.ToSlice(), lst2.Tail().ToSlice())
+
+	var empty List[int]
+	_, ok = empty.Head()
+	r.False(ok)
+	r.Equal(0, empty.Tail().Len())
+}
+
+func TestVectorPushGetSet(t *testing.T) {
+	r := require.New(t)
+	const n = width*width + 7
+	versions := make([]Vector[int], 0, n+1)
+	var v Vector[int]
+	versions = append(versions, v)
+	for i := 0; i < n; i++ {
+		v = v.Push(i)
+		versions = append(versions, v)
+	}
+	r.Equal(n, v.Len())
+	for i := 0; i < n; i++ {
+		r.Equal(i, v.Get(i))
+	}
+	// every older version must remain unchanged
+	for i, old := range versions {
+		r.Equal(i, old.Len())
+	}
+
+	v2 := v.Set(width+1, -1)
+	r.Equal(-1, v2.Get(width+1))
+	r.Equal(width+1, v.Get(width+1))
+	r.Panics(func() { v.Get(n) })
+}
+
+func TestVectorSlice(t *testing.T) {
+	r := require.New(t)
+	v := NewVector(0, 1, 2, 3, 4, 5)
+	s := v.Slice(1, 4)
+	r.Equal([]int{1, 2, 3}, s.ToSlice())
+
+	// pushing onto a slice must not clobber the original
+	s2 := s.Push(100)
+	r.Equal([]int{1, 2, 3, 100}, s2.ToSlice())
+	r.Equal([]int{0, 1, 2, 3, 4, 5}, v.ToSlice())
+	r.Equal([]int{1, 2, 3, 100, 5}, s2.Push(5).ToSlice())
+	r.Equal([]int{1, 2, 3, 100, 5, 6}, s2.Push(5).Push(6).ToSlice())
+	r.Panics(func() { v.Slice(3, 7) })
+}
+
+func TestZipper(t *testing.T) {
+	r := require.New(t)
+	z := NewZipper([]int{1, 2, 4})
+	_, ok := z.Left()
+	r.False(ok)
+
+	z, _ = z.Right()
+	z, _ = z.Right()
+	z = z.Insert(3)
+	r.Equal([]int{1, 2, 3, 4}, z.ToSlice())
+	r.Equal(2, z.Pos())
+	f, _ := z.Focus()
+	r.Equal(3, f)
+
+	z, _ = z.Replace(30)
+	z, _ = z.Left()
+	z, _ = z.Delete()
+	r.Equal([]int{1, 30, 4}, z.ToSlice())
+
+	for ok = true; ok; z, ok = z.Right() {
+	}
+	r.Equal(z.Len(), z.Pos())
+	_, ok = z.Focus()
+	r.False(ok)
+	_, ok = z.Delete()
+	r.False(ok)
+	r.Equal([]int{1, 30, 4, 5}, z.Insert(5).ToSlice())
+}
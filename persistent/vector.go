@@ -0,0 +1,177 @@
+package persistent
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+const (
+	bits  = 5
+	width = 1 << bits
+	mask  = width - 1
+)
+
+// vnode is a node in a vector's trie. Leaves hold up to width values in vals
+// and internal nodes hold up to width children
+type vnode[T any] struct {
+	children []*vnode[T]
+	vals     []T
+}
+
+// Vector is an immutable, indexable sequence backed by a 32-way trie. The
+// zero value is an empty vector ready to use.
+//
+// Get, Set and Push are O(log32 n), which is effectively constant for any
+// vector that fits in memory. Each returns a new version that shares all but
+// one path through the trie with the original.
+type Vector[T any] struct {
+	root  *vnode[T]
+	shift uint
+	// cnt is the number of elements stored in the trie, which may be more
+	// than size if v was created with Slice
+	cnt  int
+	off  int
+	size int
+}
+
+// NewVector creates a new Vector holding vals, in the same order
+func NewVector[T any](vals ...T) Vector[T] {
+	var v Vector[T]
+	for _, val := range vals {
+		v = v.Push(val)
+	}
+	return v
+}
+
+// Len returns the number of elements in v
+func (v Vector[T]) Len() int {
+	return v.size
+}
+
+// Get returns the element at index i. Like indexing a slice, Get panics if
+// i is out of range
+func (v Vector[T]) Get(i int) T {
+	v.checkIndex(i)
+	return v.leafFor(v.off + i).vals[(v.off+i)&mask]
+}
+
+// Set returns a new vector with the element at index i replaced by val.
+// Set panics if i is out of range
+func (v Vector[T]) Set(i int, val T) Vector[T] {
+	v.checkIndex(i)
+	v.root = set(v.root, v.shift, v.off+i, val)
+	return v
+}
+
+// Push returns a new vector with val appended to the end of v
+func (v Vector[T]) Push(val T) Vector[T] {
+	idx := v.off + v.size
+	switch {
+	case idx < v.cnt:
+		// v is a slice of a larger trie, so overwrite the element just past
+		// the end rather than growing the trie
+		v.root = set(v.root, v.shift, idx, val)
+	case v.root == nil:
+		v.root = &vnode[T]{vals: []T{val}}
+		v.cnt = 1
+	case v.cnt == 1<<(v.shift+bits):
+		// the trie is full, so grow it by one level
+		v.root = &vnode[T]{children: []*vnode[T]{v.root, newPath(v.shift, val)}}
+		v.shift += bits
+		v.cnt++
+	default:
+		v.root = push(v.root, v.shift, v.cnt, val)
+		v.cnt++
+	}
+	v.size++
+	return v
+}
+
+// Slice returns a new vector holding the elements of v in [lo, hi), just like
+// slc[lo:hi] does for a slice. Slice is O(1) and the returned vector shares
+// its storage with v, so like a slice, it keeps all of v's elements reachable
+// while it's in use. Slice panics if lo or hi are out of range
+func (v Vector[T]) Slice(lo, hi int) Vector[T] {
+	if lo < 0 || hi < lo || hi > v.size {
+		panic(fmt.Sprintf("persistent: slice bounds [%d:%d] out of range with length %d", lo, hi, v.size))
+	}
+	v.off += lo
+	v.size = hi - lo
+	return v
+}
+
+// All returns an iterator over the indices and elements of v, in order
+func (v Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < v.size; {
+			leaf := v.leafFor(v.off + i)
+			for j := (v.off + i) & mask; j < len(leaf.vals) && i < v.size; j++ {
+				if !yield(i, leaf.vals[j]) {
+					return
+				}
+				i++
+			}
+		}
+	}
+}
+
+// ToSlice copies the elements of v into a new slice
+func (v Vector[T]) ToSlice() []T {
+	ret := make([]T, 0, v.size)
+	for _, val := range v.All() {
+		ret = append(ret, val)
+	}
+	return ret
+}
+
+func (v Vector[T]) checkIndex(i int) {
+	if i < 0 || i >= v.size {
+		panic(fmt.Sprintf("persistent: index %d out of range with length %d", i, v.size))
+	}
+}
+
+func (v Vector[T]) leafFor(idx int) *vnode[T] {
+	n := v.root
+	for level := v.shift; level > 0; level -= bits {
+		n = n.children[(idx>>level)&mask]
+	}
+	return n
+}
+
+func newPath[T any](level uint, val T) *vnode[T] {
+	if level == 0 {
+		return &vnode[T]{vals: []T{val}}
+	}
+	return &vnode[T]{children: []*vnode[T]{newPath(level-bits, val)}}
+}
+
+// set returns a copy of n with the element at idx replaced. Only the nodes on
+// the path to idx are copied
+func set[T any](n *vnode[T], level uint, idx int, val T) *vnode[T] {
+	if level == 0 {
+		vals := slices.Clone(n.vals)
+		vals[idx&mask] = val
+		return &vnode[T]{vals: vals}
+	}
+	children := slices.Clone(n.children)
+	sub := (idx >> level) & mask
+	children[sub] = set(children[sub], level-bits, idx, val)
+	return &vnode[T]{children: children}
+}
+
+// push returns a copy of n with val stored at idx, which must be exactly one
+// past the last element stored under n
+func push[T any](n *vnode[T], level uint, idx int, val T) *vnode[T] {
+	if level == 0 {
+		return &vnode[T]{vals: append(slices.Clone(n.vals), val)}
+	}
+	children := slices.Clone(n.children)
+	sub := (idx >> level) & mask
+	if sub < len(children) {
+		children[sub] = push(children[sub], level-bits, idx, val)
+	} else {
+		children = append(children, newPath(level-bits, val))
+	}
+	return &vnode[T]{children: children}
+}
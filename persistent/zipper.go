@@ -0,0 +1,106 @@
+package persistent
+
+// Zipper is an immutable list with a cursor. Moving the cursor one step and
+// inserting, deleting or replacing at the cursor are all O(1), where making
+// the same edits to a slice is O(n) each.
+//
+// The cursor sits before an element, which is called the focus. A cursor
+// positioned after the last element has no focus, and is where Insert appends
+// to the end of the list. The zero value is an empty Zipper ready to use.
+//
+// Example usage:
+//
+//	z := NewZipper([]int{1, 2, 4})
+//	z, _ = z.Right()
+//	z, _ = z.Right()
+//	z = z.Insert(3)
+//	// z.ToSlice() will be []int{1, 2, 3, 4}
+type Zipper[T any] struct {
+	// left holds the elements before the cursor, nearest first
+	left List[T]
+	// right holds the focus followed by every element after it
+	right List[T]
+}
+
+// NewZipper creates a Zipper over the elements of slc, with the cursor at the
+// first element
+func NewZipper[T any](slc []T) Zipper[T] {
+	return Zipper[T]{right: NewList(slc...)}
+}
+
+// Len returns the number of elements in z
+func (z Zipper[T]) Len() int {
+	return z.left.Len() + z.right.Len()
+}
+
+// Pos returns the index of the cursor. It's equal to Len when the cursor
+// is after the last element
+func (z Zipper[T]) Pos() int {
+	return z.left.Len()
+}
+
+// Focus returns the element at the cursor and true, or the zero value of T
+// and false if the cursor is after the last element
+func (z Zipper[T]) Focus() (T, bool) {
+	return z.right.Head()
+}
+
+// Left returns a Zipper with the cursor moved one element toward the front.
+// Returns z and false if the cursor is already at the front
+func (z Zipper[T]) Left() (Zipper[T], bool) {
+	v, ok := z.left.Head()
+	if !ok {
+		return z, false
+	}
+	return Zipper[T]{left: z.left.Tail(), right: z.right.Cons(v)}, true
+}
+
+// Right returns a Zipper with the cursor moved one element toward the back.
+// Returns z and false if the cursor is already after the last element
+func (z Zipper[T]) Right() (Zipper[T], bool) {
+	v, ok := z.right.Head()
+	if !ok {
+		return z, false
+	}
+	return Zipper[T]{left: z.left.Cons(v), right: z.right.Tail()}, true
+}
+
+// Insert returns a Zipper with v inserted at the cursor. v becomes the new
+// focus, and the old focus follows it
+func (z Zipper[T]) Insert(v T) Zipper[T] {
+	return Zipper[T]{left: z.left, right: z.right.Cons(v)}
+}
+
+// Delete returns a Zipper with the focus removed. The element after it, if
+// any, becomes the new focus. Returns z and false if there is no focus
+func (z Zipper[T]) Delete() (Zipper[T], bool) {
+	if z.right.Len() == 0 {
+		return z, false
+	}
+	return Zipper[T]{left: z.left, right: z.right.Tail()}, true
+}
+
+// Replace returns a Zipper with the focus replaced by v. Returns z and false
+// if there is no focus
+func (z Zipper[T]) Replace(v T) (Zipper[T], bool) {
+	if z.right.Len() == 0 {
+		return z, false
+	}
+	return Zipper[T]{left: z.left, right: z.right.Tail().Cons(v)}, true
+}
+
+// ToSlice copies the elements of z into a new slice, in order
+func (z Zipper[T]) ToSlice() []T {
+	ret := make([]T, z.Len())
+	i := z.left.Len() - 1
+	for v := range z.left.All() {
+		ret[i] = v
+		i--
+	}
+	i = z.left.Len()
+	for v := range z.right.All() {
+		ret[i] = v
+		i++
+	}
+	return ret
+}
@@ -0,0 +1,358 @@
+// Package phamt provides a persistent hash map based on a hash array mapped
+// trie (HAMT).
+//
+// A Map never changes after it's created. Set and Delete return new versions
+// that share all but one path through the trie with the original, so any
+// goroutine holding a Map can read it without locks while other goroutines
+// build newer versions. Use a Transient to build a Map from many entries
+// without allocating a new version for every one.
+package phamt
+
+import (
+	"hash/maphash"
+	"iter"
+	"math/bits"
+	"slices"
+)
+
+const (
+	levelBits = 5
+	levelMask = 1<<levelBits - 1
+	hashBits  = 64
+)
+
+var seed = maphash.MakeSeed()
+
+// slot is either a single key/value pair (child == nil) or a pointer to the
+// next level of the trie
+type slot[K comparable, V any] struct {
+	child *node[K, V]
+	hash  uint64
+	key   K
+	val   V
+}
+
+// node is a level of the trie. If coll is true, every slot holds a key with
+// the same hash and bitmap is unused. Otherwise, bit i of bitmap is set if
+// there's a slot for hash fragment i, and slots is ordered by fragment.
+type node[K comparable, V any] struct {
+	bitmap uint32
+	coll   bool
+	slots  []slot[K, V]
+	// edit is the Transient that owns this node, and may mutate it in place.
+	// It's nil for nodes that are shared
+	edit *editToken
+}
+
+type editToken struct{ _ byte }
+
+// Map is an immutable hash map. The zero value is an empty map ready to use.
+type Map[K comparable, V any] struct {
+	root *node[K, V]
+	size int
+}
+
+// New returns a new, empty Map
+func New[K comparable, V any]() Map[K, V] {
+	return Map[K, V]{}
+}
+
+// Len returns the number of entries in m
+func (m Map[K, V]) Len() int {
+	return m.size
+}
+
+// Get returns the value for key and true, or the zero value of V and false if
+// key isn't in m
+func (m Map[K, V]) Get(key K) (V, bool) {
+	return get(m.root, maphash.Comparable(seed, key), key)
+}
+
+// Set returns a new Map with key set to val. m is unchanged
+func (m Map[K, V]) Set(key K, val V) Map[K, V] {
+	root, added := set(m.root, nil, maphash.Comparable(seed, key), 0, key, val)
+	if added {
+		m.size++
+	}
+	m.root = root
+	return m
+}
+
+// Delete returns a new Map without key. m is unchanged. If key isn't in m,
+// the returned Map is equivalent to m
+func (m Map[K, V]) Delete(key K) Map[K, V] {
+	root, removed := del(m.root, nil, maphash.Comparable(seed, key), 0, key)
+	if removed {
+		m.size--
+		m.root = root
+	}
+	return m
+}
+
+// All returns an iterator over the entries in m. The iteration order is
+// unspecified but stable for a given Map
+func (m Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		walk(m.root, yield)
+	}
+}
+
+// Transient returns a builder that starts with the contents of m. Changes made
+// through the builder don't affect m
+func (m Map[K, V]) Transient() *Transient[K, V] {
+	return &Transient[K, V]{m: m, edit: &editToken{}}
+}
+
+// Transient is a mutable builder for a Map. It modifies the nodes it owns in
+// place, so building a large Map through a Transient allocates far less than
+// calling Map.Set repeatedly. A Transient is not safe for concurrent use.
+//
+// Example usage:
+//
+//	t := New[string, int]().Transient()
+//	for i, s := range strs {
+//		t.Set(s, i)
+//	}
+//	m := t.Persistent()
+type Transient[K comparable, V any] struct {
+	m    Map[K, V]
+	edit *editToken
+}
+
+// Len returns the number of entries in t
+func (t *Transient[K, V]) Len() int {
+	return t.m.size
+}
+
+// Get returns the value for key and true, or the zero value of V and false if
+// key isn't in t
+func (t *Transient[K, V]) Get(key K) (V, bool) {
+	return t.m.Get(key)
+}
+
+// Set sets key to val
+func (t *Transient[K, V]) Set(key K, val V) {
+	root, added := set(t.m.root, t.edit, maphash.Comparable(seed, key), 0, key, val)
+	if added {
+		t.m.size++
+	}
+	t.m.root = root
+}
+
+// Delete removes key, if it's present
+func (t *Transient[K, V]) Delete(key K) {
+	root, removed := del(t.m.root, t.edit, maphash.Comparable(seed, key), 0, key)
+	if removed {
+		t.m.size--
+		t.m.root = root
+	}
+}
+
+// Persistent returns an immutable Map with the contents of t. t can still be
+// used afterward, but further changes won't affect the returned Map
+func (t *Transient[K, V]) Persistent() Map[K, V] {
+	// the returned map now shares every node t owned, so t has to copy them
+	// again before mutating
+	t.edit = &editToken{}
+	return t.m
+}
+
+// editable returns n if edit owns it, otherwise a copy of n owned by edit
+func editable[K comparable, V any](n *node[K, V], edit *editToken) *node[K, V] {
+	if edit != nil && n.edit == edit {
+		return n
+	}
+	return &node[K, V]{
+		bitmap: n.bitmap,
+		coll:   n.coll,
+		slots:  slices.Clone(n.slots),
+		edit:   edit,
+	}
+}
+
+func fragment(hash uint64, shift uint) uint32 {
+	return 1 << ((hash >> shift) & levelMask)
+}
+
+func index(bitmap, bit uint32) int {
+	return bits.OnesCount32(bitmap & (bit - 1))
+}
+
+func get[K comparable, V any](n *node[K, V], hash uint64, key K) (V, bool) {
+	for shift := uint(0); n != nil; shift += levelBits {
+		if n.coll {
+			for _, s := range n.slots {
+				if s.key == key {
+					return s.val, true
+				}
+			}
+			break
+		}
+		bit := fragment(hash, shift)
+		if n.bitmap&bit == 0 {
+			break
+		}
+		s := n.slots[index(n.bitmap, bit)]
+		if s.child == nil {
+			if s.hash == hash && s.key == key {
+				return s.val, true
+			}
+			break
+		}
+		n = s.child
+	}
+	var zero V
+	return zero, false
+}
+
+// set returns n with key set to val, copying nodes that edit doesn't own.
+// The second return value is true if key wasn't already present
+func set[K comparable, V any](
+	n *node[K, V],
+	edit *editToken,
+	hash uint64,
+	shift uint,
+	key K,
+	val V,
+) (*node[K, V], bool) {
+	leaf := slot[K, V]{hash: hash, key: key, val: val}
+	if n == nil {
+		return &node[K, V]{bitmap: fragment(hash, shift), slots: []slot[K, V]{leaf}, edit: edit}, true
+	}
+	if n.coll {
+		n = editable(n, edit)
+		for i := range n.slots {
+			if n.slots[i].key == key {
+				n.slots[i].val = val
+				return n, false
+			}
+		}
+		n.slots = append(n.slots, leaf)
+		return n, true
+	}
+
+	bit := fragment(hash, shift)
+	idx := index(n.bitmap, bit)
+	if n.bitmap&bit == 0 {
+		n = editable(n, edit)
+		n.bitmap |= bit
+		n.slots = slices.Insert(n.slots, idx, leaf)
+		return n, true
+	}
+
+	s := n.slots[idx]
+	switch {
+	case s.child != nil:
+		child, added := set(s.child, edit, hash, shift+levelBits, key, val)
+		n = editable(n, edit)
+		n.slots[idx].child = child
+		return n, added
+	case s.hash == hash && s.key == key:
+		n = editable(n, edit)
+		n.slots[idx].val = val
+		return n, false
+	default:
+		n = editable(n, edit)
+		n.slots[idx] = slot[K, V]{child: merge(edit, shift+levelBits, s, leaf)}
+		return n, true
+	}
+}
+
+// merge creates a subtrie holding the two distinct keys in a and b
+func merge[K comparable, V any](edit *editToken, shift uint, a, b slot[K, V]) *node[K, V] {
+	if shift >= hashBits || a.hash == b.hash {
+		return &node[K, V]{coll: true, slots: []slot[K, V]{a, b}, edit: edit}
+	}
+	abit, bbit := fragment(a.hash, shift), fragment(b.hash, shift)
+	if abit == bbit {
+		child := merge(edit, shift+levelBits, a, b)
+		return &node[K, V]{bitmap: abit, slots: []slot[K, V]{{child: child}}, edit: edit}
+	}
+	slots := []slot[K, V]{a, b}
+	if bbit < abit {
+		slots[0], slots[1] = b, a
+	}
+	return &node[K, V]{bitmap: abit | bbit, slots: slots, edit: edit}
+}
+
+// del returns n without key, copying nodes that edit doesn't own. It returns
+// nil if the resulting node would be empty. The second return value is true
+// if key was present
+func del[K comparable, V any](
+	n *node[K, V],
+	edit *editToken,
+	hash uint64,
+	shift uint,
+	key K,
+) (*node[K, V], bool) {
+	if n == nil {
+		return nil, false
+	}
+	if n.coll {
+		i := slices.IndexFunc(n.slots, func(s slot[K, V]) bool { return s.key == key })
+		if i < 0 {
+			return n, false
+		}
+		if len(n.slots) == 1 {
+			return nil, true
+		}
+		n = editable(n, edit)
+		n.slots = slices.Delete(n.slots, i, i+1)
+		return n, true
+	}
+
+	bit := fragment(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	idx := index(n.bitmap, bit)
+	s := n.slots[idx]
+	if s.child == nil {
+		if s.hash != hash || s.key != key {
+			return n, false
+		}
+		return removeSlot(n, edit, bit, idx), true
+	}
+
+	child, removed := del(s.child, edit, hash, shift+levelBits, key)
+	if !removed {
+		return n, false
+	}
+	if child == nil {
+		return removeSlot(n, edit, bit, idx), true
+	}
+	n = editable(n, edit)
+	if len(child.slots) == 1 && child.slots[0].child == nil {
+		// the child holds just one key, so pull it up to keep the trie shallow
+		n.slots[idx] = child.slots[0]
+	} else {
+		n.slots[idx].child = child
+	}
+	return n, true
+}
+
+func removeSlot[K comparable, V any](n *node[K, V], edit *editToken, bit uint32, idx int) *node[K, V] {
+	if len(n.slots) == 1 {
+		return nil
+	}
+	n = editable(n, edit)
+	n.bitmap &^= bit
+	n.slots = slices.Delete(n.slots, idx, idx+1)
+	return n
+}
+
+func walk[K comparable, V any](n *node[K, V], yield func(K, V) bool) bool {
+	if n == nil {
+		return true
+	}
+	for _, s := range n.slots {
+		if s.child != nil {
+			if !walk(s.child, yield) {
+				return false
+			}
+		} else if !yield(s.key, s.val) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package phamt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGetDelete(t *testing.T) {
+	r := require.New(t)
+	const n = 5000
+	var m Map[int, int]
+	for i := 0; i < n; i++ {
+		m = m.Set(i, i*2)
+	}
+	r.Equal(n, m.Len())
+	for i := 0; i < n; i++ {
+		v, ok := m.Get(i)
+		r.True(ok)
+		r.Equal(i*2, v)
+	}
+
+	m2 := m.Set(1, -1).Delete(2).Delete(n + 1)
+	r.Equal(n-1, m2.Len())
+	v, _ := m2.Get(1)
+	r.Equal(-1, v)
+	_, ok := m2.Get(2)
+	r.False(ok)
+	// the original must be untouched
+	v, _ = m.Get(1)
+	r.Equal(2, v)
+	_, ok = m.Get(2)
+	r.True(ok)
+
+	for i := 0; i < n; i++ {
+		m = m.Delete(i)
+	}
+	r.Equal(0, m.Len())
+	r.Nil(m.root)
+}
+
+func TestCollisions(t *testing.T) {
+	r := require.New(t)
+	var root *node[string, int]
+	root, _ = set(root, nil, 42, 0, "a", 1)
+	root, _ = set(root, nil, 42, 0, "b", 2)
+	root, added := set(root, nil, 42, 0, "b", 3)
+	r.False(added)
+
+	v, ok := get(root, 42, "b")
+	r.True(ok)
+	r.Equal(3, v)
+
+	root, removed := del(root, nil, 42, 0, "a")
+	r.True(removed)
+	_, ok = get(root, 42, "a")
+	r.False(ok)
+	v, ok = get(root, 42, "b")
+	r.True(ok)
+	r.Equal(3, v)
+}
+
+func TestTransient(t *testing.T) {
+	r := require.New(t)
+	base := New[string, int]().Set("keep", 0)
+
+	tr := base.Transient()
+	for i, s := range []string{"a", "b", "c", "d"} {
+		tr.Set(s, i)
+	}
+	tr.Delete("keep")
+	m := tr.Persistent()
+	r.Equal(4, m.Len())
+	r.Equal(1, base.Len())
+
+	// further changes to the transient must not leak into m
+	tr.Set("e", 5)
+	tr.Set("a", 100)
+	r.Equal(4, m.Len())
+	v, _ := m.Get("a")
+	r.Equal(0, v)
+
+	got := map[string]int{}
+	for k, v := range m.All() {
+		got[k] = v
+	}
+	r.Equal(map[string]int{"a": 0, "b": 1, "c": 2, "d": 3}, got)
+}
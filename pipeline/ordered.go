@@ -0,0 +1,133 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Order decides the order a stage passes its results on in
+type Order int
+
+const (
+	// CompletionOrder passes every result on as soon as it's ready, so a
+	// slow value doesn't hold up the ones behind it, but results can come
+	// out in a different order than their values went in. This is the
+	// default
+	CompletionOrder Order = iota
+	// InputOrder passes results on in the same order their values came into
+	// the stage. Workers still process values concurrently, and results that
+	// are ready early wait in a small reorder buffer until the ones before
+	// them are done. A slow value holds up the stage once the buffer fills,
+	// which takes two values per worker
+	InputOrder
+)
+
+// Ordering sets the order a stage passes its results on in. It has no effect
+// on a stage with one worker, which always keeps values in order
+func Ordering(o Order) StageOption {
+	return func(c *stageConfig) {
+		c.order = o
+	}
+}
+
+// sequenced is a value tagged with its position in the order values came
+// into a stage. dropped is true if the stage's error policy dropped it
+type sequenced[T any] struct {
+	seq     int64
+	val     T
+	dropped bool
+}
+
+// startOrdered runs a stage that sends results to out in input order. It
+// starts a goroutine that numbers the values from in, the workers, and a
+// goroutine that reorders their results, and closes out once it's done
+func startOrdered[T, U any](
+	ctx context.Context,
+	g *errgroup.Group,
+	in <-chan T,
+	out chan<- U,
+	stage func(context.Context, T) (U, error),
+	workers int,
+	cfg stageConfig,
+) {
+	// window holds a token for every value that's been numbered but not yet
+	// passed on, which bounds how far ahead of the oldest value the workers
+	// can get, and so the size of the reorder buffer
+	window := make(chan struct{}, 2*workers)
+	numbered := make(chan sequenced[T])
+	results := make(chan sequenced[U], workers)
+
+	g.Go(func() error {
+		defer close(numbered)
+		for seq := int64(0); ; seq++ {
+			t, ok, err := recv(ctx, in)
+			if err != nil || !ok {
+				return err
+			}
+			if err := send(ctx, window, struct{}{}); err != nil {
+				return err
+			}
+			if err := send(ctx, numbered, sequenced[T]{seq: seq, val: t}); err != nil {
+				return err
+			}
+		}
+	})
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		g.Go(func() error {
+			defer wg.Done()
+			for {
+				st, ok, err := recv(ctx, numbered)
+				if err != nil || !ok {
+					return err
+				}
+				res := sequenced[U]{seq: st.seq}
+				res.val, err = stage(ctx, st.val)
+				if err != nil {
+					if err := cfg.onError(err); err != nil {
+						return err
+					}
+					res.dropped = true
+				}
+				if err := send(ctx, results, res); err != nil {
+					return err
+				}
+			}
+		})
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	g.Go(func() error {
+		defer close(out)
+		pending := map[int64]sequenced[U]{}
+		var next int64
+		for {
+			res, ok, err := recv(ctx, results)
+			if err != nil || !ok {
+				return err
+			}
+			pending[res.seq] = res
+			for {
+				ready, ok := pending[next]
+				if !ok {
+					break
+				}
+				delete(pending, next)
+				next++
+				if !ready.dropped {
+					if err := send(ctx, out, ready.val); err != nil {
+						return err
+					}
+				}
+				<-window
+			}
+		}
+	})
+}
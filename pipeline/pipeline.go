@@ -0,0 +1,313 @@
+// Package pipeline builds multi-stage concurrent pipelines.
+//
+// A pipeline starts with a source that emits values, passes them through any
+// number of stages, each with its own pool of workers, and ends with a sink
+// that consumes them. Stages are connected by bounded buffers, so a slow
+// stage applies backpressure to the ones before it rather than letting work
+// pile up in memory. ParMap is the right tool when all the input is already
+// in a slice; a pipeline is the right tool when it isn't, or when different
+// steps need different amounts of parallelism.
+//
+// Example usage:
+//
+//	err := pipeline.Then(
+//		pipeline.New(pipeline.FromSlice(urls)),
+//		fetch, 8,
+//	).Then(resize, 2).Sink(ctx, store)
+//
+// Nothing runs until Sink or Collect is called. Values within a stage are
+// processed concurrently, so they can come out of a stage with more than one
+// worker in a different order than they went in, unless the stage is given
+// Ordering(InputOrder).
+package pipeline
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Source produces the values that flow into a pipeline by calling emit once
+// for each of them. emit blocks until the first stage has room for the value,
+// and returns a non-nil error if the pipeline has been cancelled, in which
+// case the Source should stop and return that error. If a Source returns a
+// non-nil error, the pipeline is cancelled and that error is returned by Sink
+type Source[T any] func(ctx context.Context, emit func(T) error) error
+
+// FromSlice returns a Source that emits every element of slc, in order
+func FromSlice[T any](slc []T) Source[T] {
+	return FromSeq(func(yield func(T) bool) {
+		for _, t := range slc {
+			if !yield(t) {
+				return
+			}
+		}
+	})
+}
+
+// FromSeq returns a Source that emits every value in s, in order. s is
+// consumed lazily, so it's only pulled from as fast as the pipeline can
+// process its values
+func FromSeq[T any](s iter.Seq[T]) Source[T] {
+	return func(ctx context.Context, emit func(T) error) error {
+		for t := range s {
+			if err := emit(t); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// StageOption configures a single stage of a pipeline
+type StageOption func(*stageConfig)
+
+type stageConfig struct {
+	buffer  int
+	onError func(error) error
+	logger  *slog.Logger
+	order   Order
+}
+
+func newStageConfig(defaultBuffer int, opts []StageOption) stageConfig {
+	cfg := stageConfig{
+		buffer:  defaultBuffer,
+		onError: func(err error) error { return err },
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// Buffer sets how many values a stage can hold in its output buffer before
+// its workers block waiting for the next stage. By default, the source's
+// buffer holds one value and every other stage's holds one value per worker
+func Buffer(n int) StageOption {
+	return func(c *stageConfig) {
+		c.buffer = max(n, 0)
+	}
+}
+
+// OnError sets the error policy for a stage. fn is called with every error
+// the stage returns. If fn returns nil, the value that caused the error is
+// dropped and the stage moves on. Otherwise, the pipeline is cancelled and
+// Sink returns the error fn returned.
+//
+// By default, the first error from any stage cancels the pipeline.
+func OnError(fn func(error) error) StageOption {
+	return func(c *stageConfig) {
+		c.onError = fn
+	}
+}
+
+// SkipErrors is an error policy that drops every value a stage returns an
+// error for, and keeps the pipeline running
+func SkipErrors() StageOption {
+	return OnError(func(error) error { return nil })
+}
+
+// Logger makes a stage log a debug record to l when it starts and finishes
+// processing each value, with the value's position in the order the stage
+// received them, how long it took and the error the stage returned, if any.
+// Whether l has debug logging enabled is checked once per run, so leaving
+// Logger in place costs next to nothing when it's disabled. Use l.With to
+// tell stages apart in the logs:
+//
+//	pipeline.Then(p, fetch, 8, pipeline.Logger(logger.With("stage", "fetch")))
+func Logger(l *slog.Logger) StageOption {
+	return func(c *stageConfig) {
+		c.logger = l
+	}
+}
+
+// Pipeline is a sequence of stages that produce values of type T. Build one
+// with New and Then, and run it with Sink or Collect. A Pipeline can be run
+// more than once, and each run calls the Source again
+type Pipeline[T any] struct {
+	start func(ctx context.Context, g *errgroup.Group) <-chan T
+}
+
+// New starts a pipeline whose values come from src. The only StageOption
+// that applies to a source is Buffer
+func New[T any](src Source[T], opts ...StageOption) *Pipeline[T] {
+	cfg := newStageConfig(1, opts)
+	return &Pipeline[T]{
+		start: func(ctx context.Context, g *errgroup.Group) <-chan T {
+			out := make(chan T, cfg.buffer)
+			g.Go(func() error {
+				defer close(out)
+				return src(ctx, func(t T) error {
+					return send(ctx, out, t)
+				})
+			})
+			return out
+		},
+	}
+}
+
+// Then adds a stage to the end of p that calls stage on every value, from
+// workers goroutines at once, and passes the results on to the next stage.
+// workers less than 1 is treated as 1.
+//
+// Then is a function rather than a method because Go methods can't introduce
+// new type parameters. Use the Then method when the stage doesn't change the
+// type of the values.
+func Then[T, U any](
+	p *Pipeline[T],
+	stage func(context.Context, T) (U, error),
+	workers int,
+	opts ...StageOption,
+) *Pipeline[U] {
+	workers = max(workers, 1)
+	cfg := newStageConfig(workers, opts)
+	return &Pipeline[U]{
+		start: func(ctx context.Context, g *errgroup.Group) <-chan U {
+			in := p.start(ctx, g)
+			out := make(chan U, cfg.buffer)
+			run := stage
+			if cfg.logger != nil && cfg.logger.Enabled(ctx, slog.LevelDebug) {
+				run = logged(cfg.logger, stage)
+			}
+			if cfg.order == InputOrder && workers > 1 {
+				startOrdered(ctx, g, in, out, run, workers, cfg)
+				return out
+			}
+			var wg sync.WaitGroup
+			wg.Add(workers)
+			for w := 0; w < workers; w++ {
+				g.Go(func() error {
+					defer wg.Done()
+					return runWorker(ctx, in, out, run, cfg)
+				})
+			}
+			// once every worker is done, nothing else can be sent, so the next
+			// stage can drain what's left and finish
+			go func() {
+				wg.Wait()
+				close(out)
+			}()
+			return out
+		},
+	}
+}
+
+func runWorker[T, U any](
+	ctx context.Context,
+	in <-chan T,
+	out chan<- U,
+	stage func(context.Context, T) (U, error),
+	cfg stageConfig,
+) error {
+	for {
+		t, ok, err := recv(ctx, in)
+		if err != nil || !ok {
+			return err
+		}
+		u, err := stage(ctx, t)
+		if err != nil {
+			if err := cfg.onError(err); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := send(ctx, out, u); err != nil {
+			return err
+		}
+	}
+}
+
+// logged wraps stage so every call to it is logged to l. The index is the
+// position of the value in the order the stage's workers received them
+func logged[T, U any](l *slog.Logger, stage func(context.Context, T) (U, error)) func(context.Context, T) (U, error) {
+	var next atomic.Int64
+	return func(ctx context.Context, t T) (U, error) {
+		i := next.Add(1) - 1
+		l.DebugContext(ctx, "pipeline: value started", slog.Int64("index", i))
+		start := time.Now()
+		u, err := stage(ctx, t)
+		elapsed := time.Since(start)
+		if err != nil {
+			l.DebugContext(ctx, "pipeline: value failed", slog.Int64("index", i), slog.Duration("duration", elapsed), slog.Any("error", err))
+			return u, err
+		}
+		l.DebugContext(ctx, "pipeline: value finished", slog.Int64("index", i), slog.Duration("duration", elapsed))
+		return u, nil
+	}
+}
+
+// Then adds a stage to the end of p that doesn't change the type of its
+// values. It's the same as calling the Then function with p
+func (p *Pipeline[T]) Then(
+	stage func(context.Context, T) (T, error),
+	workers int,
+	opts ...StageOption,
+) *Pipeline[T] {
+	return Then(p, stage, workers, opts...)
+}
+
+// Sink runs the pipeline, calling fn on every value that comes out of the
+// last stage, one at a time, and blocks until the pipeline is done.
+//
+// When the source finishes, every stage drains the values it's holding
+// before Sink returns nil. If the source, a stage or fn returns an error (and
+// the stage's error policy doesn't drop it), or ctx is cancelled, the whole
+// pipeline is cancelled and Sink returns the first error once every goroutine
+// it started has stopped
+func (p *Pipeline[T]) Sink(ctx context.Context, fn func(context.Context, T) error) error {
+	g, ctx := errgroup.WithContext(ctx)
+	in := p.start(ctx, g)
+	g.Go(func() error {
+		for {
+			t, ok, err := recv(ctx, in)
+			if err != nil || !ok {
+				return err
+			}
+			if err := fn(ctx, t); err != nil {
+				return err
+			}
+		}
+	})
+	return g.Wait()
+}
+
+// Collect runs the pipeline and returns every value that comes out of the
+// last stage. It has the same error semantics as Sink, and returns nil for
+// the slice if there's an error
+func (p *Pipeline[T]) Collect(ctx context.Context) ([]T, error) {
+	var ret []T
+	err := p.Sink(ctx, func(_ context.Context, t T) error {
+		ret = append(ret, t)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+func send[T any](ctx context.Context, out chan<- T, t T) error {
+	select {
+	case out <- t:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recv receives the next value from in. The bool is false once in is closed
+// and drained, and the error is non-nil if ctx is done first
+func recv[T any](ctx context.Context, in <-chan T) (T, bool, error) {
+	select {
+	case t, ok := <-in:
+		return t, ok, nil
+	case <-ctx.Done():
+		var zero T
+		return zero, false, ctx.Err()
+	}
+}
@@ -0,0 +1,150 @@
+package pipeline
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	r := require.New(t)
+	p := Then(
+		New(FromSlice([]int{1, 2, 3, 4, 5})),
+		func(_ context.Context, i int) (string, error) { return strconv.Itoa(i * 10), nil },
+		3,
+	).Then(func(_ context.Context, s string) (string, error) { return s + "!", nil }, 2, Buffer(0))
+
+	got, err := p.Collect(context.Background())
+	r.NoError(err)
+	sort.Strings(got)
+	r.Equal([]string{"10!", "20!", "30!", "40!", "50!"}, got)
+
+	// a pipeline can be run again
+	got, err = p.Collect(context.Background())
+	r.NoError(err)
+	r.Len(got, 5)
+}
+
+func TestPipelineErrors(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	failOnThree := func(_ context.Context, i int) (int, error) {
+		if i == 3 {
+			return 0, boom
+		}
+		return i, nil
+	}
+	src := FromSlice([]int{1, 2, 3, 4})
+
+	_, err := New(src).Then(failOnThree, 2).Collect(context.Background())
+	r.ErrorIs(err, boom)
+
+	got, err := New(src).Then(failOnThree, 1, SkipErrors()).Collect(context.Background())
+	r.NoError(err)
+	r.Equal([]int{1, 2, 4}, got)
+
+	wrapped := errors.New("wrapped")
+	_, err = New(src).Then(failOnThree, 1, OnError(func(error) error { return wrapped })).Collect(context.Background())
+	r.ErrorIs(err, wrapped)
+
+	err = New(src).Sink(context.Background(), func(context.Context, int) error { return boom })
+	r.ErrorIs(err, boom)
+}
+
+func TestPipelineCancel(t *testing.T) {
+	r := require.New(t)
+	var emitted atomic.Int64
+	infinite := func(ctx context.Context, emit func(int) error) error {
+		for i := 0; ; i++ {
+			if err := emit(i); err != nil {
+				return err
+			}
+			emitted.Add(1)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	slow := func(ctx context.Context, i int) (int, error) {
+		time.Sleep(time.Millisecond)
+		return i, nil
+	}
+	err := New(infinite).Then(slow, 2).Sink(ctx, func(context.Context, int) error { return nil })
+	r.ErrorIs(err, context.DeadlineExceeded)
+	// backpressure should keep the source from running far ahead of the
+	// slow stage
+	r.Less(emitted.Load(), int64(200))
+}
+
+func TestPipelineLogger(t *testing.T) {
+	r := require.New(t)
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	bad := errors.New("bad")
+	out, err := Then(
+		New(FromSlice([]int{1, 2, 3})),
+		func(_ context.Context, i int) (int, error) {
+			if i == 2 {
+				return 0, bad
+			}
+			return i, nil
+		},
+		1,
+		SkipErrors(),
+		Logger(logger.With("stage", "check")),
+	).Collect(context.Background())
+	r.NoError(err)
+	r.Equal([]int{1, 3}, out)
+	logs := buf.String()
+	r.Contains(logs, `msg="pipeline: value started" stage=check index=0`)
+	r.Contains(logs, `msg="pipeline: value failed" stage=check index=1 duration=`)
+	r.Contains(logs, `msg="pipeline: value finished" stage=check index=2 duration=`)
+}
+
+func TestPipelineOrdering(t *testing.T) {
+	r := require.New(t)
+	in := make([]int, 200)
+	for i := range in {
+		in[i] = i
+	}
+	// later values finish first, so completion order would scramble them
+	slow := func(_ context.Context, i int) (int, error) {
+		time.Sleep(time.Duration((7*i)%5) * 100 * time.Microsecond)
+		if i%10 == 3 {
+			return 0, errors.New("skip")
+		}
+		return i, nil
+	}
+	var want []int
+	for _, i := range in {
+		if i%10 != 3 {
+			want = append(want, i)
+		}
+	}
+
+	out, err := Then(New(FromSlice(in)), slow, 8, Ordering(InputOrder), SkipErrors()).Collect(context.Background())
+	r.NoError(err)
+	r.Equal(want, out)
+
+	out, err = Then(New(FromSlice(in)), slow, 8, SkipErrors()).Collect(context.Background())
+	r.NoError(err)
+	sort.Ints(out)
+	r.Equal(want, out)
+
+	// errors still cancel an ordered stage
+	bad := errors.New("bad")
+	_, err = Then(New(FromSlice(in)), func(_ context.Context, i int) (int, error) {
+		if i == 50 {
+			return 0, bad
+		}
+		return i, nil
+	}, 4, Ordering(InputOrder)).Collect(context.Background())
+	r.ErrorIs(err, bad)
+}
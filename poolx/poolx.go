@@ -0,0 +1,65 @@
+// Package poolx provides a typed wrapper around sync.Pool.
+package poolx
+
+import "sync"
+
+// Pool is a typed sync.Pool that resets objects before they're reused. Like
+// sync.Pool, it's safe for concurrent use, and the objects it holds may be
+// discarded at any time, so it's for cutting allocations of scratch objects
+// rather than for holding on to anything that matters.
+//
+// A Pool[T] holds *T, not T. sync.Pool stores its objects in an interface,
+// so pooling a non-pointer type, like a []byte, would allocate on every Put
+// and defeat the point of the pool, and a reset function would only ever
+// see a copy of the object it's meant to reset.
+//
+// Example usage:
+//
+//	bufs := poolx.New(
+//		func() *bytes.Buffer { return new(bytes.Buffer) },
+//		(*bytes.Buffer).Reset,
+//	)
+//	slice.ParMap(ctx, docs, func(ctx context.Context, _ uint, d Doc) (string, error) {
+//		buf := bufs.Get()
+//		defer bufs.Put(buf)
+//		render(buf, d)
+//		return buf.String(), nil
+//	})
+type Pool[T any] struct {
+	pool  sync.Pool
+	reset func(*T)
+}
+
+// New creates a Pool that calls newFn to make an object when the pool is
+// empty, and calls resetFn on every object passed to Put before it's made
+// available again. resetFn may be nil if objects don't need resetting.
+//
+// To pool a slice, pool a pointer to it, and reset it by reslicing:
+//
+//	ints := poolx.New(
+//		func() *[]int {
+//			s := make([]int, 0, 64)
+//			return &s
+//		},
+//		func(s *[]int) { *s = (*s)[:0] },
+//	)
+func New[T any](newFn func() *T, resetFn func(*T)) *Pool[T] {
+	return &Pool[T]{
+		pool:  sync.Pool{New: func() any { return newFn() }},
+		reset: resetFn,
+	}
+}
+
+// Get returns an object from the pool, or a new one if the pool is empty
+func (p *Pool[T]) Get() *T {
+	return p.pool.Get().(*T)
+}
+
+// Put resets t and returns it to the pool. t must not be used after it's been
+// put back
+func (p *Pool[T]) Put(t *T) {
+	if p.reset != nil {
+		p.reset(t)
+	}
+	p.pool.Put(t)
+}
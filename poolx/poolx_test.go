@@ -0,0 +1,45 @@
+package poolx
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPool(t *testing.T) {
+	r := require.New(t)
+	created := 0
+	p := New(
+		func() *bytes.Buffer {
+			created++
+			return new(bytes.Buffer)
+		},
+		(*bytes.Buffer).Reset,
+	)
+	buf := p.Get()
+	r.Equal(1, created)
+	buf.WriteString("scratch")
+	p.Put(buf)
+	// Put resets before the pool holds on to the object, so whatever Get
+	// returns is always empty, whether it was reused or not
+	r.Equal(0, buf.Len())
+	r.Equal(0, p.Get().Len())
+
+	ints := New(
+		func() *[]int {
+			s := make([]int, 0, 8)
+			return &s
+		},
+		func(s *[]int) { *s = (*s)[:0] },
+	)
+	s := ints.Get()
+	r.Equal(8, cap(*s))
+	*s = append(*s, 1, 2)
+	ints.Put(s)
+	// the reset reaches the pooled slice, not a copy of it
+	r.Empty(*s)
+
+	noReset := New(func() *int { return new(int) }, nil)
+	noReset.Put(noReset.Get())
+}
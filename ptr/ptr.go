@@ -0,0 +1,50 @@
+// Package ptr provides helpers for working with pointers and zero values,
+// which come up constantly when filling in API request structs whose optional
+// fields are pointers.
+package ptr
+
+// To returns a pointer to a copy of v. It's handy for getting a pointer to a
+// literal or the result of a function call, which Go doesn't allow with &.
+//
+// Example usage:
+//
+//	req := UpdateRequest{Name: To("new-name"), Replicas: To(3)}
+func To[T any](v T) *T {
+	return &v
+}
+
+// Deref returns the value p points to, or fallback if p is nil
+func Deref[T any](p *T, fallback T) T {
+	if p == nil {
+		return fallback
+	}
+	return *p
+}
+
+// DerefZero returns the value p points to, or the zero value of T if p is nil
+func DerefZero[T any](p *T) T {
+	var zero T
+	return Deref(p, zero)
+}
+
+// Equal returns true if a and b are both nil, or both non-nil and point to
+// equal values
+func Equal[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// IsZero returns true if v is the zero value of T
+func IsZero[T comparable](v T) bool {
+	var zero T
+	return v == zero
+}
+
+// ZeroOf returns the zero value of T. It's useful when a zero value is needed
+// in an expression, like a return statement in a generic function
+func ZeroOf[T any]() T {
+	var zero T
+	return zero
+}
@@ -0,0 +1,30 @@
+package ptr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToDeref(t *testing.T) {
+	r := require.New(t)
+	p := To(3)
+	r.Equal(3, *p)
+	r.Equal(3, Deref(p, 7))
+	r.Equal(7, Deref(nil, 7))
+	r.Equal("", DerefZero[string](nil))
+
+	r.True(Equal[int](nil, nil))
+	r.True(Equal(To(1), To(1)))
+	r.False(Equal(To(1), nil))
+	r.False(Equal(To(1), To(2)))
+}
+
+func TestZero(t *testing.T) {
+	r := require.New(t)
+	r.True(IsZero(""))
+	r.False(IsZero(1))
+	r.True(IsZero(struct{ a int }{}))
+	r.Nil(ZeroOf[*int]())
+	r.Equal(0.0, ZeroOf[float64]())
+}
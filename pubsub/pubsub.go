@@ -0,0 +1,305 @@
+// Package pubsub provides a lightweight, in-process publish/subscribe hub.
+//
+// A Bus delivers every published value to every current subscriber over a
+// channel. Each subscriber picks its own buffer size and what happens when
+// its buffer is full, so one slow subscriber can either slow publishers down
+// or miss values, without affecting the others.
+package pubsub
+
+import (
+	"context"
+	"sync"
+)
+
+// Policy decides what Publish does when a subscriber's buffer is full
+type Policy int
+
+const (
+	// Block makes Publish wait until the subscriber has room, or
+	// unsubscribes. This is the default
+	Block Policy = iota
+	// DropNewest discards the value being published
+	DropNewest
+	// DropOldest discards the oldest value in the subscriber's buffer to make
+	// room for the one being published
+	DropOldest
+)
+
+// SubscribeOption configures a single subscription
+type SubscribeOption func(*subscriber)
+
+// Buffer sets how many values a subscription can hold before its Policy
+// applies. The default is 0, meaning every value is handed directly to the
+// receiver
+func Buffer(n int) SubscribeOption {
+	return func(s *subscriber) {
+		s.buffer = max(n, 0)
+	}
+}
+
+// WithPolicy sets what Publish does when the subscription's buffer is full
+func WithPolicy(p Policy) SubscribeOption {
+	return func(s *subscriber) {
+		s.policy = p
+	}
+}
+
+type subscriber struct {
+	buffer int
+	policy Policy
+	done   chan struct{}
+	stop   sync.Once
+	// mut serializes non-blocking deliveries, so DropOldest can't evict a
+	// value another publisher just delivered
+	mut sync.Mutex
+}
+
+type subscription[T any] struct {
+	*subscriber
+	ch chan T
+}
+
+// Bus delivers published values of type T to every subscriber. The zero
+// value is not usable; create one with New. A Bus is safe for concurrent use.
+//
+// Example usage:
+//
+//	bus := New[Event]()
+//	events := bus.Subscribe(ctx, Buffer(16), WithPolicy(DropOldest))
+//	go func() {
+//		for ev := range events {
+//			handle(ev)
+//		}
+//	}()
+//	bus.Publish(Event{Kind: "started"})
+type Bus[T any] struct {
+	mut    sync.RWMutex
+	subs   map[*subscription[T]]struct{}
+	closed bool
+	// done is closed when Close is called, to release blocked publishers
+	done      chan struct{}
+	closeOnce sync.Once
+	// onIdle, if set, is called after an unsubscribe leaves the Bus with no
+	// subscribers
+	onIdle func()
+}
+
+// New creates a new Bus with no subscribers
+func New[T any]() *Bus[T] {
+	return &Bus[T]{
+		subs: map[*subscription[T]]struct{}{},
+		done: make(chan struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives every value published after
+// Subscribe returns. The channel is closed when ctx is done or the Bus is
+// closed, which is the only way to unsubscribe.
+func (b *Bus[T]) Subscribe(ctx context.Context, opts ...SubscribeOption) <-chan T {
+	ch, _ := b.subscribe(ctx, opts...)
+	return ch
+}
+
+// subscribe is the same as Subscribe, but also returns false if the Bus was
+// closed, in which case the channel is already closed
+func (b *Bus[T]) subscribe(ctx context.Context, opts ...SubscribeOption) (<-chan T, bool) {
+	s := &subscriber{done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(s)
+	}
+	sub := &subscription[T]{subscriber: s, ch: make(chan T, s.buffer)}
+
+	b.mut.Lock()
+	if b.closed {
+		b.mut.Unlock()
+		close(sub.ch)
+		return sub.ch, false
+	}
+	b.subs[sub] = struct{}{}
+	b.mut.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			b.unsubscribe(sub)
+		case <-sub.done:
+			// the Bus was closed, which already unsubscribed
+		}
+	}()
+	return sub.ch, true
+}
+
+func (b *Bus[T]) unsubscribe(sub *subscription[T]) {
+	// closing done first releases any publisher blocked on this subscriber,
+	// so it can give up the read lock this needs to acquire
+	sub.stop.Do(func() { close(sub.done) })
+	b.mut.Lock()
+	_, ok := b.subs[sub]
+	if ok {
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+	idle := ok && len(b.subs) == 0
+	b.mut.Unlock()
+	if idle && b.onIdle != nil {
+		b.onIdle()
+	}
+}
+
+// retire closes b if it has no subscribers, so nobody can subscribe to it
+// after Topics has decided to remove it, and reports whether it did
+func (b *Bus[T]) retire() bool {
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	if len(b.subs) > 0 {
+		return false
+	}
+	b.closed = true
+	return true
+}
+
+// Publish delivers v to every current subscriber, according to each one's
+// Policy. It returns once v has been delivered or dropped for every
+// subscriber, so with the Block policy it can wait on slow subscribers.
+// Publishing to a closed Bus does nothing
+func (b *Bus[T]) Publish(v T) {
+	b.mut.RLock()
+	defer b.mut.RUnlock()
+	for sub := range b.subs {
+		deliver(sub, v, b.done)
+	}
+}
+
+func deliver[T any](sub *subscription[T], v T, closing <-chan struct{}) {
+	switch sub.policy {
+	case DropNewest:
+		select {
+		case sub.ch <- v:
+		default:
+		}
+	case DropOldest:
+		sub.mut.Lock()
+		defer sub.mut.Unlock()
+		for {
+			select {
+			case sub.ch <- v:
+				return
+			default:
+			}
+			select {
+			case <-sub.ch:
+			default:
+			}
+			if sub.buffer == 0 {
+				// there's nothing to evict from an unbuffered channel, so
+				// there's no room unless a receiver is waiting right now
+				return
+			}
+		}
+	default:
+		select {
+		case sub.ch <- v:
+		case <-sub.done:
+		case <-closing:
+		}
+	}
+}
+
+// Close unsubscribes every subscriber, closing their channels. Publishing
+// after Close does nothing, and subscribing after Close returns a closed
+// channel
+func (b *Bus[T]) Close() {
+	b.closeOnce.Do(func() { close(b.done) })
+	b.mut.Lock()
+	defer b.mut.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		sub.stop.Do(func() { close(sub.done) })
+		delete(b.subs, sub)
+		close(sub.ch)
+	}
+}
+
+// Topics is a set of Buses keyed by topic. A topic's Bus is created when it
+// gets its first subscriber, and removed when its last subscriber leaves, so
+// topics nobody is subscribed to take up no memory. A Topics is safe for
+// concurrent use
+type Topics[K comparable, T any] struct {
+	mut    sync.Mutex
+	buses  map[K]*Bus[T]
+	closed bool
+}
+
+// NewTopics creates a new Topics with no subscribers
+func NewTopics[K comparable, T any]() *Topics[K, T] {
+	return &Topics[K, T]{buses: map[K]*Bus[T]{}}
+}
+
+// Subscribe is the same as Bus.Subscribe, for the Bus of the given topic
+func (t *Topics[K, T]) Subscribe(ctx context.Context, topic K, opts ...SubscribeOption) <-chan T {
+	for {
+		t.mut.Lock()
+		if t.closed {
+			t.mut.Unlock()
+			ch := make(chan T)
+			close(ch)
+			return ch
+		}
+		b, ok := t.buses[topic]
+		if !ok {
+			b = New[T]()
+			b.onIdle = func() { t.remove(topic, b) }
+			t.buses[topic] = b
+		}
+		t.mut.Unlock()
+
+		// subscribing without holding t.mut means a Bus blocked on a slow
+		// subscriber can't hold up the other topics. If b was retired in
+		// the meantime, drop it and try again with a new one
+		if ch, ok := b.subscribe(ctx, opts...); ok {
+			return ch
+		}
+		t.mut.Lock()
+		if t.buses[topic] == b {
+			delete(t.buses, topic)
+		}
+		t.mut.Unlock()
+	}
+}
+
+// remove deletes b from t if it's still the Bus for topic and has no
+// subscribers
+func (t *Topics[K, T]) remove(topic K, b *Bus[T]) {
+	// retiring b first means a Subscribe racing with this can't add a
+	// subscriber to a Bus that's about to be deleted
+	if !b.retire() {
+		return
+	}
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	if t.buses[topic] == b {
+		delete(t.buses, topic)
+	}
+}
+
+// Publish is the same as Bus.Publish, for the Bus of the given topic. If the
+// topic has no subscribers, v is dropped
+func (t *Topics[K, T]) Publish(topic K, v T) {
+	t.mut.Lock()
+	b, ok := t.buses[topic]
+	t.mut.Unlock()
+	if ok {
+		b.Publish(v)
+	}
+}
+
+// Close closes the Bus for every topic
+func (t *Topics[K, T]) Close() {
+	t.mut.Lock()
+	defer t.mut.Unlock()
+	t.closed = true
+	for topic, b := range t.buses {
+		b.Close()
+		delete(t.buses, topic)
+	}
+}
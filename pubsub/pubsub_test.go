@@ -0,0 +1,147 @@
+package pubsub
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishSubscribe(t *testing.T) {
+	r := require.New(t)
+	bus := New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	a := bus.Subscribe(ctx, Buffer(3))
+	b := bus.Subscribe(context.Background(), Buffer(3))
+	for i := 1; i <= 3; i++ {
+		bus.Publish(i)
+	}
+	cancel()
+	r.Equal([]int{1, 2, 3}, slices.Collect(chanSeq(a)))
+
+	bus.Close()
+	r.Equal([]int{1, 2, 3}, slices.Collect(chanSeq(b)))
+	bus.Publish(4)
+	_, ok := <-bus.Subscribe(context.Background())
+	r.False(ok)
+}
+
+func TestPolicies(t *testing.T) {
+	r := require.New(t)
+	bus := New[int]()
+	newest := bus.Subscribe(context.Background(), Buffer(2), WithPolicy(DropNewest))
+	oldest := bus.Subscribe(context.Background(), Buffer(2), WithPolicy(DropOldest))
+	for i := 1; i <= 4; i++ {
+		bus.Publish(i)
+	}
+	bus.Close()
+	r.Equal([]int{1, 2}, slices.Collect(chanSeq(newest)))
+	r.Equal([]int{3, 4}, slices.Collect(chanSeq(oldest)))
+}
+
+func TestBlockingUnsubscribe(t *testing.T) {
+	r := require.New(t)
+	bus := New[int]()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := bus.Subscribe(ctx)
+	published := make(chan struct{})
+	go func() {
+		bus.Publish(1)
+		close(published)
+	}()
+	time.Sleep(5 * time.Millisecond)
+	// nobody is receiving, so Publish blocks until the subscriber goes away
+	cancel()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		r.Fail("Publish should unblock when the subscriber unsubscribes")
+	}
+	for range ch {
+	}
+}
+
+func TestTopics(t *testing.T) {
+	r := require.New(t)
+	topics := NewTopics[string, int]()
+	a := topics.Subscribe(context.Background(), "a", Buffer(1))
+	b := topics.Subscribe(context.Background(), "b", Buffer(1))
+	topics.Publish("a", 1)
+	topics.Publish("b", 2)
+	topics.Close()
+	r.Equal([]int{1}, slices.Collect(chanSeq(a)))
+	r.Equal([]int{2}, slices.Collect(chanSeq(b)))
+}
+
+func TestTopicsRemovesIdleBuses(t *testing.T) {
+	r := require.New(t)
+	topics := NewTopics[string, int]()
+	numBuses := func() int {
+		topics.mut.Lock()
+		defer topics.mut.Unlock()
+		return len(topics.buses)
+	}
+	// publishing to a topic nobody subscribes to doesn't create its Bus
+	topics.Publish("nobody", 1)
+	r.Zero(numBuses())
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	a := topics.Subscribe(ctx1, "a", Buffer(1))
+	topics.Subscribe(ctx2, "a")
+	r.Equal(1, numBuses())
+
+	cancel1()
+	for range a {
+	}
+	r.Equal(1, numBuses())
+	cancel2()
+	r.Eventually(func() bool { return numBuses() == 0 }, time.Second, time.Millisecond)
+
+	// a new subscriber gets a new Bus
+	b := topics.Subscribe(context.Background(), "a", Buffer(1))
+	topics.Publish("a", 2)
+	topics.Close()
+	r.Equal([]int{2}, slices.Collect(chanSeq(b)))
+	r.Zero(numBuses())
+	_, ok := <-topics.Subscribe(context.Background(), "a")
+	r.False(ok)
+}
+
+func TestTopicsBlockedTopicDoesNotStallOthers(t *testing.T) {
+	r := require.New(t)
+	topics := NewTopics[string, int]()
+	// nobody receives from a, so publishing to it blocks
+	topics.Subscribe(context.Background(), "a")
+	b := topics.Subscribe(context.Background(), "b", Buffer(1))
+	go topics.Publish("a", 1)
+	time.Sleep(5 * time.Millisecond)
+	// subscribing to a waits for the blocked Publish, but mustn't hold up b
+	go topics.Subscribe(context.Background(), "a")
+	time.Sleep(5 * time.Millisecond)
+
+	published := make(chan struct{})
+	go func() {
+		topics.Publish("b", 2)
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		r.FailNow("Publish to b should not wait on a's subscriber")
+	}
+	r.Equal(2, <-b)
+	topics.Close()
+}
+
+func chanSeq[T any](ch <-chan T) func(func(T) bool) {
+	return func(yield func(T) bool) {
+		for v := range ch {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,225 @@
+// Package queue provides a bounded, lock-free queue for any number of
+// producers and consumers.
+//
+// For most producer/consumer code, a buffered channel is the right tool.
+// Queue is for hot paths where a channel's lock shows up in profiles: pushes
+// and pops that don't have to wait never take a lock, so producers and
+// consumers contend only on a pair of atomic counters.
+package queue
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// cell is one slot of the ring buffer. seq says whose turn it is to use the
+// slot: a producer at position pos may write it when seq == pos, and a
+// consumer at position pos may read it when seq == pos+1
+type cell[T any] struct {
+	seq atomic.Uint64
+	val T
+}
+
+// Queue is a bounded first-in, first-out queue that's safe for any number of
+// goroutines to push to and pop from at once. The zero value is not usable;
+// create one with New.
+//
+// Example usage:
+//
+//	q := queue.New[Job](1024)
+//	go func() {
+//		for _, j := range jobs {
+//			q.Push(j)
+//		}
+//	}()
+//	for {
+//		j, err := q.PopCtx(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		handle(j)
+//	}
+type Queue[T any] struct {
+	cells []cell[T]
+	mask  uint64
+
+	// the counters are padded onto their own cache lines, so producers and
+	// consumers don't slow each other down by writing to the same one
+	_   [64]byte
+	enq atomic.Uint64
+	_   [56]byte
+	deq atomic.Uint64
+	_   [56]byte
+
+	notFull  waiters
+	notEmpty waiters
+}
+
+// New creates a Queue that holds up to capacity values. capacity is rounded
+// up to the next power of two, and values less than 2 are treated as 2
+func New[T any](capacity int) *Queue[T] {
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+	q := &Queue[T]{
+		cells:    make([]cell[T], size),
+		mask:     size - 1,
+		notFull:  waiters{ch: make(chan struct{})},
+		notEmpty: waiters{ch: make(chan struct{})},
+	}
+	for i := range q.cells {
+		q.cells[i].seq.Store(uint64(i))
+	}
+	return q
+}
+
+// Cap returns the number of values q can hold
+func (q *Queue[T]) Cap() int {
+	return len(q.cells)
+}
+
+// Len returns the number of values in q. Other goroutines can push and pop
+// at the same time, so the result may be out of date as soon as it's returned
+func (q *Queue[T]) Len() int {
+	deq := q.deq.Load()
+	enq := q.enq.Load()
+	if enq < deq {
+		return 0
+	}
+	return int(min(enq-deq, uint64(len(q.cells))))
+}
+
+// TryPush adds t to the back of q and returns true if q has room, and returns
+// false without blocking otherwise
+func (q *Queue[T]) TryPush(t T) bool {
+	pos := q.enq.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			if q.enq.CompareAndSwap(pos, pos+1) {
+				c.val = t
+				c.seq.Store(pos + 1)
+				q.notEmpty.wake()
+				return true
+			}
+			pos = q.enq.Load()
+		case diff < 0:
+			// the consumer from the last lap hasn't read this slot yet, so
+			// the queue is full
+			return false
+		default:
+			// another producer took this position first
+			pos = q.enq.Load()
+		}
+	}
+}
+
+// TryPop removes and returns the value at the front of q and true if q isn't
+// empty, and returns false without blocking otherwise
+func (q *Queue[T]) TryPop() (T, bool) {
+	pos := q.deq.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			if q.deq.CompareAndSwap(pos, pos+1) {
+				t := c.val
+				var zero T
+				c.val = zero
+				c.seq.Store(pos + q.mask + 1)
+				q.notFull.wake()
+				return t, true
+			}
+			pos = q.deq.Load()
+		case diff < 0:
+			// no producer has written this slot yet, so the queue is empty
+			var zero T
+			return zero, false
+		default:
+			// another consumer took this position first
+			pos = q.deq.Load()
+		}
+	}
+}
+
+// Push adds t to the back of q, blocking until q has room
+func (q *Queue[T]) Push(t T) {
+	// a background context is never done, so there's never an error
+	_ = q.PushCtx(context.Background(), t)
+}
+
+// PushCtx adds t to the back of q, blocking until q has room, and returns
+// nil. Returns ctx.Err() without adding t if ctx is done first
+func (q *Queue[T]) PushCtx(ctx context.Context, t T) error {
+	return q.notFull.wait(ctx, func() bool { return q.TryPush(t) })
+}
+
+// Pop removes and returns the value at the front of q, blocking until q
+// isn't empty
+func (q *Queue[T]) Pop() T {
+	t, _ := q.PopCtx(context.Background())
+	return t
+}
+
+// PopCtx removes and returns the value at the front of q, blocking until q
+// isn't empty. Returns ctx.Err() if ctx is done first
+func (q *Queue[T]) PopCtx(ctx context.Context) (T, error) {
+	var t T
+	err := q.notEmpty.wait(ctx, func() bool {
+		var ok bool
+		t, ok = q.TryPop()
+		return ok
+	})
+	return t, err
+}
+
+// waiters parks goroutines until the queue changes. Only goroutines that
+// have to block touch the lock; a push or pop that nobody is waiting on just
+// checks the count
+type waiters struct {
+	n   atomic.Int64
+	mut sync.Mutex
+	// ch is closed and replaced every time the queue changes while anyone is
+	// waiting, to wake all of them
+	ch chan struct{}
+}
+
+func (w *waiters) wait(ctx context.Context, try func() bool) error {
+	for {
+		if try() {
+			return nil
+		}
+		w.mut.Lock()
+		w.n.Add(1)
+		ch := w.ch
+		w.mut.Unlock()
+		// try again now that wake can see us, in case the queue changed
+		// between the first try and registering
+		if try() {
+			w.n.Add(-1)
+			return nil
+		}
+		select {
+		case <-ch:
+			w.n.Add(-1)
+		case <-ctx.Done():
+			w.n.Add(-1)
+			return ctx.Err()
+		}
+	}
+}
+
+func (w *waiters) wake() {
+	if w.n.Load() == 0 {
+		return
+	}
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	close(w.ch)
+	w.ch = make(chan struct{})
+}
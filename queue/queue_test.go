@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueue(t *testing.T) {
+	r := require.New(t)
+	q := New[int](3)
+	r.Equal(4, q.Cap())
+	_, ok := q.TryPop()
+	r.False(ok)
+	for i := 0; i < 4; i++ {
+		r.True(q.TryPush(i))
+	}
+	r.False(q.TryPush(4))
+	r.Equal(4, q.Len())
+	for i := 0; i < 4; i++ {
+		v, ok := q.TryPop()
+		r.True(ok)
+		r.Equal(i, v)
+	}
+	r.Equal(0, q.Len())
+	r.Equal(2, New[int](0).Cap())
+}
+
+func TestQueueCtx(t *testing.T) {
+	r := require.New(t)
+	q := New[int](2)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err := q.PopCtx(ctx)
+	r.ErrorIs(err, context.DeadlineExceeded)
+
+	q.Push(1)
+	q.Push(2)
+	r.ErrorIs(q.PushCtx(ctx, 3), context.DeadlineExceeded)
+
+	done := make(chan struct{})
+	go func() {
+		q.Push(3)
+		close(done)
+	}()
+	r.Equal(1, q.Pop())
+	<-done
+	r.Equal(2, q.Pop())
+	r.Equal(3, q.Pop())
+}
+
+func TestQueueConcurrent(t *testing.T) {
+	r := require.New(t)
+	const producers, perProducer = 4, 2000
+	q := New[int](16)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Push(p*perProducer + i)
+			}
+		}()
+	}
+	var mut sync.Mutex
+	var got []int
+	var cwg sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			for i := 0; i < producers*perProducer/4; i++ {
+				v := q.Pop()
+				mut.Lock()
+				got = append(got, v)
+				mut.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	cwg.Wait()
+	sort.Ints(got)
+	for i, v := range got {
+		r.Equal(i, v)
+	}
+	r.Len(got, producers*perProducer)
+}
+
+func BenchmarkQueue(b *testing.B) {
+	q := New[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Push(1)
+			q.Pop()
+		}
+	})
+}
+
+func BenchmarkChannel(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
@@ -0,0 +1,264 @@
+// Package rx provides push-based streams of values, in the style of
+// ReactiveX.
+//
+// Where a seq pulls values from its source as the consumer asks for them, an
+// Observable pushes values to its subscriber as the source produces them.
+// That's a better fit for events that arrive on their own schedule, like
+// messages from a socket or ticks of a clock, and it makes time-based
+// operators like Window possible.
+//
+// Observables are cold: nothing happens until Subscribe is called, and each
+// call to Subscribe runs the source again. Cancelling the context passed to
+// Subscribe unsubscribes.
+package rx
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// Observable is a stream of values of type T that are pushed to subscribers
+type Observable[T any] struct {
+	subscribe func(ctx context.Context, next func(T)) error
+}
+
+// Create makes an Observable from fn, which is called once per subscription.
+// fn should call emit for every value, and return when it's done. It must
+// not call emit from more than one goroutine at a time, and should return
+// ctx.Err() promptly once ctx is done. fn's return value is what Subscribe
+// returns
+func Create[T any](fn func(ctx context.Context, emit func(T)) error) Observable[T] {
+	return Observable[T]{subscribe: fn}
+}
+
+// FromSlice returns an Observable that emits every element of slc, in order
+func FromSlice[T any](slc []T) Observable[T] {
+	return Create(func(ctx context.Context, emit func(T)) error {
+		for _, t := range slc {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			emit(t)
+		}
+		return nil
+	})
+}
+
+// FromChan returns an Observable that emits every value received from ch
+// until it's closed. Since receiving from a channel consumes the value,
+// subscribing to the returned Observable more than once splits the values
+// between the subscribers
+func FromChan[T any](ch <-chan T) Observable[T] {
+	return Create(func(ctx context.Context, emit func(T)) error {
+		for {
+			select {
+			case t, ok := <-ch:
+				if !ok {
+					return nil
+				}
+				emit(t)
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	})
+}
+
+// Subscribe runs the Observable, calling next with every value it emits, and
+// blocks until it completes. next is never called concurrently. Returns nil
+// if the Observable completed, the error it failed with otherwise, or
+// ctx.Err() if ctx was cancelled first.
+//
+// Example usage:
+//
+//	err := Map(clicks, toEvent).Subscribe(ctx, func(ev Event) {
+//		fmt.Println(ev)
+//	})
+func (o Observable[T]) Subscribe(ctx context.Context, next func(T)) error {
+	return o.subscribe(ctx, next)
+}
+
+// ToSlice subscribes to o and collects every value it emits. Returns nil for
+// the slice if o fails
+func ToSlice[T any](ctx context.Context, o Observable[T]) ([]T, error) {
+	var ret []T
+	if err := o.Subscribe(ctx, func(t T) { ret = append(ret, t) }); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// Map returns an Observable that emits fn(t) for every t that o emits
+func Map[T, U any](o Observable[T], fn func(T) U) Observable[U] {
+	return Create(func(ctx context.Context, emit func(U)) error {
+		return o.subscribe(ctx, func(t T) { emit(fn(t)) })
+	})
+}
+
+// Filter returns an Observable that emits only the values from o for which
+// pred returns true
+func Filter[T any](o Observable[T], pred func(T) bool) Observable[T] {
+	return Create(func(ctx context.Context, emit func(T)) error {
+		return o.subscribe(ctx, func(t T) {
+			if pred(t) {
+				emit(t)
+			}
+		})
+	})
+}
+
+// Buffer returns an Observable that groups the values from o into slices of
+// size values and emits each slice once it's full. When o completes, any
+// leftover values are emitted as a final, shorter slice. If o fails, the
+// leftover values are discarded
+func Buffer[T any](o Observable[T], size int) Observable[[]T] {
+	size = max(size, 1)
+	return Create(func(ctx context.Context, emit func([]T)) error {
+		var buf []T
+		err := o.subscribe(ctx, func(t T) {
+			buf = append(buf, t)
+			if len(buf) == size {
+				emit(buf)
+				buf = nil
+			}
+		})
+		if err != nil {
+			return err
+		}
+		if len(buf) > 0 {
+			emit(buf)
+		}
+		return nil
+	})
+}
+
+// Window returns an Observable that collects the values o emits during each
+// consecutive period of length d, and emits them as a slice at the end of
+// the period. Periods with no values are skipped. When o completes, the
+// values from the last, partial period are emitted. It panics if d <= 0,
+// when it's called rather than when the Observable is subscribed to
+func Window[T any](o Observable[T], d time.Duration) Observable[[]T] {
+	if d <= 0 {
+		panic("rx: Window called with a non-positive period")
+	}
+	return Create(func(ctx context.Context, emit func([]T)) error {
+		var (
+			mut  sync.Mutex
+			buf  []T
+			stop = make(chan struct{})
+			wg   sync.WaitGroup
+		)
+		flush := func() {
+			mut.Lock()
+			defer mut.Unlock()
+			if len(buf) > 0 {
+				emit(buf)
+				buf = nil
+			}
+		}
+		ticker := time.NewTicker(d)
+		defer ticker.Stop()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ticker.C:
+					flush()
+				case <-stop:
+					return
+				}
+			}
+		}()
+
+		err := o.subscribe(ctx, func(t T) {
+			mut.Lock()
+			defer mut.Unlock()
+			buf = append(buf, t)
+		})
+		close(stop)
+		wg.Wait()
+		if err != nil {
+			return err
+		}
+		flush()
+		return nil
+	})
+}
+
+// Merge returns an Observable that subscribes to every one of obs at once and
+// emits their values as they arrive. It completes once they've all
+// completed. If any of them fails, the others are unsubscribed and Merge
+// fails with the same error
+func Merge[T any](obs ...Observable[T]) Observable[T] {
+	return Create(func(ctx context.Context, emit func(T)) error {
+		var mut sync.Mutex
+		serialized := func(t T) {
+			mut.Lock()
+			defer mut.Unlock()
+			emit(t)
+		}
+		g, ctx := errgroup.WithContext(ctx)
+		for _, o := range obs {
+			g.Go(func() error {
+				return o.subscribe(ctx, serialized)
+			})
+		}
+		return g.Wait()
+	})
+}
+
+// CombineLatest returns an Observable that subscribes to a and b at once, and
+// whenever either emits a value, emits fn applied to the latest value from
+// each of them. Nothing is emitted until both have emitted at least once. It
+// completes once both have completed, and fails if either fails
+func CombineLatest[A, B, C any](a Observable[A], b Observable[B], fn func(A, B) C) Observable[C] {
+	return Create(func(ctx context.Context, emit func(C)) error {
+		var (
+			mut          sync.Mutex
+			la           A
+			lb           B
+			haveA, haveB bool
+		)
+		g, ctx := errgroup.WithContext(ctx)
+		g.Go(func() error {
+			return a.subscribe(ctx, func(v A) {
+				mut.Lock()
+				defer mut.Unlock()
+				la, haveA = v, true
+				if haveB {
+					emit(fn(la, lb))
+				}
+			})
+		})
+		g.Go(func() error {
+			return b.subscribe(ctx, func(v B) {
+				mut.Lock()
+				defer mut.Unlock()
+				lb, haveB = v, true
+				if haveA {
+					emit(fn(la, lb))
+				}
+			})
+		})
+		return g.Wait()
+	})
+}
+
+// Retry returns an Observable that resubscribes to o each time it fails, up
+// to n times, and fails with the last error if o is still failing after
+// that. Each attempt runs o from the beginning, so values emitted before a
+// failure will be emitted again
+func Retry[T any](o Observable[T], n int) Observable[T] {
+	return Create(func(ctx context.Context, emit func(T)) error {
+		for attempt := 0; ; attempt++ {
+			err := o.subscribe(ctx, emit)
+			if err == nil || ctx.Err() != nil || attempt >= n {
+				return err
+			}
+		}
+	})
+}
@@ -0,0 +1,109 @@
+package rx
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapFilterBuffer(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	evens := Filter(FromSlice([]int{1, 2, 3, 4, 5, 6, 7}), func(i int) bool { return i%2 == 1 })
+	got, err := ToSlice(ctx, Buffer(Map(evens, func(i int) int { return i * 10 }), 3))
+	r.NoError(err)
+	r.Equal([][]int{{10, 30, 50}, {70}}, got)
+}
+
+func TestWindow(t *testing.T) {
+	r := require.New(t)
+	src := Create(func(ctx context.Context, emit func(int)) error {
+		emit(1)
+		emit(2)
+		time.Sleep(30 * time.Millisecond)
+		emit(3)
+		return nil
+	})
+	got, err := ToSlice(context.Background(), Window(src, 10*time.Millisecond))
+	r.NoError(err)
+	r.Equal([][]int{{1, 2}, {3}}, got)
+
+	r.Panics(func() { Window(src, 0) })
+	r.Panics(func() { Window(src, -time.Second) })
+}
+
+func TestMergeCombineLatest(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	merged, err := ToSlice(ctx, Merge(FromSlice([]int{1, 2}), FromSlice([]int{3})))
+	r.NoError(err)
+	sort.Ints(merged)
+	r.Equal([]int{1, 2, 3}, merged)
+
+	as := make(chan int)
+	bs := make(chan string)
+	combined := CombineLatest(FromChan(as), FromChan(bs), func(a int, b string) string {
+		return b + string(rune('0'+a))
+	})
+	results := make(chan string)
+	done := make(chan error)
+	go func() {
+		done <- combined.Subscribe(ctx, func(s string) { results <- s })
+	}()
+	as <- 1
+	bs <- "x"
+	r.Equal("x1", <-results)
+	as <- 2
+	r.Equal("x2", <-results)
+	bs <- "y"
+	r.Equal("y2", <-results)
+	close(as)
+	close(bs)
+	r.NoError(<-done)
+
+	boom := errors.New("boom")
+	failing := Create(func(context.Context, func(int)) error { return boom })
+	blocked := FromChan(make(chan int))
+	_, err = ToSlice(ctx, Merge(failing, blocked))
+	r.ErrorIs(err, boom)
+}
+
+func TestRetry(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	attempts := 0
+	flaky := Create(func(_ context.Context, emit func(int)) error {
+		attempts++
+		emit(attempts)
+		if attempts < 3 {
+			return boom
+		}
+		return nil
+	})
+	got, err := ToSlice(context.Background(), Retry(flaky, 5))
+	r.NoError(err)
+	r.Equal([]int{1, 2, 3}, got)
+
+	attempts = 0
+	_, err = ToSlice(context.Background(), Retry(flaky, 1))
+	r.ErrorIs(err, boom)
+	r.Equal(2, attempts)
+}
+
+func TestUnsubscribe(t *testing.T) {
+	r := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan int)
+	go func() {
+		ch <- 1
+		cancel()
+	}()
+	var got []int
+	err := FromChan(ch).Subscribe(ctx, func(i int) { got = append(got, i) })
+	r.ErrorIs(err, context.Canceled)
+	r.Equal([]int{1}, got)
+}
@@ -0,0 +1,91 @@
+// Package saga runs workflows made of steps that can each be undone, so that
+// a failure partway through leaves things as they were before it started,
+// rather than half done.
+package saga
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-functional/core/errs"
+)
+
+// Step is one step of a saga. Do performs the step, and Undo compensates for
+// it, reversing its effects after a later step fails. Undo is only called if
+// Do succeeded, and may be nil if there's nothing to undo
+type Step struct {
+	Name string
+	Do   func(context.Context) error
+	Undo func(context.Context) error
+}
+
+// Error is the error Run returns when a step fails. Step and Index identify
+// the step that failed, and Err is the error it returned. Undo is nil if every
+// completed step was undone, and otherwise a *errs.Multi holding the error
+// from each Undo that failed, keyed by its step's name.
+//
+// Error implements Unwrap() []error, returning Err and Undo, so errors.Is and
+// errors.As see through it to both
+type Error struct {
+	Step  string
+	Index int
+	Err   error
+	Undo  error
+}
+
+func (e *Error) Error() string {
+	if e.Undo == nil {
+		return fmt.Sprintf("saga: step %q failed: %v", e.Step, e.Err)
+	}
+	return fmt.Sprintf("saga: step %q failed: %v; undo failed: %v", e.Step, e.Err, e.Undo)
+}
+
+func (e *Error) Unwrap() []error {
+	if e.Undo == nil {
+		return []error{e.Err}
+	}
+	return []error{e.Err, e.Undo}
+}
+
+// Run calls Do on each step in order. If one fails, or ctx is done before a
+// step starts, Run stops and calls Undo on every step that completed, in
+// reverse order, then returns an *Error describing the failure. A failing
+// Undo doesn't stop the others from running. Undo is called with a context
+// that isn't cancelled when ctx is, so compensation can finish after the
+// saga has been cancelled.
+//
+// Example usage:
+//
+//	err := saga.Run(ctx, []saga.Step{
+//		{Name: "reserve", Do: reserveStock, Undo: releaseStock},
+//		{Name: "charge", Do: chargeCard, Undo: refundCard},
+//		{Name: "ship", Do: createShipment},
+//	})
+func Run(ctx context.Context, steps []Step) error {
+	for i, step := range steps {
+		err := ctx.Err()
+		if err == nil {
+			err = step.Do(ctx)
+		}
+		if err != nil {
+			return &Error{
+				Step:  step.Name,
+				Index: i,
+				Err:   err,
+				Undo:  compensate(context.WithoutCancel(ctx), steps[:i]),
+			}
+		}
+	}
+	return nil
+}
+
+// compensate undoes every step in done, last first
+func compensate(ctx context.Context, done []Step) error {
+	var failed errs.Multi
+	for i := len(done) - 1; i >= 0; i-- {
+		if done[i].Undo != nil {
+			failed.Add(done[i].Name, done[i].Undo(ctx))
+		}
+	}
+	return failed.Err()
+}
@@ -0,0 +1,88 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	r := require.New(t)
+	var log []string
+	step := func(name string, doErr, undoErr error) Step {
+		return Step{
+			Name: name,
+			Do: func(context.Context) error {
+				log = append(log, "do "+name)
+				return doErr
+			},
+			Undo: func(ctx context.Context) error {
+				r.NoError(ctx.Err())
+				log = append(log, "undo "+name)
+				return undoErr
+			},
+		}
+	}
+	ctx := context.Background()
+
+	r.NoError(Run(ctx, []Step{step("a", nil, nil), step("b", nil, nil)}))
+	r.Equal([]string{"do a", "do b"}, log)
+
+	log = nil
+	boom := errors.New("boom")
+	err := Run(ctx, []Step{
+		step("a", nil, nil),
+		{Name: "nothing to undo", Do: func(context.Context) error { return nil }},
+		step("b", nil, nil),
+		step("c", boom, nil),
+		step("d", nil, nil),
+	})
+	r.Equal([]string{"do a", "do b", "do c", "undo b", "undo a"}, log)
+	var sagaErr *Error
+	r.ErrorAs(err, &sagaErr)
+	r.Equal("c", sagaErr.Step)
+	r.Equal(3, sagaErr.Index)
+	r.NoError(sagaErr.Undo)
+	r.ErrorIs(err, boom)
+	r.EqualError(err, `saga: step "c" failed: boom`)
+
+	log = nil
+	stuck := errors.New("stuck")
+	err = Run(ctx, []Step{step("a", nil, stuck), step("b", nil, nil), step("c", boom, nil)})
+	r.Equal([]string{"do a", "do b", "do c", "undo b", "undo a"}, log)
+	r.ErrorIs(err, boom)
+	r.ErrorIs(err, stuck)
+	var undoErr *errs.Multi
+	r.ErrorAs(err, &undoErr)
+	r.Equal("a", undoErr.Errors()[0].Key)
+	r.EqualError(err, `saga: step "c" failed: boom; undo failed: a: stuck`)
+}
+
+func TestRunCancelled(t *testing.T) {
+	r := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	var undone bool
+	err := Run(ctx, []Step{
+		{
+			Name: "a",
+			Do: func(context.Context) error {
+				cancel()
+				return nil
+			},
+			Undo: func(ctx context.Context) error {
+				undone = true
+				return ctx.Err()
+			},
+		},
+		{Name: "b", Do: func(context.Context) error { panic("unreachable") }},
+	})
+	var sagaErr *Error
+	r.ErrorAs(err, &sagaErr)
+	r.Equal("b", sagaErr.Step)
+	r.ErrorIs(err, context.Canceled)
+	r.NoError(sagaErr.Undo)
+	r.True(undone)
+}
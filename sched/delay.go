@@ -0,0 +1,150 @@
+// Package sched runs work at a later time.
+//
+// A DelayQueue holds items until their deadline passes, for code that wants
+// to pull due work on its own terms. A Scheduler sits on top of one and runs
+// functions after a delay or at a fixed interval, until it's stopped or its
+// context is done.
+package sched
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// DelayQueue holds items until their deadline, and hands them out in
+// deadline order once it's passed. It's safe for concurrent use. The zero
+// value is not usable; create one with NewDelayQueue.
+//
+// Example usage:
+//
+//	q := NewDelayQueue[Retry]()
+//	q.Push(r, time.Now().Add(backoff))
+//	for {
+//		r, err := q.Pop(ctx)
+//		if err != nil {
+//			return err
+//		}
+//		resend(r)
+//	}
+type DelayQueue[T any] struct {
+	mut   sync.Mutex
+	items delayHeap[T]
+	// wake is closed and replaced whenever an item is pushed, since it might
+	// be due before whatever each waiting Pop is waiting for. Closing it
+	// wakes every waiting Pop, not just one
+	wake chan struct{}
+	now  func() time.Time
+}
+
+// NewDelayQueue creates an empty DelayQueue
+func NewDelayQueue[T any]() *DelayQueue[T] {
+	return &DelayQueue[T]{wake: make(chan struct{}), now: time.Now}
+}
+
+// Push adds t to q, to be handed out by Pop once at has passed. Items with
+// the same deadline are handed out in the order they were pushed
+func (q *DelayQueue[T]) Push(t T, at time.Time) {
+	q.mut.Lock()
+	heap.Push(&q.items, delayed[T]{val: t, at: at, seq: q.items.next})
+	q.items.next++
+	close(q.wake)
+	q.wake = make(chan struct{})
+	q.mut.Unlock()
+}
+
+// Len returns the number of items in q, whether they're due or not
+func (q *DelayQueue[T]) Len() int {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	return len(q.items.items)
+}
+
+// TryPop removes and returns the item with the earliest deadline and true if
+// its deadline has passed, and returns false otherwise
+func (q *DelayQueue[T]) TryPop() (T, bool) {
+	q.mut.Lock()
+	defer q.mut.Unlock()
+	t, ok, _ := q.popDue()
+	return t, ok
+}
+
+// popDue pops the first item if it's due. Otherwise it returns how long until
+// it will be, or a negative duration if q is empty. q.mut must be held
+func (q *DelayQueue[T]) popDue() (T, bool, time.Duration) {
+	var zero T
+	if len(q.items.items) == 0 {
+		return zero, false, -1
+	}
+	wait := q.items.items[0].at.Sub(q.now())
+	if wait > 0 {
+		return zero, false, wait
+	}
+	return heap.Pop(&q.items).(delayed[T]).val, true, 0
+}
+
+// Pop removes and returns the item with the earliest deadline, blocking until
+// that deadline has passed. Returns ctx.Err() if ctx is done first
+func (q *DelayQueue[T]) Pop(ctx context.Context) (T, error) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		q.mut.Lock()
+		t, ok, wait := q.popDue()
+		// taken under the same lock as popDue, so any Push after it closes
+		// this wake
+		wake := q.wake
+		q.mut.Unlock()
+		if ok {
+			return t, nil
+		}
+
+		var timerC <-chan time.Time
+		if wait > 0 {
+			timer.Reset(wait)
+			timerC = timer.C
+		}
+		select {
+		case <-timerC:
+		case <-wake:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		timer.Stop()
+	}
+}
+
+type delayed[T any] struct {
+	val T
+	at  time.Time
+	seq uint64
+}
+
+// delayHeap is a min-heap of items by deadline, then by push order
+type delayHeap[T any] struct {
+	items []delayed[T]
+	next  uint64
+}
+
+func (h *delayHeap[T]) Len() int { return len(h.items) }
+
+func (h *delayHeap[T]) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if !a.at.Equal(b.at) {
+		return a.at.Before(b.at)
+	}
+	return a.seq < b.seq
+}
+
+func (h *delayHeap[T]) Swap(i, j int) { h.items[i], h.items[j] = h.items[j], h.items[i] }
+
+func (h *delayHeap[T]) Push(x any) { h.items = append(h.items, x.(delayed[T])) }
+
+func (h *delayHeap[T]) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items[len(h.items)-1] = delayed[T]{}
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
@@ -0,0 +1,115 @@
+package sched
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelayQueue(t *testing.T) {
+	r := require.New(t)
+	q := NewDelayQueue[string]()
+	now := time.Now()
+	q.Push("later", now.Add(20*time.Millisecond))
+	q.Push("past", now.Add(-time.Second))
+	q.Push("soon", now.Add(5*time.Millisecond))
+	q.Push("soon too", now.Add(5*time.Millisecond))
+	r.Equal(4, q.Len())
+
+	v, ok := q.TryPop()
+	r.True(ok)
+	r.Equal("past", v)
+	_, ok = q.TryPop()
+	r.False(ok)
+
+	ctx := context.Background()
+	for _, want := range []string{"soon", "soon too", "later"} {
+		v, err := q.Pop(ctx)
+		r.NoError(err)
+		r.Equal(want, v)
+	}
+	r.GreaterOrEqual(time.Since(now), 20*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Millisecond)
+	defer cancel()
+	_, err := q.Pop(ctx)
+	r.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestDelayQueueEarlierPush(t *testing.T) {
+	r := require.New(t)
+	q := NewDelayQueue[int]()
+	q.Push(1, time.Now().Add(time.Hour))
+	go func() {
+		time.Sleep(time.Millisecond)
+		q.Push(2, time.Now())
+	}()
+	v, err := q.Pop(context.Background())
+	r.NoError(err)
+	r.Equal(2, v)
+}
+
+func TestDelayQueueWakesEveryPop(t *testing.T) {
+	r := require.New(t)
+	q := NewDelayQueue[int]()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	const consumers = 4
+	errs := make(chan error, consumers)
+	for range consumers {
+		go func() {
+			_, err := q.Pop(ctx)
+			errs <- err
+		}()
+	}
+	// let every consumer start waiting on the empty queue
+	time.Sleep(10 * time.Millisecond)
+	for i := range consumers {
+		q.Push(i, time.Now())
+	}
+	for range consumers {
+		r.NoError(<-errs)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	r := require.New(t)
+	s := New(context.Background())
+	once := make(chan struct{})
+	s.After(time.Millisecond, func(context.Context) { close(once) })
+	cancelled := s.After(time.Millisecond, func(context.Context) { panic("cancelled job ran") })
+	cancelled.Cancel()
+
+	var ticks atomic.Int64
+	tick := make(chan struct{}, 10)
+	job := s.Every(2*time.Millisecond, func(context.Context) {
+		ticks.Add(1)
+		tick <- struct{}{}
+	})
+	<-once
+	for i := 0; i < 3; i++ {
+		<-tick
+	}
+	job.Cancel()
+	time.Sleep(10 * time.Millisecond)
+	n := ticks.Load()
+	time.Sleep(10 * time.Millisecond)
+	r.Equal(n, ticks.Load())
+
+	started := make(chan struct{})
+	var sawCancel atomic.Bool
+	s.After(0, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		sawCancel.Store(true)
+	})
+	<-started
+	s.Stop()
+	r.True(sawCancel.Load())
+	s.Stop()
+
+	r.Panics(func() { s.Every(0, func(context.Context) {}) })
+}
@@ -0,0 +1,118 @@
+package sched
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Job is a function scheduled with a Scheduler
+type Job struct {
+	fn        func(context.Context)
+	at        time.Time
+	interval  time.Duration
+	cancelled atomic.Bool
+}
+
+// Cancel stops j from running again. If it's running right now, that run
+// isn't interrupted, but a job scheduled with Every won't be rescheduled
+func (j *Job) Cancel() {
+	j.cancelled.Store(true)
+}
+
+// Scheduler runs functions after a delay, or repeatedly at a fixed interval.
+// Every function runs in its own goroutine, with the Scheduler's context,
+// which is cancelled when the Scheduler is stopped. The zero value is not
+// usable; create one with New.
+//
+// Example usage:
+//
+//	s := sched.New(ctx)
+//	defer s.Stop()
+//	s.Every(time.Minute, func(ctx context.Context) {
+//		_ = p.Flush(ctx)
+//	})
+//	s.After(10*time.Second, func(ctx context.Context) {
+//		log.Print("warmed up")
+//	})
+type Scheduler struct {
+	queue  *DelayQueue[*Job]
+	ctx    context.Context
+	cancel context.CancelFunc
+	// running counts the dispatch goroutine and every job that's running
+	running sync.WaitGroup
+	now     func() time.Time
+}
+
+// New creates a Scheduler and starts the goroutine that runs its jobs. It
+// runs until ctx is done or Stop is called
+func New(ctx context.Context) *Scheduler {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Scheduler{
+		queue:  NewDelayQueue[*Job](),
+		ctx:    ctx,
+		cancel: cancel,
+		now:    time.Now,
+	}
+	s.running.Add(1)
+	go s.dispatch()
+	return s
+}
+
+// After schedules fn to run once, after d has passed
+func (s *Scheduler) After(d time.Duration, fn func(context.Context)) *Job {
+	j := &Job{fn: fn, at: s.now().Add(d)}
+	s.queue.Push(j, j.at)
+	return j
+}
+
+// Every schedules fn to run every interval, starting one interval from now,
+// until the Job is cancelled or the Scheduler stops. Runs never overlap: if
+// a run takes longer than interval, the runs it overlapped are skipped, and
+// the next one happens at the next multiple of interval from the start.
+// interval must be positive, or Every panics
+func (s *Scheduler) Every(interval time.Duration, fn func(context.Context)) *Job {
+	if interval <= 0 {
+		panic("sched: Every called with a non-positive interval")
+	}
+	j := &Job{fn: fn, at: s.now().Add(interval), interval: interval}
+	s.queue.Push(j, j.at)
+	return j
+}
+
+// Stop cancels the Scheduler's context, so no more jobs start, and waits for
+// the jobs that are running to return. Calling Stop more than once is safe
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.running.Wait()
+}
+
+func (s *Scheduler) dispatch() {
+	defer s.running.Done()
+	for {
+		j, err := s.queue.Pop(s.ctx)
+		if err != nil {
+			return
+		}
+		if j.cancelled.Load() {
+			continue
+		}
+		s.running.Add(1)
+		go s.run(j)
+	}
+}
+
+func (s *Scheduler) run(j *Job) {
+	defer s.running.Done()
+	j.fn(s.ctx)
+	if j.interval == 0 || j.cancelled.Load() || s.ctx.Err() != nil {
+		return
+	}
+	next := j.at.Add(j.interval)
+	for now := s.now(); !next.After(now); {
+		next = next.Add(j.interval)
+	}
+	j.at = next
+	s.queue.Push(j, next)
+}
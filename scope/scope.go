@@ -0,0 +1,136 @@
+// Package scope provides structured concurrency: goroutines started in a
+// Scope can't outlive it.
+//
+// Run doesn't return until every goroutine spawned in its Scope has returned,
+// so there's no way to leak one by forgetting to wait. If any of them fails
+// the rest are cancelled, and if any of them panics, the panic is carried
+// back to the goroutine that called Run instead of crashing the program from
+// somewhere unrelated.
+package scope
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-functional/core/errs"
+)
+
+// SpawnOption configures a single goroutine spawned in a Scope
+type SpawnOption func(*spawnConfig)
+
+type spawnConfig struct {
+	timeout time.Duration
+}
+
+// Timeout gives a spawned goroutine a context that's cancelled after d, in
+// addition to being cancelled along with the rest of the Scope. Exceeding the
+// timeout only fails the Scope if the goroutine returns the context's error
+func Timeout(d time.Duration) SpawnOption {
+	return func(c *spawnConfig) {
+		c.timeout = d
+	}
+}
+
+// Scope is a group of goroutines that all return before the Run call that
+// created the Scope does
+type Scope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mut    sync.Mutex
+	err    error
+	panics *errs.PanicError
+}
+
+// Run creates a Scope, calls fn with it, and waits for every goroutine
+// spawned in it to return before returning itself. The Scope's context is
+// cancelled as soon as fn or any spawned goroutine returns an error, and Run
+// returns the first one.
+//
+// If a spawned goroutine panics, the Scope is cancelled and, once everything
+// has returned, Run panics with a *errs.PanicError holding the original value.
+//
+// Example usage:
+//
+//	err := Run(ctx, func(s *Scope) error {
+//		for _, shard := range shards {
+//			s.Spawn(func(ctx context.Context) error {
+//				return process(ctx, shard)
+//			}, Timeout(time.Minute))
+//		}
+//		return nil
+//	})
+func Run(ctx context.Context, fn func(s *Scope) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Scope{ctx: ctx, cancel: cancel}
+	func() {
+		// even if fn panics, its children still have to be cancelled and
+		// waited for before the panic continues up the stack
+		defer func() {
+			if v := recover(); v != nil {
+				cancel()
+				s.wg.Wait()
+				panic(v)
+			}
+		}()
+		if err := fn(s); err != nil {
+			s.fail(err)
+		}
+	}()
+	s.wg.Wait()
+	cancel()
+	if s.panics != nil {
+		panic(s.panics)
+	}
+	return s.err
+}
+
+// Context returns the Scope's context, which is cancelled when the Scope
+// fails or Run returns
+func (s *Scope) Context() context.Context {
+	return s.ctx
+}
+
+// Spawn runs fn in a new goroutine that belongs to s. fn is passed a context
+// derived from the Scope's. Spawn can be called from fn or from other
+// spawned goroutines, but not after Run has returned
+func (s *Scope) Spawn(fn func(ctx context.Context) error, opts ...SpawnOption) {
+	var cfg spawnConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			if v := recover(); v != nil {
+				s.mut.Lock()
+				if s.panics == nil {
+					s.panics = errs.NewPanicError(v)
+				}
+				s.mut.Unlock()
+				s.cancel()
+			}
+		}()
+		ctx := s.ctx
+		if cfg.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+			defer cancel()
+		}
+		if err := fn(ctx); err != nil {
+			s.fail(err)
+		}
+	}()
+}
+
+func (s *Scope) fail(err error) {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.err == nil {
+		s.err = err
+		s.cancel()
+	}
+}
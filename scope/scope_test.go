@@ -0,0 +1,81 @@
+package scope
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-functional/core/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunWaitsForChildren(t *testing.T) {
+	r := require.New(t)
+	var finished atomic.Int64
+	err := Run(context.Background(), func(s *Scope) error {
+		for i := 0; i < 5; i++ {
+			s.Spawn(func(ctx context.Context) error {
+				time.Sleep(time.Millisecond)
+				s.Spawn(func(context.Context) error {
+					finished.Add(1)
+					return nil
+				})
+				finished.Add(1)
+				return nil
+			})
+		}
+		return nil
+	})
+	r.NoError(err)
+	r.Equal(int64(10), finished.Load())
+}
+
+func TestRunCancelsOnError(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	err := Run(context.Background(), func(s *Scope) error {
+		s.Spawn(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		s.Spawn(func(context.Context) error { return boom })
+		return nil
+	})
+	r.ErrorIs(err, boom)
+}
+
+func TestRunPanics(t *testing.T) {
+	r := require.New(t)
+	boom := errors.New("boom")
+	var cancelled atomic.Bool
+	defer func() {
+		v := recover()
+		pe, ok := v.(*errs.PanicError)
+		r.True(ok)
+		r.ErrorIs(pe, boom)
+		r.True(cancelled.Load(), "other children must finish before the panic propagates")
+	}()
+	Run(context.Background(), func(s *Scope) error {
+		s.Spawn(func(ctx context.Context) error {
+			<-ctx.Done()
+			cancelled.Store(true)
+			return nil
+		})
+		s.Spawn(func(context.Context) error { panic(boom) })
+		return nil
+	})
+}
+
+func TestTimeout(t *testing.T) {
+	r := require.New(t)
+	err := Run(context.Background(), func(s *Scope) error {
+		s.Spawn(func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}, Timeout(5*time.Millisecond))
+		return nil
+	})
+	r.ErrorIs(err, context.DeadlineExceeded)
+}
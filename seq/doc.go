@@ -0,0 +1,7 @@
+// Package seq provides lazy sequences built on the standard library's
+// iter.Seq and iter.Seq2 types.
+//
+// Nothing in a sequence is computed until it's ranged over, and most
+// operations stop pulling from their source as soon as the consumer stops,
+// so sequences can be long, expensive to produce, or even infinite.
+package seq
@@ -0,0 +1,21 @@
+package seq
+
+import (
+	"iter"
+
+	core "github.com/go-functional/core"
+)
+
+// Enumerate returns a sequence of Tuples pairing the position of every value
+// in s, starting at 0, with the value itself
+func Enumerate[T any](s iter.Seq[T]) iter.Seq[core.Tuple[int, T]] {
+	return func(yield func(core.Tuple[int, T]) bool) {
+		i := 0
+		for t := range s {
+			if !yield(core.Tup(i, t)) {
+				return
+			}
+			i++
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package seq
+
+import (
+	"slices"
+	"testing"
+
+	core "github.com/go-functional/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumerate(t *testing.T) {
+	r := require.New(t)
+	got := slices.Collect(Enumerate(slices.Values([]string{"a", "b", "c"})))
+	r.Equal([]core.Tuple[int, string]{core.Tup(0, "a"), core.Tup(1, "b"), core.Tup(2, "c")}, got)
+
+	for p := range Enumerate(Times(10, func(i int) int { return i * i })) {
+		r.Equal(0, core.First(p))
+		break
+	}
+}
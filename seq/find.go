@@ -0,0 +1,50 @@
+package seq
+
+import "iter"
+
+// Find returns the first value in s that pred returns true for, and true, or
+// the zero value of T and false if there isn't one. It stops pulling from s
+// as soon as it finds a match, so the rest of s is never computed.
+//
+// Example usage, stopping at the first page with a result:
+//
+//	page, ok := Find(fetchPages(ctx), func(p Page) bool {
+//		return len(p.Results) > 0
+//	})
+func Find[T any](s iter.Seq[T], pred func(T) bool) (T, bool) {
+	for t := range s {
+		if pred(t) {
+			return t, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// First returns the first value in s, and true, or the zero value of T and
+// false if s is empty. It pulls only that one value from s
+func First[T any](s iter.Seq[T]) (T, bool) {
+	for t := range s {
+		return t, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Any returns true if pred returns true for any value in s, stopping at the
+// first one it does. It returns false if s is empty
+func Any[T any](s iter.Seq[T], pred func(T) bool) bool {
+	_, ok := Find(s, pred)
+	return ok
+}
+
+// All returns true if pred returns true for every value in s, stopping at the
+// first one it returns false for. It returns true if s is empty
+func All[T any](s iter.Seq[T], pred func(T) bool) bool {
+	for t := range s {
+		if !pred(t) {
+			return false
+		}
+	}
+	return true
+}
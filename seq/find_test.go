@@ -0,0 +1,54 @@
+package seq
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	r := require.New(t)
+	pulled := 0
+	counting := Times(100, func(i int) int { pulled++; return i })
+	isBig := func(n int) bool { return n > 2 }
+
+	n, ok := Find(counting, isBig)
+	r.True(ok)
+	r.Equal(3, n)
+	r.Equal(4, pulled)
+
+	n, ok = Find(RangeN(3), isBig)
+	r.False(ok)
+	r.Equal(0, n)
+}
+
+func TestFirst(t *testing.T) {
+	r := require.New(t)
+	pulled := 0
+	n, ok := First(Times(100, func(i int) int { pulled++; return i + 1 }))
+	r.True(ok)
+	r.Equal(1, n)
+	r.Equal(1, pulled)
+
+	_, ok = First(RangeN(0))
+	r.False(ok)
+}
+
+func TestAnyAll(t *testing.T) {
+	r := require.New(t)
+	pulled := 0
+	counting := Times(100, func(i int) int { pulled++; return i })
+	even := func(n int) bool { return n%2 == 0 }
+	small := func(n int) bool { return n < 5 }
+
+	r.True(Any(counting, small))
+	r.Equal(1, pulled)
+	pulled = 0
+	r.False(All(counting, small))
+	r.Equal(6, pulled)
+
+	r.True(All(Times(3, func(i int) int { return i * 2 }), even))
+	r.False(Any(Times(3, func(i int) int { return i*2 + 1 }), even))
+	r.False(Any(RangeN(0), even))
+	r.True(All(RangeN(0), even))
+}
@@ -0,0 +1,111 @@
+package seq
+
+import "iter"
+
+// JoinMode decides which unmatched values JoinSorted keeps
+type JoinMode int
+
+const (
+	// InnerJoin keeps only pairs of values with matching keys
+	InnerJoin JoinMode = iota
+	// LeftJoin also keeps every left value with no matching right value
+	LeftJoin
+	// OuterJoin also keeps every unmatched value from either side
+	OuterJoin
+)
+
+// JoinSorted returns a streaming sort-merge join of left and right, which
+// must both be sorted by their keys, in the order keyCmp defines. keyCmp
+// compares the key of a left value to the key of a right value, returning a
+// negative number, 0 or a positive number, like cmp.Compare.
+//
+// For every left value and every right value whose keys match, the sequence
+// yields combine called with pointers to both. Depending on mode, it also
+// yields combine for unmatched values, with a nil pointer for the side
+// that's missing. Results come out in key order, and left values with equal
+// keys are each matched with every right value with that key. combine may
+// keep the pointers it's passed, since they stay valid after it returns, but
+// left values with equal keys share pointers to the same right values.
+//
+// Both inputs are consumed lazily, in a single pass, so JoinSorted only
+// holds the run of right values that share the current key in memory.
+//
+// Example usage:
+//
+//	byUser := func(o Order, u User) int { return cmp.Compare(o.UserID, u.ID) }
+//	rows := JoinSorted(orders, users, byUser, func(o *Order, u *User) Row {
+//		return Row{Order: *o, User: ptr.DerefZero(u)}
+//	}, LeftJoin)
+func JoinSorted[L, R, O any](
+	left iter.Seq[L],
+	right iter.Seq[R],
+	keyCmp func(L, R) int,
+	combine func(l *L, r *R) O,
+	mode JoinMode,
+) iter.Seq[O] {
+	return func(yield func(O) bool) {
+		nextL, stopL := iter.Pull(left)
+		defer stopL()
+		nextR, stopR := iter.Pull(right)
+		defer stopR()
+
+		l, okL := nextL()
+		r, okR := nextR()
+		// group is the run of right values that matched the previous left
+		// value, to match against following left values with the same key
+		var group []R
+		emitGroup := func(l L) bool {
+			for i := range group {
+				if !yield(combine(&l, &group[i])) {
+					return false
+				}
+			}
+			return true
+		}
+		for ; okL; l, okL = nextL() {
+			if len(group) > 0 && keyCmp(l, group[0]) == 0 {
+				if !emitGroup(l) {
+					return
+				}
+				continue
+			}
+			// start a new run rather than reusing the old one's array, since
+			// combine may have kept pointers into it
+			group = nil
+			for okR && keyCmp(l, r) > 0 {
+				if mode == OuterJoin {
+					unmatched := r
+					if !yield(combine(nil, &unmatched)) {
+						return
+					}
+				}
+				r, okR = nextR()
+			}
+			for okR && keyCmp(l, r) == 0 {
+				group = append(group, r)
+				r, okR = nextR()
+			}
+			if len(group) > 0 {
+				if !emitGroup(l) {
+					return
+				}
+				continue
+			}
+			if mode != InnerJoin {
+				unmatched := l
+				if !yield(combine(&unmatched, nil)) {
+					return
+				}
+			}
+		}
+		if mode != OuterJoin {
+			return
+		}
+		for ; okR; r, okR = nextR() {
+			unmatched := r
+			if !yield(combine(nil, &unmatched)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package seq
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinSorted(t *testing.T) {
+	r := require.New(t)
+	type user struct {
+		ID   int
+		Name string
+	}
+	orders := []int{1, 1, 3, 4, 6}
+	users := []user{{0, "zoe"}, {1, "ann"}, {1, "al"}, {3, "bo"}, {5, "cy"}}
+	keyCmp := func(o int, u user) int { return cmp.Compare(o, u.ID) }
+	combine := func(o *int, u *user) string {
+		ord, name := "-", "-"
+		if o != nil {
+			ord = fmt.Sprint(*o)
+		}
+		if u != nil {
+			name = u.Name
+		}
+		return ord + ":" + name
+	}
+	join := func(mode JoinMode) []string {
+		return slices.Collect(JoinSorted(slices.Values(orders), slices.Values(users), keyCmp, combine, mode))
+	}
+
+	r.Equal([]string{"1:ann", "1:al", "1:ann", "1:al", "3:bo"}, join(InnerJoin))
+	r.Equal([]string{"1:ann", "1:al", "1:ann", "1:al", "3:bo", "4:-", "6:-"}, join(LeftJoin))
+	r.Equal([]string{"-:zoe", "1:ann", "1:al", "1:ann", "1:al", "3:bo", "4:-", "-:cy", "6:-"}, join(OuterJoin))
+
+	empty := slices.Collect(JoinSorted(slices.Values([]int(nil)), slices.Values(users), keyCmp, combine, OuterJoin))
+	r.Equal([]string{"-:zoe", "-:ann", "-:al", "-:bo", "-:cy"}, empty)
+
+	// pointers kept from earlier runs aren't overwritten by later ones
+	kept := slices.Collect(JoinSorted(slices.Values([]int{1, 3}), slices.Values(users), keyCmp,
+		func(_ *int, u *user) *user { return u }, InnerJoin))
+	r.Len(kept, 3)
+	r.Equal([]string{"ann", "al", "bo"}, []string{kept[0].Name, kept[1].Name, kept[2].Name})
+
+	for s := range JoinSorted(slices.Values(orders), slices.Values(users), keyCmp, combine, OuterJoin) {
+		r.Equal("-:zoe", s)
+		break
+	}
+}
@@ -0,0 +1,96 @@
+package seq
+
+import (
+	"container/heap"
+	"iter"
+)
+
+// MergeSorted returns a sequence of every value in seqs, in sorted order,
+// given that each of seqs is already sorted by less. It's a k-way merge that
+// holds only one value from each input at a time, so it can merge sorted
+// files or streams that are far too large to load. Values that are equal
+// according to less come out in the order of the seqs they came from, so the
+// merge is stable.
+//
+// Example usage, merging time-ordered log shards:
+//
+//	byTime := func(a, b Entry) bool { return a.Time.Before(b.Time) }
+//	for e := range MergeSorted(byTime, shard1, shard2, shard3) {
+//		...
+//	}
+func MergeSorted[T any](less func(a, b T) bool, seqs ...iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		h := &mergeHeap[T]{less: less}
+		defer func() {
+			for _, c := range h.cursors {
+				c.stop()
+			}
+		}()
+		for i, s := range seqs {
+			next, stop := iter.Pull(s)
+			c := &mergeCursor[T]{next: next, stop: stop, src: i}
+			if c.advance() {
+				h.cursors = append(h.cursors, c)
+			} else {
+				stop()
+			}
+		}
+		heap.Init(h)
+		for h.Len() > 0 {
+			c := h.cursors[0]
+			if !yield(c.head) {
+				return
+			}
+			if c.advance() {
+				heap.Fix(h, 0)
+			} else {
+				c.stop()
+				heap.Pop(h)
+			}
+		}
+	}
+}
+
+// mergeCursor is one input to MergeSorted, along with the value it's
+// currently holding
+type mergeCursor[T any] struct {
+	next func() (T, bool)
+	stop func()
+	head T
+	// src is the position of the input in the arguments, to break ties
+	src int
+}
+
+func (c *mergeCursor[T]) advance() bool {
+	t, ok := c.next()
+	c.head = t
+	return ok
+}
+
+type mergeHeap[T any] struct {
+	cursors []*mergeCursor[T]
+	less    func(a, b T) bool
+}
+
+func (h *mergeHeap[T]) Len() int { return len(h.cursors) }
+
+func (h *mergeHeap[T]) Less(i, j int) bool {
+	a, b := h.cursors[i], h.cursors[j]
+	if h.less(a.head, b.head) {
+		return true
+	}
+	if h.less(b.head, a.head) {
+		return false
+	}
+	return a.src < b.src
+}
+
+func (h *mergeHeap[T]) Swap(i, j int) { h.cursors[i], h.cursors[j] = h.cursors[j], h.cursors[i] }
+
+func (h *mergeHeap[T]) Push(x any) { h.cursors = append(h.cursors, x.(*mergeCursor[T])) }
+
+func (h *mergeHeap[T]) Pop() any {
+	last := h.cursors[len(h.cursors)-1]
+	h.cursors = h.cursors[:len(h.cursors)-1]
+	return last
+}
@@ -0,0 +1,55 @@
+package seq
+
+import (
+	"iter"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	core "github.com/go-functional/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeSorted(t *testing.T) {
+	r := require.New(t)
+	less := func(a, b int) bool { return a < b }
+	got := slices.Collect(MergeSorted(less,
+		slices.Values([]int{1, 4, 7}),
+		slices.Values([]int{}),
+		slices.Values([]int{2, 3, 8, 9}),
+		slices.Values([]int{0, 5}),
+	))
+	r.Equal([]int{0, 1, 2, 3, 4, 5, 7, 8, 9}, got)
+	r.Empty(slices.Collect(MergeSorted(less)))
+
+	// ties come out in argument order
+	byKey := func(a, b core.Tuple[int, string]) bool { return core.First(a) < core.First(b) }
+	pairs := slices.Collect(MergeSorted(byKey,
+		slices.Values([]core.Tuple[int, string]{core.Tup(1, "a"), core.Tup(2, "a")}),
+		slices.Values([]core.Tuple[int, string]{core.Tup(1, "b"), core.Tup(2, "b")}),
+	))
+	r.Equal([]core.Tuple[int, string]{core.Tup(1, "a"), core.Tup(1, "b"), core.Tup(2, "a"), core.Tup(2, "b")}, pairs)
+
+	// random inputs merge to the same result as sorting everything
+	rng := rand.New(rand.NewPCG(1, 1))
+	var (
+		all  []int
+		seqs []iter.Seq[int]
+	)
+	for range 10 {
+		s := make([]int, rng.IntN(50))
+		for i := range s {
+			s[i] = rng.IntN(100)
+		}
+		slices.Sort(s)
+		all = append(all, s...)
+		seqs = append(seqs, slices.Values(s))
+	}
+	slices.Sort(all)
+	r.Equal(all, slices.Collect(MergeSorted(less, seqs...)))
+
+	for v := range MergeSorted(less, slices.Values([]int{3}), slices.Values([]int{1})) {
+		r.Equal(1, v)
+		break
+	}
+}
@@ -0,0 +1,136 @@
+package seq
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/go-functional/core/constraints"
+)
+
+// RangeN returns a sequence of the integers from 0 up to, but not including,
+// n. The sequence is empty if n <= 0
+func RangeN(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns a sequence that starts at start and moves by step until it
+// reaches or passes end, which isn't included. step must move start toward
+// end, so it has to be positive if start < end and negative if start > end.
+// If it isn't, Range returns a nil sequence and a descriptive, non-nil error.
+// It does the same if N is a float type and any of start, end or step is NaN
+// or infinite, since the sequence would never reach end. If start == end, the
+// sequence is empty.
+//
+// Example usage:
+//
+//	evens, err := Range(0, 10, 2)
+//	// ranging over evens yields 0, 2, 4, 6, 8
+func Range[N constraints.Number](start, end, step N) (iter.Seq[N], error) {
+	if err := checkStep(start, end, step); err != nil {
+		return nil, err
+	}
+	return func(yield func(N) bool) {
+		if start == end {
+			return
+		}
+		// N(1)/N(2) is only non-zero when N is a float type
+		if N(1)/N(2) != 0 {
+			// multiplying rather than repeatedly adding keeps floating point
+			// error from accumulating
+			for i := 0; ; i++ {
+				v := start + N(i)*step
+				if (step > 0 && v >= end) || (step < 0 && v <= end) {
+					return
+				}
+				if !yield(v) {
+					return
+				}
+			}
+		}
+		// integers are exact, so adding is safe, and lets the loop notice
+		// when the next value would wrap around past the end of N, rather
+		// than yielding the wrapped value
+		for v := start; ; {
+			if !yield(v) {
+				return
+			}
+			next := v + step
+			if step > 0 && (next <= v || next >= end) {
+				return
+			}
+			if step < 0 && (next >= v || next <= end) {
+				return
+			}
+			v = next
+		}
+	}, nil
+}
+
+func checkStep[N constraints.Number](start, end, step N) error {
+	switch {
+	case !finite(start) || !finite(end) || !finite(step):
+		return errors.New("Range called with a NaN or infinite value")
+	case start == end:
+		return nil
+	case step == 0:
+		return errors.New("Range called with a zero step")
+	case start < end && step < 0:
+		return errors.New("Range called with a negative step but start is less than end")
+	case start > end && step > 0:
+		return errors.New("Range called with a positive step but start is greater than end")
+	}
+	return nil
+}
+
+// finite reports whether n is neither NaN nor infinite. That's always true
+// for integers. NaN is the only value not equal to itself, and subtracting an
+// infinity from itself gives NaN
+func finite[N constraints.Number](n N) bool {
+	return n-n == 0
+}
+
+// Linspace returns a sequence of n evenly spaced values from lo to hi,
+// including both. If n is 1, the sequence holds just lo, and if n <= 0 it's
+// empty.
+//
+// Example usage:
+//
+//	for v := range Linspace(0.0, 1.0, 5) {
+//		// v will be 0, 0.25, 0.5, 0.75 and 1
+//	}
+func Linspace[F constraints.Float](lo, hi F, n int) iter.Seq[F] {
+	return func(yield func(F) bool) {
+		if n == 1 {
+			yield(lo)
+			return
+		}
+		for i := 0; i < n; i++ {
+			v := lo + (hi-lo)*F(i)/F(n-1)
+			if i == n-1 {
+				// make sure the last value is exactly hi
+				v = hi
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Times returns a sequence of the results of calling fn with 0, 1, ... n-1.
+// fn is called lazily, one element at a time, as the sequence is ranged over
+func Times[T any](n int, fn func(i int) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < n; i++ {
+			if !yield(fn(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package seq
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]int{0, 1, 2}, slices.Collect(RangeN(3)))
+	r.Empty(slices.Collect(RangeN(-1)))
+
+	evens, err := Range(0, 10, 2)
+	r.NoError(err)
+	r.Equal([]int{0, 2, 4, 6, 8}, slices.Collect(evens))
+
+	down, err := Range(3, 0, -1)
+	r.NoError(err)
+	r.Equal([]int{3, 2, 1}, slices.Collect(down))
+
+	empty, err := Range(1, 1, 0)
+	r.NoError(err)
+	r.Empty(slices.Collect(empty))
+
+	// steps that would wrap around the end of the type stop instead
+	near, err := Range[int8](100, 127, 50)
+	r.NoError(err)
+	r.Equal([]int8{100}, slices.Collect(near))
+	full, err := Range[int8](-128, 127, 127)
+	r.NoError(err)
+	r.Equal([]int8{-128, -1, 126}, slices.Collect(full))
+	downward, err := Range[int8](-100, -128, -20)
+	r.NoError(err)
+	r.Equal([]int8{-100, -120}, slices.Collect(downward))
+	top, err := Range[uint8](250, 255, 3)
+	r.NoError(err)
+	r.Equal([]uint8{250, 253}, slices.Collect(top))
+	wrapping, err := Range[uint8](200, 255, 100)
+	r.NoError(err)
+	r.Equal([]uint8{200}, slices.Collect(wrapping))
+	halves, err := Range(0.0, 1.0, 0.25)
+	r.NoError(err)
+	r.Equal([]float64{0, 0.25, 0.5, 0.75}, slices.Collect(halves))
+
+	_, err = Range(0, 10, 0)
+	r.Error(err)
+	_, err = Range(0, 10, -1)
+	r.Error(err)
+	_, err = Range(10, 0, 1)
+	r.Error(err)
+
+	nan, inf := math.NaN(), math.Inf(1)
+	_, err = Range(0, 1, nan)
+	r.Error(err)
+	_, err = Range(nan, 1, 0.5)
+	r.Error(err)
+	_, err = Range(0, nan, 0.5)
+	r.Error(err)
+	_, err = Range(0, 1, inf)
+	r.Error(err)
+	_, err = Range(0, 1, -inf)
+	r.Error(err)
+	_, err = Range(-inf, 1, 0.5)
+	r.Error(err)
+	_, err = Range(0, inf, 0.5)
+	r.Error(err)
+}
+
+func TestLinspaceTimes(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]float64{0, 0.25, 0.5, 0.75, 1}, slices.Collect(Linspace(0.0, 1.0, 5)))
+	r.Equal([]float64{2}, slices.Collect(Linspace(2.0, 3.0, 1)))
+	r.Empty(slices.Collect(Linspace(2.0, 3.0, 0)))
+
+	calls := 0
+	squares := Times(100, func(i int) int {
+		calls++
+		return i * i
+	})
+	for v := range squares {
+		if v > 4 {
+			break
+		}
+	}
+	r.Equal(4, calls)
+}
@@ -0,0 +1,40 @@
+package seq
+
+import "iter"
+
+// Zip returns a sequence of pairs holding the first value of a and the first
+// value of b, then the second of each, and so on, stopping as soon as either
+// runs out. Both are consumed lazily, one value at a time, so neither is ever
+// materialized.
+//
+// Example usage:
+//
+//	for want, got := range Zip(textio.Lines(expected), textio.Lines(actual)) {
+//		if want != got {
+//			...
+//		}
+//	}
+func Zip[T, U any](a iter.Seq[T], b iter.Seq[U]) iter.Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+		for t := range a {
+			u, ok := next()
+			if !ok || !yield(t, u) {
+				return
+			}
+		}
+	}
+}
+
+// ZipWith returns a sequence of fn applied to the values of a and b in
+// lockstep, the same way Zip pairs them, stopping as soon as either runs out
+func ZipWith[T, U, V any](a iter.Seq[T], b iter.Seq[U], fn func(T, U) V) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for t, u := range Zip(a, b) {
+			if !yield(fn(t, u)) {
+				return
+			}
+		}
+	}
+}
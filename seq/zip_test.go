@@ -0,0 +1,41 @@
+package seq
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestZip(t *testing.T) {
+	r := require.New(t)
+	var (
+		nums []int
+		strs []string
+	)
+	for n, s := range Zip(RangeN(5), slices.Values([]string{"a", "b", "c"})) {
+		nums = append(nums, n)
+		strs = append(strs, s)
+	}
+	r.Equal([]int{0, 1, 2}, nums)
+	r.Equal([]string{"a", "b", "c"}, strs)
+
+	// stopping early stops pulling from both
+	pulled := 0
+	counting := Times(100, func(i int) int { pulled++; return i })
+	for range Zip(counting, RangeN(100)) {
+		break
+	}
+	r.Equal(1, pulled)
+
+	// an infinite sequence is fine as long as the other one ends
+	forever := func(yield func(int) bool) {
+		for i := 0; yield(i); i++ {
+		}
+	}
+	got := slices.Collect(ZipWith(forever, slices.Values([]string{"x", "y"}), func(i int, s string) string {
+		return s + strconv.Itoa(i)
+	}))
+	r.Equal([]string{"x0", "y1"}, got)
+}
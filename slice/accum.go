@@ -0,0 +1,51 @@
+package slice
+
+// MapAccum is similar to Map, except it threads a state value through every
+// call to fn, from the first element to the last. fn is passed the current
+// state and an element, and returns the next state along with the mapped
+// value. MapAccum returns the final state and the mapped slice.
+//
+// MapAccum is useful for transformations that need to remember something
+// about the elements that came before, like numbering or running totals.
+//
+// Example usage, computing running totals:
+//
+//	total, running := MapAccum([]int{1, 2, 3}, 0, func(sum int, val int) (int, int) {
+//		return sum + val, sum + val
+//	})
+//	// total will be 6 and running will be []int{1, 3, 6}
+func MapAccum[T, S, U any](slc []T, init S, fn func(S, T) (S, U)) (S, []U) {
+	state := init
+	ret := make([]U, len(slc))
+	for i, t := range slc {
+		state, ret[i] = fn(state, t)
+	}
+	return state, ret
+}
+
+// MapWriter is similar to Map, except fn can also emit any number of log
+// entries for each element. MapWriter returns the mapped slice along with
+// every log entry, in the order they were emitted.
+//
+// This gives instrumented pipeline stages somewhere to put their logs or
+// metrics without having to close over a shared slice.
+//
+// Example usage:
+//
+//	parsed, warnings := MapWriter(strs, func(s string) (int, []string) {
+//		i, err := strconv.Atoi(s)
+//		if err != nil {
+//			return 0, []string{fmt.Sprintf("couldn't parse %q, using 0", s)}
+//		}
+//		return i, nil
+//	})
+func MapWriter[T, U, L any](slc []T, fn func(T) (U, []L)) ([]U, []L) {
+	ret := make([]U, len(slc))
+	var logs []L
+	for i, t := range slc {
+		u, l := fn(t)
+		ret[i] = u
+		logs = append(logs, l...)
+	}
+	return ret, logs
+}
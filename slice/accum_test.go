@@ -0,0 +1,37 @@
+package slice
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapAccum(t *testing.T) {
+	r := require.New(t)
+	total, running := MapAccum([]int{1, 2, 3}, 0, func(sum int, val int) (int, int) {
+		return sum + val, sum + val
+	})
+	r.Equal(6, total)
+	r.Equal([]int{1, 3, 6}, running)
+
+	seen, deduped := MapAccum([]string{"a", "b", "a"}, map[string]bool{}, func(seen map[string]bool, s string) (map[string]bool, bool) {
+		first := !seen[s]
+		seen[s] = true
+		return seen, first
+	})
+	r.Len(seen, 2)
+	r.Equal([]bool{true, true, false}, deduped)
+}
+
+func TestMapWriter(t *testing.T) {
+	r := require.New(t)
+	doubled, logs := MapWriter([]int{1, 2, 3}, func(i int) (int, []string) {
+		if i%2 == 0 {
+			return i * 2, []string{"even", strconv.Itoa(i)}
+		}
+		return i * 2, nil
+	})
+	r.Equal([]int{2, 4, 6}, doubled)
+	r.Equal([]string{"even", "2"}, logs)
+}
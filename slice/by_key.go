@@ -0,0 +1,66 @@
+package slice
+
+import (
+	"context"
+
+	"github.com/go-functional/core/par"
+)
+
+// ParMapByKey is similar to ParMap, except elements with the same key, from
+// keyFn, are never processed at the same time: fn is called for them one at
+// a time, in the order they appear in slc, while elements with different keys
+// are processed in parallel. The results are in the same order as slc.
+//
+// It's for when fn touches state that belongs to each key, like a per-user
+// counter or a per-account ledger, or when the order of updates matters per
+// entity but not across them. Pass par.Limit to cap how many keys are
+// processed at once. Like ParMap, the first error cancels the context passed
+// to fn, and is returned with a nil slice.
+//
+// Example usage:
+//
+//	balances, err := ParMapByKey(ctx, txns, func(t Txn) string { return t.Account },
+//		func(ctx context.Context, _ uint, t Txn) (Balance, error) {
+//			return ledger.Apply(ctx, t)
+//		}, par.Limit(16))
+func ParMapByKey[T any, K comparable, U any](
+	ctx context.Context,
+	slc []T,
+	keyFn func(T) K,
+	fn func(context.Context, uint, T) (U, error),
+	opts ...par.Option,
+) ([]U, error) {
+	// groups holds the indices of every element with each key, in order, and
+	// the groups themselves are in the order their keys first appear
+	var groups [][]int
+	byKey := map[K]int{}
+	for i, t := range slc {
+		k := keyFn(t)
+		g, ok := byKey[k]
+		if !ok {
+			g = len(groups)
+			byKey[k] = g
+			groups = append(groups, nil)
+		}
+		groups[g] = append(groups[g], i)
+	}
+
+	ret := make([]U, len(slc))
+	err := par.Do(ctx, len(groups), func(ctx context.Context, g int) error {
+		for _, i := range groups[g] {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			u, err := fn(ctx, uint(i), slc[i])
+			if err != nil {
+				return err
+			}
+			ret[i] = u
+		}
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -0,0 +1,66 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParMapByKey(t *testing.T) {
+	r := require.New(t)
+	type txn struct {
+		Account string
+		Amount  int
+	}
+	var txns []txn
+	for i := range 60 {
+		txns = append(txns, txn{Account: string(rune('a' + i%3)), Amount: i})
+	}
+
+	for _, opts := range [][]par.Option{nil, {par.Limit(2)}} {
+		var (
+			mut      sync.Mutex
+			balances = map[string]int{}
+			inFlight = map[string]bool{}
+		)
+		out, err := ParMapByKey(context.Background(), txns, func(t txn) string { return t.Account },
+			func(_ context.Context, _ uint, t txn) (int, error) {
+				mut.Lock()
+				r.False(inFlight[t.Account], "two calls for %s at once", t.Account)
+				inFlight[t.Account] = true
+				mut.Unlock()
+
+				time.Sleep(100 * time.Microsecond)
+
+				mut.Lock()
+				defer mut.Unlock()
+				inFlight[t.Account] = false
+				balances[t.Account] += t.Amount
+				return balances[t.Account], nil
+			}, opts...)
+		r.NoError(err)
+		r.Len(out, len(txns))
+		// per-key order is preserved, so every result is the running total
+		running := map[string]int{}
+		for i, tx := range txns {
+			running[tx.Account] += tx.Amount
+			r.Equal(running[tx.Account], out[i])
+		}
+	}
+
+	bad := errors.New("bad")
+	out, err := ParMapByKey(context.Background(), txns, func(t txn) string { return t.Account },
+		func(_ context.Context, i uint, _ txn) (int, error) {
+			if i == 10 {
+				return 0, bad
+			}
+			return 0, nil
+		})
+	r.ErrorIs(err, bad)
+	r.Nil(out)
+}
@@ -0,0 +1,50 @@
+package slice
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-functional/core/par"
+)
+
+// ParMapChunks splits slc into consecutive chunks of chunkSize elements, the
+// last of which may be shorter, and calls fn once per chunk, in parallel,
+// like ParMap. The slices fn returns are concatenated in chunk order, so if
+// fn returns one result per element, the results line up with slc. fn may
+// also return more or fewer, for bulk APIs that filter or expand.
+//
+// It's for APIs with bulk endpoints, where one call per element would waste
+// round trips. Each chunk shares slc's backing array, so fn mustn't modify
+// it. Returns nil and a descriptive, non-nil error if chunkSize <= 0, and
+// otherwise has the same error semantics as ParMap.
+//
+// Example usage:
+//
+//	users, err := ParMapChunks(ctx, ids, 100, func(ctx context.Context, ids []string) ([]User, error) {
+//		return api.BatchGetUsers(ctx, ids)
+//	}, par.Limit(4))
+func ParMapChunks[T, U any](
+	ctx context.Context,
+	slc []T,
+	chunkSize int,
+	fn func(context.Context, []T) ([]U, error),
+	opts ...par.Option,
+) ([]U, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("ParMapChunks called with a chunkSize <= 0")
+	}
+	// written so a chunkSize near math.MaxInt can't overflow
+	n := len(slc) / chunkSize
+	if len(slc)%chunkSize != 0 {
+		n++
+	}
+	chunks := make([][]T, 0, n)
+	for lo := 0; lo < len(slc); lo += chunkSize {
+		hi := lo + min(chunkSize, len(slc)-lo)
+		// capping the capacity stops fn from appending into the next chunk
+		chunks = append(chunks, slc[lo:hi:hi])
+	}
+	return ParMapConcat(ctx, chunks, func(ctx context.Context, _ uint, chunk []T) ([]U, error) {
+		return fn(ctx, chunk)
+	}, opts...)
+}
@@ -0,0 +1,65 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParMapChunks(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	in := make([]int, 25)
+	for i := range in {
+		in[i] = i
+	}
+	var calls atomic.Int32
+	out, err := ParMapChunks(ctx, in, 10, func(_ context.Context, chunk []int) ([]string, error) {
+		calls.Add(1)
+		r.LessOrEqual(len(chunk), 10)
+		ret := make([]string, len(chunk))
+		for i, v := range chunk {
+			ret[i] = strconv.Itoa(v)
+		}
+		return ret, nil
+	}, par.Limit(2))
+	r.NoError(err)
+	r.Equal(int32(3), calls.Load())
+	r.Len(out, 25)
+	for i, s := range out {
+		r.Equal(strconv.Itoa(i), s)
+	}
+
+	// results don't have to line up with the input
+	evens, err := ParMapChunks(ctx, in, 4, func(_ context.Context, chunk []int) ([]int, error) {
+		return FilterInto(nil, chunk, func(v int) bool { return v%2 == 0 }), nil
+	})
+	r.NoError(err)
+	r.Equal([]int{0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24}, evens)
+
+	// a huge chunkSize is one chunk holding everything
+	whole, err := ParMapChunks(ctx, in, math.MaxInt, func(_ context.Context, chunk []int) ([]int, error) {
+		return []int{len(chunk)}, nil
+	})
+	r.NoError(err)
+	r.Equal([]int{len(in)}, whole)
+
+	_, err = ParMapChunks(ctx, in, 0, func(context.Context, []int) ([]int, error) { return nil, nil })
+	r.Error(err)
+
+	bad := errors.New("bad")
+	out2, err := ParMapChunks(ctx, in, 5, func(_ context.Context, chunk []int) ([]int, error) {
+		if chunk[0] == 10 {
+			return nil, bad
+		}
+		return chunk, nil
+	})
+	r.ErrorIs(err, bad)
+	r.Nil(out2)
+}
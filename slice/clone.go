@@ -0,0 +1,34 @@
+package slice
+
+// Clone returns a shallow copy of slc: a new slice with the same elements,
+// so appending to or setting elements of one doesn't affect the other.
+// Elements that hold pointers, slices or maps still share what they point
+// to; use CloneBy to copy those too. Returns nil if slc is nil
+func Clone[T any](slc []T) []T {
+	if slc == nil {
+		return nil
+	}
+	return append(make([]T, 0, len(slc)), slc...)
+}
+
+// CloneBy returns a new slice holding cloneElem applied to every element of
+// slc, for a deep copy that shares nothing with slc. Use it to hand data to
+// the parallel combinators when elements hold pointers that fn might write
+// through. Returns nil if slc is nil.
+//
+// Example usage:
+//
+//	rows := CloneBy(shared, func(r Row) Row {
+//		r.Tags = Clone(r.Tags)
+//		return r
+//	})
+func CloneBy[T any](slc []T, cloneElem func(T) T) []T {
+	if slc == nil {
+		return nil
+	}
+	ret := make([]T, len(slc))
+	for i, t := range slc {
+		ret[i] = cloneElem(t)
+	}
+	return ret
+}
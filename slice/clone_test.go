@@ -0,0 +1,28 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	r := require.New(t)
+	in := []int{1, 2, 3}
+	out := Clone(in)
+	r.Equal(in, out)
+	out[0] = 9
+	r.Equal(1, in[0])
+	r.Nil(Clone([]int(nil)))
+	r.NotNil(Clone([]int{}))
+}
+
+func TestCloneBy(t *testing.T) {
+	r := require.New(t)
+	in := [][]int{{1}, {2, 3}}
+	out := CloneBy(in, Clone[int])
+	r.Equal(in, out)
+	out[1][0] = 9
+	r.Equal(2, in[1][0])
+	r.Nil(CloneBy([][]int(nil), Clone[int]))
+}
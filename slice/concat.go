@@ -0,0 +1,53 @@
+package slice
+
+import (
+	"context"
+
+	"github.com/go-functional/core/par"
+)
+
+// MapConcat is the Map counterpart of FlatMap: it calls fn with the index
+// and value of every element of slc, and concatenates the slices fn returns,
+// in order. If fn returns a non-nil error, MapConcat returns immediately with
+// (nil, <the_error>).
+//
+// Example usage, expanding every page number into the records on that page:
+//
+//	records, err := MapConcat(pages, func(_ uint, page int) ([]Record, error) {
+//		return fetchPage(page)
+//	})
+func MapConcat[T, U any](slc []T, fn func(uint, T) ([]U, error)) ([]U, error) {
+	var ret []U
+	for i, t := range slc {
+		us, err := fn(uint(i), t)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, us...)
+	}
+	return ret, nil
+}
+
+// ParMapConcat is the same as MapConcat, except fn is called in parallel,
+// like ParMap. The results are still concatenated in element order, into a
+// single slice allocated once every call has returned
+func ParMapConcat[T, U any](
+	ctx context.Context,
+	slc []T,
+	fn func(context.Context, uint, T) ([]U, error),
+	opts ...par.Option,
+) ([]U, error) {
+	parts, err := ParMap(ctx, slc, fn, opts...)
+	if err != nil {
+		return nil, err
+	}
+	n := 0
+	for _, p := range parts {
+		n += len(p)
+	}
+	ret := make([]U, 0, n)
+	for _, p := range parts {
+		ret = append(ret, p...)
+	}
+	return ret, nil
+}
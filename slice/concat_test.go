@@ -0,0 +1,41 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapConcat(t *testing.T) {
+	r := require.New(t)
+	repeat := func(_ uint, n int) ([]int, error) {
+		return Times(n, func(int) int { return n }), nil
+	}
+	out, err := MapConcat([]int{1, 0, 3}, repeat)
+	r.NoError(err)
+	r.Equal([]int{1, 3, 3, 3}, out)
+
+	boom := errors.New("boom")
+	out, err = MapConcat([]int{1}, func(uint, int) ([]int, error) { return nil, boom })
+	r.ErrorIs(err, boom)
+	r.Nil(out)
+}
+
+func TestParMapConcat(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	repeat := func(_ context.Context, _ uint, n int) ([]int, error) {
+		return Times(n, func(int) int { return n }), nil
+	}
+	out, err := ParMapConcat(ctx, RangeN(6), repeat, par.Limit(2))
+	r.NoError(err)
+	r.Equal([]int{1, 2, 2, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 5}, out)
+	r.Equal(len(out), cap(out))
+
+	boom := errors.New("boom")
+	_, err = ParMapConcat(ctx, []int{1}, func(context.Context, uint, int) ([]int, error) { return nil, boom })
+	r.ErrorIs(err, boom)
+}
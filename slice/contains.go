@@ -0,0 +1,55 @@
+package slice
+
+// Contains returns true if any element of slc equals t
+func Contains[T comparable](slc []T, t T) bool {
+	return IndexOf(slc, t) >= 0
+}
+
+// ContainsBy returns true if pred returns true for any element of slc
+func ContainsBy[T any](slc []T, pred func(T) bool) bool {
+	return IndexBy(slc, pred) >= 0
+}
+
+// ContainsAll returns true if every element of subset is also in slc. It
+// returns true for an empty subset. It builds a set of slc, so it takes
+// O(len(slc) + len(subset)) time rather than checking every pair.
+//
+// Example usage:
+//
+//	ContainsAll(granted, []string{"read", "write"})
+func ContainsAll[T comparable](slc, subset []T) bool {
+	if len(subset) == 0 {
+		return true
+	}
+	set := toSet(slc)
+	for _, t := range subset {
+		if _, ok := set[t]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsAny returns true if any element of candidates is also in slc. It
+// returns false for empty candidates, and runs in O(len(slc) +
+// len(candidates)) time
+func ContainsAny[T comparable](slc, candidates []T) bool {
+	if len(candidates) == 0 || len(slc) == 0 {
+		return false
+	}
+	set := toSet(candidates)
+	for _, t := range slc {
+		if _, ok := set[t]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func toSet[T comparable](slc []T) map[T]struct{} {
+	set := make(map[T]struct{}, len(slc))
+	for _, t := range slc {
+		set[t] = struct{}{}
+	}
+	return set
+}
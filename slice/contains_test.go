@@ -0,0 +1,31 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContains(t *testing.T) {
+	r := require.New(t)
+	r.True(Contains([]int{1, 2}, 2))
+	r.False(Contains([]int{1, 2}, 3))
+	r.False(Contains(nil, 3))
+	r.True(ContainsBy([]string{"a", "bb"}, func(s string) bool { return len(s) == 2 }))
+	r.False(ContainsBy([]string{"a"}, func(s string) bool { return len(s) == 2 }))
+}
+
+func TestContainsAllAny(t *testing.T) {
+	r := require.New(t)
+	slc := []string{"read", "write", "admin"}
+	r.True(ContainsAll(slc, []string{"write", "read", "read"}))
+	r.False(ContainsAll(slc, []string{"read", "delete"}))
+	r.True(ContainsAll(slc, nil))
+	r.True(ContainsAll[string](nil, nil))
+	r.False(ContainsAll(nil, []string{"read"}))
+
+	r.True(ContainsAny(slc, []string{"delete", "admin"}))
+	r.False(ContainsAny(slc, []string{"delete"}))
+	r.False(ContainsAny(slc, nil))
+	r.False(ContainsAny(nil, []string{"read"}))
+}
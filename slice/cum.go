@@ -0,0 +1,32 @@
+package slice
+
+import "github.com/go-functional/core/constraints"
+
+// CumSum returns the running totals of slc: element i of the result is the
+// sum of slc[0] through slc[i].
+//
+// Example usage:
+//
+//	CumSum([]int{1, 2, 3}) // [1, 3, 6]
+func CumSum[N constraints.Number](slc []N) []N {
+	return CumBy(slc, func(acc, n N) N { return acc + n })
+}
+
+// CumBy returns the running aggregates of slc under fn: element 0 of the
+// result is slc[0], and element i is fn applied to element i-1 of the result
+// and slc[i]. It's MapAccum for the common case where the state and the
+// output are the same, and start with the first element.
+//
+// Example usage, a running maximum:
+//
+//	CumBy([]int{2, 1, 4, 3}, func(acc, i int) int { return max(acc, i) }) // [2, 2, 4, 4]
+func CumBy[T any](slc []T, fn func(acc, t T) T) []T {
+	if len(slc) == 0 {
+		return []T{}
+	}
+	_, rest := MapAccum(slc[1:], slc[0], func(acc, t T) (T, T) {
+		next := fn(acc, t)
+		return next, next
+	})
+	return Cons(slc[0], rest)
+}
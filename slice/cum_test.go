@@ -0,0 +1,24 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCumSum(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]int{1, 3, 6}, CumSum([]int{1, 2, 3}))
+	r.Equal([]float64{0.5, 0.75}, CumSum([]float64{0.5, 0.25}))
+	r.Empty(CumSum([]int(nil)))
+}
+
+func TestCumBy(t *testing.T) {
+	r := require.New(t)
+	runningMax := CumBy([]int{2, 1, 4, 3}, func(acc, i int) int { return max(acc, i) })
+	r.Equal([]int{2, 2, 4, 4}, runningMax)
+	r.Equal([]string{"a", "ab", "abc"}, CumBy([]string{"a", "b", "c"}, func(acc, s string) string {
+		return acc + s
+	}))
+	r.Equal([]int{7}, CumBy([]int{7}, func(acc, i int) int { return acc + i }))
+}
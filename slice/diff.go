@@ -0,0 +1,199 @@
+package slice
+
+// EditOp is the kind of change an Edit describes
+type EditOp int
+
+const (
+	// Keep means the element is in both the old and new slices
+	Keep EditOp = iota
+	// Insert means the element is only in the new slice
+	Insert
+	// Delete means the element is only in the old slice
+	Delete
+)
+
+func (o EditOp) String() string {
+	switch o {
+	case Keep:
+		return "keep"
+	case Insert:
+		return "insert"
+	case Delete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Edit is a single step in the changeset that Diff returns
+type Edit[T any] struct {
+	Op    EditOp
+	Value T
+}
+
+// Diff returns a minimal list of edits that turns old into new. Applying the
+// edits in order, keeping Keep elements, dropping Delete elements and adding
+// Insert elements, produces new.
+//
+// Diff uses the linear space variant of Myers' algorithm, which runs in
+// O((n+m)d) time and O(n+m) space, where d is the
+// number of inserts and deletes. It's very fast when the slices are similar.
+//
+// Example usage:
+//
+//	edits := Diff([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+//	// edits will be [keep a, delete b, keep c, insert d]
+func Diff[T comparable](old, new []T) []Edit[T] {
+	return DiffFunc(old, new, func(a, b T) bool { return a == b })
+}
+
+// DiffFunc is the same as Diff, except it uses eq to decide whether two
+// elements are equal
+func DiffFunc[T any](old, new []T, eq func(T, T) bool) []Edit[T] {
+	n, m := len(old), len(new)
+	// the middle snake search never looks past diagonal (n+m+1)/2 in either
+	// direction, so both vectors can be shared by every level of recursion
+	size := 2*((n+m+1)/2) + 3
+	d := differ[T]{
+		old:   old,
+		new:   new,
+		eq:    eq,
+		vf:    make([]int, size),
+		vb:    make([]int, size),
+		edits: make([]Edit[T], 0, max(n, m)),
+	}
+	d.diff(0, n, 0, m)
+	return d.edits
+}
+
+// differ holds the state for the linear space variant of Myers' algorithm,
+// which finds the middle snake of the edit path, then recurses on the
+// halves either side of it. This keeps memory at O(n+m) rather than
+// O((n+m)d).
+type differ[T any] struct {
+	old, new []T
+	eq       func(T, T) bool
+	// vf[k+off] and vb[k+off] hold the furthest x reached on diagonal k by
+	// the forward and backward searches, where the diagonal of a point
+	// (x, y) is x-y
+	vf, vb []int
+	edits  []Edit[T]
+}
+
+// diff appends the edits that turn old[a0:a1] into new[b0:b1]
+func (d *differ[T]) diff(a0, a1, b0, b1 int) {
+	for a0 < a1 && b0 < b1 && d.eq(d.old[a0], d.new[b0]) {
+		d.edits = append(d.edits, Edit[T]{Op: Keep, Value: d.old[a0]})
+		a0++
+		b0++
+	}
+	var suffix int
+	for a0 < a1-suffix && b0 < b1-suffix &&
+		d.eq(d.old[a1-suffix-1], d.new[b1-suffix-1]) {
+		suffix++
+	}
+	a1 -= suffix
+	b1 -= suffix
+
+	switch {
+	case a0 == a1:
+		for ; b0 < b1; b0++ {
+			d.edits = append(d.edits, Edit[T]{Op: Insert, Value: d.new[b0]})
+		}
+	case b0 == b1:
+		for ; a0 < a1; a0++ {
+			d.edits = append(d.edits, Edit[T]{Op: Delete, Value: d.old[a0]})
+		}
+	default:
+		// with the common prefix and suffix gone and both sides non-empty,
+		// at least two edits are left, so the middle snake always splits
+		// this into two strictly smaller problems
+		x, y, u, v := d.middleSnake(a0, a1, b0, b1)
+		d.diff(a0, x, b0, y)
+		for ; x < u; x++ {
+			d.edits = append(d.edits, Edit[T]{Op: Keep, Value: d.old[x]})
+		}
+		d.diff(u, a1, v, b1)
+	}
+
+	for i := suffix; i > 0; i-- {
+		d.edits = append(d.edits, Edit[T]{Op: Keep, Value: d.old[a1+suffix-i]})
+	}
+}
+
+// middleSnake runs the forward and backward searches over old[a0:a1] and
+// new[b0:b1] at the same time until they overlap, and returns the start
+// (x, y) and end (u, v) of the snake where they met
+func (d *differ[T]) middleSnake(a0, a1, b0, b1 int) (x, y, u, v int) {
+	n, m := a1-a0, b1-b0
+	delta := n - m
+	odd := delta&1 != 0
+	maxD := (n + m + 1) / 2
+	off := maxD + 1
+	vf, vb := d.vf, d.vb
+	vf[off+1], vb[off+1] = 0, 0
+
+	for dd := 0; dd <= maxD; dd++ {
+		for k := -dd; k <= dd; k += 2 {
+			var x int
+			if k == -dd || (k != dd && vf[off+k-1] < vf[off+k+1]) {
+				x = vf[off+k+1]
+			} else {
+				x = vf[off+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.eq(d.old[a0+x], d.new[b0+y]) {
+				x++
+				y++
+			}
+			vf[off+k] = x
+			// the backward search is on diagonal delta-k of the reversed
+			// slices, and is one step behind when delta is odd
+			if rk := delta - k; odd && rk >= -(dd-1) && rk <= dd-1 {
+				if x+vb[off+rk] >= n {
+					return a0 + sx, b0 + sy, a0 + x, b0 + y
+				}
+			}
+		}
+		for k := -dd; k <= dd; k += 2 {
+			var x int
+			if k == -dd || (k != dd && vb[off+k-1] < vb[off+k+1]) {
+				x = vb[off+k+1]
+			} else {
+				x = vb[off+k-1] + 1
+			}
+			y := x - k
+			sx, sy := x, y
+			for x < n && y < m && d.eq(d.old[a1-1-x], d.new[b1-1-y]) {
+				x++
+				y++
+			}
+			vb[off+k] = x
+			if fk := delta - k; !odd && fk >= -dd && fk <= dd {
+				if x+vf[off+fk] >= n {
+					return a1 - x, b1 - y, a1 - sx, b1 - sy
+				}
+			}
+		}
+	}
+	// unreachable, since the searches always meet by maxD
+	return a0, b0, a1, b1
+}
+
+// LCS returns a longest common subsequence of a and b. That is, the longest
+// slice whose elements appear in both a and b in the same order, though not
+// necessarily next to each other.
+//
+// Example usage:
+//
+//	common := LCS([]int{1, 2, 3, 4}, []int{2, 4, 5})
+//	// common will be []int{2, 4}
+func LCS[T comparable](a, b []T) []T {
+	var ret []T
+	for _, e := range Diff(a, b) {
+		if e.Op == Keep {
+			ret = append(ret, e.Value)
+		}
+	}
+	return ret
+}
@@ -0,0 +1,85 @@
+package slice
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	r := require.New(t)
+	edits := Diff([]string{"a", "b", "c"}, []string{"a", "c", "d"})
+	r.Equal([]Edit[string]{
+		{Op: Keep, Value: "a"},
+		{Op: Delete, Value: "b"},
+		{Op: Keep, Value: "c"},
+		{Op: Insert, Value: "d"},
+	}, edits)
+	r.Empty(Diff[int](nil, nil))
+	r.Equal([]int{2, 4}, LCS([]int{1, 2, 3, 4}, []int{2, 4, 5}))
+}
+
+func TestDiffRandom(t *testing.T) {
+	r := require.New(t)
+	rnd := rand.New(rand.NewSource(1))
+	randSlc := func() []int {
+		ret := make([]int, rnd.Intn(20))
+		for i := range ret {
+			ret[i] = rnd.Intn(4)
+		}
+		return ret
+	}
+	for i := 0; i < 200; i++ {
+		a, b := randSlc(), randSlc()
+		var gotOld, gotNew []int
+		for _, e := range Diff(a, b) {
+			if e.Op != Insert {
+				gotOld = append(gotOld, e.Value)
+			}
+			if e.Op != Delete {
+				gotNew = append(gotNew, e.Value)
+			}
+		}
+		r.True(slices.Equal(a, gotOld), "old: %v, got: %v", a, gotOld)
+		r.True(slices.Equal(b, gotNew), "new: %v, got: %v", b, gotNew)
+		r.Equal(lcsLen(a, b), len(LCS(a, b)))
+	}
+}
+
+// lcsLen computes the length of the LCS with the textbook dynamic
+// programming algorithm, to check that Diff is minimal
+func lcsLen(a, b []int) int {
+	dp := make([][]int, len(a)+1)
+	for i := range dp {
+		dp[i] = make([]int, len(b)+1)
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			if a[i-1] == b[j-1] {
+				dp[i][j] = dp[i-1][j-1] + 1
+			} else {
+				dp[i][j] = max(dp[i-1][j], dp[i][j-1])
+			}
+		}
+	}
+	return dp[len(a)][len(b)]
+}
+
+func TestDiffLargeDisjoint(t *testing.T) {
+	r := require.New(t)
+	// keeping a copy of the search state per step would need gigabytes here
+	const n = 5000
+	a, b := make([]int, n), make([]int, n)
+	for i := range a {
+		a[i] = i
+		b[i] = n + i
+	}
+	edits := Diff(a, b)
+	r.Len(edits, 2*n)
+	for _, e := range edits {
+		r.NotEqual(Keep, e.Op)
+	}
+	r.Empty(LCS(a, b))
+}
@@ -0,0 +1,53 @@
+package slice
+
+// EditCosts holds the cost of each kind of edit for EditDistanceCosts
+type EditCosts struct {
+	Insert     int
+	Delete     int
+	Substitute int
+}
+
+// EditDistance returns the Levenshtein distance between a and b. That is, the
+// minimum number of single-element insertions, deletions and substitutions
+// needed to turn a into b.
+//
+// It works for any comparable element type, so you can use it on []rune for
+// character-level distance, or on []string for token-level distance.
+//
+// Example usage:
+//
+//	d := EditDistance([]rune("kitten"), []rune("sitting"))
+//	// d will be 3
+func EditDistance[T comparable](a, b []T) int {
+	return EditDistanceCosts(a, b, EditCosts{Insert: 1, Delete: 1, Substitute: 1})
+}
+
+// EditDistanceCosts is the same as EditDistance, except each kind of edit
+// costs what costs says it does rather than 1. For example, setting
+// costs.Substitute to 2 or more means substitutions are never cheaper than a
+// deletion followed by an insertion.
+//
+// EditDistanceCosts runs in O(len(a)*len(b)) time and O(len(b)) space
+func EditDistanceCosts[T comparable](a, b []T, costs EditCosts) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j * costs.Insert
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i * costs.Delete
+		for j := 1; j <= len(b); j++ {
+			sub := prev[j-1]
+			if a[i-1] != b[j-1] {
+				sub += costs.Substitute
+			}
+			cur[j] = min(
+				sub,
+				prev[j]+costs.Delete,
+				cur[j-1]+costs.Insert,
+			)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,19 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEditDistance(t *testing.T) {
+	r := require.New(t)
+	r.Equal(3, EditDistance([]rune("kitten"), []rune("sitting")))
+	r.Equal(0, EditDistance([]rune(""), []rune("")))
+	r.Equal(4, EditDistance([]rune(""), []rune("abcd")))
+	r.Equal(1, EditDistance([]string{"go", "is", "fun"}, []string{"go", "fun"}))
+
+	costs := EditCosts{Insert: 1, Delete: 1, Substitute: 2}
+	r.Equal(2, EditDistanceCosts([]rune("a"), []rune("b"), costs))
+	r.Equal(3, EditDistanceCosts([]rune("abc"), []rune(""), costs))
+}
@@ -0,0 +1,19 @@
+package slice
+
+import core "github.com/go-functional/core"
+
+// Enumerate returns a slice of Tuples pairing the index of every element of
+// slc with the element itself, so the index can travel with the value
+// through combinators that only see values, like a filter or a sort.
+//
+// Example usage:
+//
+//	pairs := Enumerate([]string{"a", "b"})
+//	// core.First(pairs[1]) == 1, core.Second(pairs[1]) == "b"
+func Enumerate[T any](slc []T) []core.Tuple[int, T] {
+	ret := make([]core.Tuple[int, T], len(slc))
+	for i, t := range slc {
+		ret[i] = core.Tup(i, t)
+	}
+	return ret
+}
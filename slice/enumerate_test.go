@@ -0,0 +1,15 @@
+package slice
+
+import (
+	"testing"
+
+	core "github.com/go-functional/core"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumerate(t *testing.T) {
+	r := require.New(t)
+	pairs := Enumerate([]string{"a", "b"})
+	r.Equal([]core.Tuple[int, string]{core.Tup(0, "a"), core.Tup(1, "b")}, pairs)
+	r.Empty(Enumerate([]int(nil)))
+}
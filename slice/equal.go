@@ -0,0 +1,60 @@
+package slice
+
+// EqualBy returns true if a and b have the same length, and eq returns true
+// for every pair of elements at the same index
+func EqualBy[T any](a, b []T, eq func(T, T) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !eq(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// ElementsMatch returns true if a and b have exactly the same elements,
+// including the same number of duplicates, in any order.
+//
+// Example usage:
+//
+//	ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}) // true
+//	ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2}) // false
+func ElementsMatch[T comparable](a, b []T) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := make(map[T]int, len(a))
+	for _, t := range a {
+		counts[t]++
+	}
+	for _, t := range b {
+		if counts[t] == 0 {
+			return false
+		}
+		counts[t]--
+	}
+	return true
+}
+
+// SetEqual returns true if every element of a is in b and every element of b
+// is in a. Order and duplicates are ignored.
+//
+// Example usage:
+//
+//	SetEqual([]int{1, 2, 2}, []int{2, 1}) // true
+func SetEqual[T comparable](a, b []T) bool {
+	inA := make(map[T]struct{}, len(a))
+	for _, t := range a {
+		inA[t] = struct{}{}
+	}
+	inB := make(map[T]struct{}, len(b))
+	for _, t := range b {
+		if _, ok := inA[t]; !ok {
+			return false
+		}
+		inB[t] = struct{}{}
+	}
+	return len(inA) == len(inB)
+}
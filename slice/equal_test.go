@@ -0,0 +1,30 @@
+package slice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEqualBy(t *testing.T) {
+	r := require.New(t)
+	r.True(EqualBy([]string{"A", "b"}, []string{"a", "B"}, strings.EqualFold))
+	r.False(EqualBy([]string{"a"}, []string{"a", "b"}, strings.EqualFold))
+	r.False(EqualBy([]string{"a"}, []string{"c"}, strings.EqualFold))
+}
+
+func TestElementsMatch(t *testing.T) {
+	r := require.New(t)
+	r.True(ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}))
+	r.False(ElementsMatch([]int{1, 2, 2}, []int{1, 1, 2}))
+	r.False(ElementsMatch([]int{1}, []int{1, 1}))
+	r.True(ElementsMatch[int](nil, []int{}))
+}
+
+func TestSetEqual(t *testing.T) {
+	r := require.New(t)
+	r.True(SetEqual([]int{1, 2, 2}, []int{2, 1}))
+	r.False(SetEqual([]int{1, 2}, []int{1}))
+	r.False(SetEqual([]int{1}, []int{1, 2}))
+}
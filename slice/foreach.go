@@ -0,0 +1,49 @@
+package slice
+
+// ForEach calls fn with every element of slc, in order, for its side effects
+func ForEach[T any](slc []T, fn func(T)) {
+	for _, t := range slc {
+		fn(t)
+	}
+}
+
+// ForEachIdx is the same as ForEach, except fn is also passed the index of
+// each element
+func ForEachIdx[T any](slc []T, fn func(uint, T)) {
+	for i, t := range slc {
+		fn(uint(i), t)
+	}
+}
+
+// ForEachWhile calls fn with every element of slc, in order, until fn
+// returns false. Returns true if fn was called with every element, and false
+// if it stopped early.
+//
+// Example usage:
+//
+//	ForEachWhile(lines, func(line string) bool {
+//		if line == "" {
+//			return false
+//		}
+//		fmt.Println(line)
+//		return true
+//	})
+func ForEachWhile[T any](slc []T, fn func(T) bool) bool {
+	for _, t := range slc {
+		if !fn(t) {
+			return false
+		}
+	}
+	return true
+}
+
+// ForEachWhileIdx is the same as ForEachWhile, except fn is also passed the
+// index of each element
+func ForEachWhileIdx[T any](slc []T, fn func(uint, T) bool) bool {
+	for i, t := range slc {
+		if !fn(uint(i), t) {
+			return false
+		}
+	}
+	return true
+}
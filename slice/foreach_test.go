@@ -0,0 +1,38 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEach(t *testing.T) {
+	r := require.New(t)
+	sum := 0
+	ForEach([]int{1, 2, 3}, func(i int) { sum += i })
+	r.Equal(6, sum)
+
+	var idxs []uint
+	ForEachIdx([]string{"a", "b"}, func(i uint, _ string) { idxs = append(idxs, i) })
+	r.Equal([]uint{0, 1}, idxs)
+}
+
+func TestForEachWhile(t *testing.T) {
+	r := require.New(t)
+	var seen []int
+	r.False(ForEachWhile([]int{1, 2, 3, 4}, func(i int) bool {
+		seen = append(seen, i)
+		return i < 2
+	}))
+	r.Equal([]int{1, 2}, seen)
+	r.True(ForEachWhile([]int{1, 2}, func(int) bool { return true }))
+	r.True(ForEachWhile(nil, func(int) bool { return false }))
+
+	var last uint
+	r.False(ForEachWhileIdx([]string{"a", "b", "c"}, func(i uint, s string) bool {
+		last = i
+		return s != "b"
+	}))
+	r.Equal(uint(1), last)
+	r.True(ForEachWhileIdx([]string{"a"}, func(uint, string) bool { return true }))
+}
@@ -0,0 +1,35 @@
+package slice
+
+// Tabulate is an alias of Times, under the name functional languages usually
+// give it: it returns a slice of length n where the element at index i is
+// fn(i). It behaves exactly like Times, so use whichever reads better.
+//
+// Example usage:
+//
+//	users := Tabulate(3, func(i int) User {
+//		return User{ID: i, Name: fmt.Sprintf("user-%d", i)}
+//	})
+func Tabulate[T any](n int, fn func(i int) T) []T {
+	return Times(n, fn)
+}
+
+// GenerateWhile calls fn repeatedly, collecting the values it returns, until
+// it returns false. The value returned alongside false isn't included.
+//
+// Example usage, draining a paginated API, where ok is true whenever Next
+// returned a page, including the last one:
+//
+//	pages := GenerateWhile(func() (Page, bool) {
+//		page, ok := client.Next()
+//		return page, ok
+//	})
+func GenerateWhile[T any](fn func() (T, bool)) []T {
+	var ret []T
+	for {
+		t, ok := fn()
+		if !ok {
+			return ret
+		}
+		ret = append(ret, t)
+	}
+}
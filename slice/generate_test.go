@@ -0,0 +1,29 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTabulate(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]string{"", "a", "aa"}, Tabulate(3, func(i int) string {
+		ret := ""
+		for j := 0; j < i; j++ {
+			ret += "a"
+		}
+		return ret
+	}))
+	r.Empty(Tabulate(0, func(int) int { return 1 }))
+}
+
+func TestGenerateWhile(t *testing.T) {
+	r := require.New(t)
+	n := 0
+	r.Equal([]int{1, 2, 3}, GenerateWhile(func() (int, bool) {
+		n++
+		return n, n <= 3
+	}))
+	r.Nil(GenerateWhile(func() (int, bool) { return 0, false }))
+}
@@ -0,0 +1,31 @@
+package slice
+
+// GroupBy returns a map from every key that key returns for an element of
+// slc to the elements with that key, in the order they appear in slc.
+//
+// Example usage:
+//
+//	byLen := GroupBy([]string{"a", "bb", "c"}, func(s string) int { return len(s) })
+//	// byLen will be map[int][]string{1: {"a", "c"}, 2: {"bb"}}
+func GroupBy[T any, K comparable](slc []T, key func(T) K) map[K][]T {
+	ret := map[K][]T{}
+	for _, t := range slc {
+		k := key(t)
+		ret[k] = append(ret[k], t)
+	}
+	return ret
+}
+
+// Frequencies returns how many times every distinct element appears in slc.
+//
+// Example usage:
+//
+//	counts := Frequencies([]string{"a", "b", "a"})
+//	// counts will be map[string]int{"a": 2, "b": 1}
+func Frequencies[T comparable](slc []T) map[T]int {
+	ret := map[T]int{}
+	for _, t := range slc {
+		ret[t]++
+	}
+	return ret
+}
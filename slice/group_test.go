@@ -0,0 +1,20 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupBy(t *testing.T) {
+	r := require.New(t)
+	byLen := GroupBy([]string{"a", "bb", "c", "dd", "eee"}, func(s string) int { return len(s) })
+	r.Equal(map[int][]string{1: {"a", "c"}, 2: {"bb", "dd"}, 3: {"eee"}}, byLen)
+	r.Empty(GroupBy([]string(nil), func(s string) int { return len(s) }))
+}
+
+func TestFrequencies(t *testing.T) {
+	r := require.New(t)
+	r.Equal(map[string]int{"a": 2, "b": 1}, Frequencies([]string{"a", "b", "a"}))
+	r.Empty(Frequencies([]int(nil)))
+}
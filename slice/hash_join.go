@@ -0,0 +1,113 @@
+package slice
+
+// joinMatches returns, for every element of left, the indices of the
+// elements of right with the same key, in order. It builds a hash index on
+// whichever side is smaller, and scans the other
+func joinMatches[L, R any, K comparable](left []L, right []R, leftKey func(L) K, rightKey func(R) K) [][]int {
+	matches := make([][]int, len(left))
+	if len(right) <= len(left) {
+		index := make(map[K][]int, len(right))
+		for i, r := range right {
+			k := rightKey(r)
+			index[k] = append(index[k], i)
+		}
+		for i, l := range left {
+			matches[i] = index[leftKey(l)]
+		}
+		return matches
+	}
+	index := make(map[K][]int, len(left))
+	for i, l := range left {
+		k := leftKey(l)
+		index[k] = append(index[k], i)
+	}
+	for j, r := range right {
+		for _, i := range index[rightKey(r)] {
+			matches[i] = append(matches[i], j)
+		}
+	}
+	return matches
+}
+
+// InnerJoin returns combine called with every pair of an element of left and
+// an element of right whose keys, from leftKey and rightKey, are equal. It's
+// a hash join, so it runs in time proportional to the size of the inputs and
+// the output, rather than the product of their lengths like a nested loop.
+// Results are in the order of left, and for each element of left, in the
+// order of right.
+//
+// Example usage:
+//
+//	lines := InnerJoin(orders, users,
+//		func(o Order) int { return o.UserID },
+//		func(u User) int { return u.ID },
+//		func(o Order, u User) string { return u.Name + " ordered " + o.Item },
+//	)
+func InnerJoin[L, R any, K comparable, O any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	combine func(L, R) O,
+) []O {
+	var ret []O
+	for i, js := range joinMatches(left, right, leftKey, rightKey) {
+		for _, j := range js {
+			ret = append(ret, combine(left[i], right[j]))
+		}
+	}
+	return ret
+}
+
+// LeftJoin is the same as InnerJoin, except every element of left with no
+// matching element in right is also kept, and combined with a nil right
+func LeftJoin[L, R any, K comparable, O any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	combine func(L, *R) O,
+) []O {
+	ret := make([]O, 0, len(left))
+	for i, js := range joinMatches(left, right, leftKey, rightKey) {
+		if len(js) == 0 {
+			ret = append(ret, combine(left[i], nil))
+			continue
+		}
+		for _, j := range js {
+			ret = append(ret, combine(left[i], &right[j]))
+		}
+	}
+	return ret
+}
+
+// FullJoin is the same as LeftJoin, except every element of right with no
+// matching element in left is also kept, and combined with a nil left.
+// Unmatched elements of right come after everything else, in their order in
+// right
+func FullJoin[L, R any, K comparable, O any](
+	left []L,
+	right []R,
+	leftKey func(L) K,
+	rightKey func(R) K,
+	combine func(*L, *R) O,
+) []O {
+	ret := make([]O, 0, max(len(left), len(right)))
+	matched := make([]bool, len(right))
+	for i, js := range joinMatches(left, right, leftKey, rightKey) {
+		if len(js) == 0 {
+			ret = append(ret, combine(&left[i], nil))
+			continue
+		}
+		for _, j := range js {
+			matched[j] = true
+			ret = append(ret, combine(&left[i], &right[j]))
+		}
+	}
+	for j, ok := range matched {
+		if !ok {
+			ret = append(ret, combine(nil, &right[j]))
+		}
+	}
+	return ret
+}
@@ -0,0 +1,54 @@
+package slice
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type joinUser struct {
+	ID   int
+	Name string
+}
+
+func TestHashJoins(t *testing.T) {
+	r := require.New(t)
+	orders := []int{1, 3, 1, 4}
+	users := []joinUser{{1, "ann"}, {2, "bo"}, {1, "al"}, {3, "cy"}}
+	orderKey := func(o int) int { return o }
+	userKey := func(u joinUser) int { return u.ID }
+
+	// the output order doesn't depend on which side is indexed
+	for _, extra := range []int{0, 10} {
+		left := append([]int(nil), orders...)
+		for range extra {
+			left = append(left, 99)
+		}
+		inner := InnerJoin(left, users, orderKey, userKey, func(o int, u joinUser) string {
+			return fmt.Sprint(o, u.Name)
+		})
+		r.Equal([]string{"1ann", "1al", "3cy", "1ann", "1al"}, inner)
+	}
+
+	leftJoin := LeftJoin(orders, users, orderKey, userKey, func(o int, u *joinUser) string {
+		if u == nil {
+			return fmt.Sprint(o, "-")
+		}
+		return fmt.Sprint(o, u.Name)
+	})
+	r.Equal([]string{"1ann", "1al", "3cy", "1ann", "1al", "4-"}, leftJoin)
+
+	full := FullJoin(orders, users, orderKey, userKey, func(o *int, u *joinUser) string {
+		switch {
+		case o == nil:
+			return "-" + u.Name
+		case u == nil:
+			return fmt.Sprint(*o, "-")
+		}
+		return fmt.Sprint(*o, u.Name)
+	})
+	r.Equal([]string{"1ann", "1al", "3cy", "1ann", "1al", "4-", "-bo"}, full)
+
+	r.Empty(InnerJoin(nil, users, orderKey, userKey, func(o int, u joinUser) int { return o }))
+}
@@ -0,0 +1,45 @@
+package slice
+
+// IndexOf returns the index of the first element of slc that equals t, or -1
+// if there isn't one
+func IndexOf[T comparable](slc []T, t T) int {
+	return IndexBy(slc, func(e T) bool { return e == t })
+}
+
+// LastIndexOf returns the index of the last element of slc that equals t, or
+// -1 if there isn't one
+func LastIndexOf[T comparable](slc []T, t T) int {
+	for i := len(slc) - 1; i >= 0; i-- {
+		if slc[i] == t {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndexBy returns the index of the first element of slc for which pred
+// returns true, or -1 if there isn't one
+func IndexBy[T any](slc []T, pred func(T) bool) int {
+	for i, t := range slc {
+		if pred(t) {
+			return i
+		}
+	}
+	return -1
+}
+
+// IndicesWhere returns the indices of every element of slc for which pred
+// returns true, in order. Returns nil if there aren't any.
+//
+// Example usage:
+//
+//	IndicesWhere([]int{3, 8, 1, 6}, func(i int) bool { return i > 5 }) // [1, 3]
+func IndicesWhere[T any](slc []T, pred func(T) bool) []int {
+	var ret []int
+	for i, t := range slc {
+		if pred(t) {
+			ret = append(ret, i)
+		}
+	}
+	return ret
+}
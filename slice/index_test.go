@@ -0,0 +1,27 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexOf(t *testing.T) {
+	r := require.New(t)
+	slc := []string{"a", "b", "a"}
+	r.Equal(0, IndexOf(slc, "a"))
+	r.Equal(2, LastIndexOf(slc, "a"))
+	r.Equal(1, LastIndexOf(slc, "b"))
+	r.Equal(-1, IndexOf(slc, "c"))
+	r.Equal(-1, LastIndexOf(slc, "c"))
+	r.Equal(-1, IndexOf(nil, "a"))
+}
+
+func TestIndexBy(t *testing.T) {
+	r := require.New(t)
+	big := func(i int) bool { return i > 5 }
+	r.Equal(1, IndexBy([]int{3, 8, 1, 6}, big))
+	r.Equal(-1, IndexBy([]int{1}, big))
+	r.Equal([]int{1, 3}, IndicesWhere([]int{3, 8, 1, 6}, big))
+	r.Nil(IndicesWhere([]int{1, 2}, big))
+}
@@ -0,0 +1,51 @@
+package slice
+
+// MapInto is the same as Map, except it writes the results into dst instead
+// of a new slice, growing it only if it's too small. It returns the results,
+// which are dst[:len(slc)] when dst has enough capacity. Reusing one dst over
+// many calls keeps Map out of allocation profiles in hot loops.
+//
+// dst may be slc itself, when T and U are the same type, to map in place. If
+// fn returns an error, MapInto returns nil and the error, and dst may hold
+// some of the results.
+//
+// Example usage:
+//
+//	var buf []string
+//	for _, row := range rows {
+//		buf, _ = MapInto(buf, row, func(_ uint, i int) (string, error) {
+//			return strconv.Itoa(i), nil
+//		})
+//		w.Write(buf)
+//	}
+func MapInto[T, U any](dst []U, slc []T, fn func(i uint, t T) (U, error)) ([]U, error) {
+	if cap(dst) < len(slc) {
+		dst = make([]U, len(slc))
+	}
+	dst = dst[:len(slc)]
+	for i, t := range slc {
+		u, err := fn(uint(i), t)
+		if err != nil {
+			return nil, err
+		}
+		dst[i] = u
+	}
+	return dst, nil
+}
+
+// FilterInto writes every element of slc for which pred returns true into
+// dst, in order, growing dst only if it's too small, and returns the result.
+// Whatever dst held before is overwritten.
+//
+// dst may be slc[:0], to filter slc in place. The elements past the end of
+// the result are left as they were, so zero them if they hold pointers that
+// shouldn't be kept alive.
+func FilterInto[T any](dst []T, slc []T, pred func(T) bool) []T {
+	dst = dst[:0]
+	for _, t := range slc {
+		if pred(t) {
+			dst = append(dst, t)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,54 @@
+package slice
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapInto(t *testing.T) {
+	r := require.New(t)
+	itoa := func(_ uint, i int) (string, error) { return strconv.Itoa(i), nil }
+	buf := make([]string, 0, 4)
+	out, err := MapInto(buf, []int{1, 2, 3}, itoa)
+	r.NoError(err)
+	r.Equal([]string{"1", "2", "3"}, out)
+	r.Same(&buf[:1][0], &out[0])
+
+	out, err = MapInto(out, []int{1, 2, 3, 4, 5}, itoa)
+	r.NoError(err)
+	r.Equal([]string{"1", "2", "3", "4", "5"}, out)
+
+	in := []int{1, 2, 3}
+	doubled, err := MapInto(in, in, func(_ uint, i int) (int, error) { return i * 2, nil })
+	r.NoError(err)
+	r.Equal([]int{2, 4, 6}, in)
+	r.Equal(in, doubled)
+
+	boom := errors.New("boom")
+	out, err = MapInto(nil, []int{1}, func(uint, int) (string, error) { return "", boom })
+	r.ErrorIs(err, boom)
+	r.Nil(out)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = MapInto(buf, []int{1, 2, 3}, func(_ uint, i int) (string, error) { return "", nil })
+	})
+	r.Zero(allocs)
+}
+
+func TestFilterInto(t *testing.T) {
+	r := require.New(t)
+	even := func(i int) bool { return i%2 == 0 }
+	r.Equal([]int{2, 4}, FilterInto(nil, []int{1, 2, 3, 4}, even))
+
+	buf := []int{9, 9, 9}
+	out := FilterInto(buf, []int{2, 3}, even)
+	r.Equal([]int{2}, out)
+	r.Equal([]int{2, 9, 9}, buf)
+
+	in := []int{1, 2, 3, 4, 6}
+	r.Equal([]int{2, 4, 6}, FilterInto(in[:0], in, even))
+	r.Empty(FilterInto(nil, []int{1}, even))
+}
@@ -0,0 +1,29 @@
+package slice
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Join formats every element of slc with format and joins the results with
+// sep between them, like strings.Join does for a []string. If format is nil,
+// elements are formatted with fmt.Sprint, so types that implement
+// fmt.Stringer or error are formatted with their String or Error method.
+//
+// Example usage:
+//
+//	Join([]int{1, 2, 3}, ", ", nil) // "1, 2, 3"
+//	Join(ids, ",", func(id int) string { return strconv.Itoa(id) })
+func Join[T any](slc []T, sep string, format func(T) string) string {
+	if format == nil {
+		format = func(t T) string { return fmt.Sprint(t) }
+	}
+	var b strings.Builder
+	for i, t := range slc {
+		if i > 0 {
+			b.WriteString(sep)
+		}
+		b.WriteString(format(t))
+	}
+	return b.String()
+}
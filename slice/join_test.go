@@ -0,0 +1,22 @@
+package slice
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoin(t *testing.T) {
+	r := require.New(t)
+	r.Equal("1, 2, 3", Join([]int{1, 2, 3}, ", ", nil))
+	r.Equal("1s|2m0s", Join([]time.Duration{time.Second, 2 * time.Minute}, "|", nil))
+	r.Equal("a; b", Join([]error{errors.New("a"), errors.New("b")}, "; ", nil))
+	r.Equal("", Join([]int(nil), ",", nil))
+	r.Equal("x", Join([]string{"x"}, ",", nil))
+
+	hex := func(i int) string { return "0x" + strconv.FormatInt(int64(i), 16) }
+	r.Equal("0xa 0xff", Join([]int{10, 255}, " ", hex))
+}
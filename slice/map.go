@@ -1,10 +1,9 @@
-package iter
+package slice
 
 import (
 	"context"
-	"sync"
 
-	"golang.org/x/sync/errgroup"
+	"github.com/go-functional/core/par"
 )
 
 // Map iterates through slc and, for each element, calls fn with its index
@@ -31,18 +30,19 @@ func Map[T any, U any](slc []T, fn func(i uint, t T) (U, error)) ([]U, error) {
 	return ret, nil
 }
 
-// ParMap is similar to Map, except calls fn in a separate goroutine for 
+// ParMap is similar to Map, except calls fn in a separate goroutine for
 // each element in slc. If any one of the calls to fn returns an error,
 // the first that returns an error will have that error returned, and nil will
-// be returned for the slice. fn will be passed a context that is derived from 
+// be returned for the slice. fn will be passed a context that is derived from
 // the input ctx.
 //
 // Common use of this function is to do operations on a slice that can be
 // done concurrently. Often this applies to "embarassingly parallel" problems.
+// Pass options from the par package, like par.Limit, to change how the calls
+// to fn are run.
 //
 // Example usage:
 //
-//	var mut sync.Mutex
 //	slc := []int{1, 2, 3, 4, 5}
 //	ParMap(context.Background(), slc, func(_ context.Context, _ uint, val int) (string, error) {
 //		return strconv.Itoa(val), nil
@@ -51,24 +51,18 @@ func ParMap[T any, U any](
 	ctx context.Context,
 	slc []T,
 	fn func(context.Context, uint, T) (U, error),
+	opts ...par.Option,
 ) ([]U, error) {
-
-	g, ctx := errgroup.WithContext(ctx)
 	ret := make([]U, len(slc))
-	for idx, v := range slc {
-		i, v := uint(idx), v
-		g.Go(func() error {
-			r, err := fn(ctx, i, v)
-			if err == nil {
-				ret[i] = r
-			}
-			return err
-		})
-	}
-	
-	if err := g.Wait(); err != nil {
+	err := par.Do(ctx, len(slc), func(ctx context.Context, i int) error {
+		r, err := fn(ctx, uint(i), slc[i])
+		if err == nil {
+			ret[i] = r
+		}
+		return err
+	}, opts...)
+	if err != nil {
 		return nil, err
 	}
-	
 	return ret, nil
-}
\ No newline at end of file
+}
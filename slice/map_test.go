@@ -0,0 +1,36 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParMap(t *testing.T) {
+	r := require.New(t)
+	slc := []int{1, 2, 3, 4, 5}
+	fn := func(_ context.Context, _ uint, val int) (string, error) {
+		return strconv.Itoa(val), nil
+	}
+	strs, err := ParMap(context.Background(), slc, fn)
+	r.NoError(err)
+	r.Equal([]string{"1", "2", "3", "4", "5"}, strs)
+
+	strs, err = ParMap(context.Background(), slc, fn, par.Limit(2))
+	r.NoError(err)
+	r.Equal([]string{"1", "2", "3", "4", "5"}, strs)
+
+	boom := errors.New("boom")
+	strs, err = ParMap(context.Background(), slc, func(_ context.Context, i uint, _ int) (string, error) {
+		if i == 3 {
+			return "", boom
+		}
+		return "", nil
+	})
+	r.ErrorIs(err, boom)
+	r.Nil(strs)
+}
@@ -0,0 +1,90 @@
+package slice
+
+import (
+	"errors"
+
+	"github.com/go-functional/core/constraints"
+	"github.com/go-functional/core/monoid"
+)
+
+// MovingAgg combines every window of window consecutive elements of slc with
+// s, and returns the results in order, one per window, so the result has
+// len(slc)-window+1 elements. It returns nil if slc is shorter than window,
+// and an error if window is less than 1.
+//
+// It keeps the window in a queue built from two stacks of running
+// aggregates, so it calls s.Combine O(len(slc)) times in total, rather than
+// window times per result. That makes it a good fit for moving min and max,
+// which can't be updated by subtracting the element that leaves the window.
+//
+// Example usage, a moving maximum:
+//
+//	MovingAgg([]int{1, 3, 2, 5, 4}, 2, monoid.Max(math.MinInt)) // [3, 3, 5, 5]
+func MovingAgg[T any](slc []T, window int, s monoid.Semigroup[T]) ([]T, error) {
+	if window < 1 {
+		return nil, errors.New("MovingAgg called with a window smaller than 1")
+	}
+	if len(slc) < window {
+		return nil, nil
+	}
+
+	// front holds the older part of the window, where each entry is the
+	// aggregate of itself and every newer entry in front. back holds the
+	// newer part, as a single running aggregate. Popping the oldest element
+	// pops front, refilling it from back when it's empty
+	var (
+		front   []T
+		backLo  = 0
+		backAgg T
+		haveAgg bool
+	)
+	ret := make([]T, 0, len(slc)-window+1)
+	for i, t := range slc {
+		if haveAgg {
+			backAgg = s.Combine(backAgg, t)
+		} else {
+			backAgg, haveAgg = t, true
+		}
+		if i < window-1 {
+			continue
+		}
+		if len(front) == 0 {
+			// move the back elements into front, newest first, so that
+			// each entry aggregates everything newer than it
+			back := slc[backLo : i+1]
+			front = make([]T, len(back))
+			front[len(back)-1] = back[len(back)-1]
+			for j := len(back) - 2; j >= 0; j-- {
+				front[j] = s.Combine(back[j], front[j+1])
+			}
+			// front is stored oldest first, and popped from the start
+			backLo = i + 1
+			haveAgg = false
+		}
+		if haveAgg {
+			ret = append(ret, s.Combine(front[0], backAgg))
+		} else {
+			ret = append(ret, front[0])
+		}
+		front = front[1:]
+	}
+	return ret, nil
+}
+
+// MovingAverage returns the mean of every window of window consecutive
+// elements of slc, in order, so the result has len(slc)-window+1 elements.
+// It returns nil if slc is shorter than window, and an error if window is
+// less than 1. Each window's sum is computed with MovingAgg, so floating
+// point error doesn't build up over long inputs the way it would with a
+// running sum
+func MovingAverage[N constraints.Number](slc []N, window int) ([]float64, error) {
+	fs, _ := Map(slc, func(_ uint, n N) (float64, error) { return float64(n), nil })
+	sums, err := MovingAgg(fs, window, monoid.Sum[float64]())
+	if err != nil {
+		return nil, errors.New("MovingAverage called with a window smaller than 1")
+	}
+	for i := range sums {
+		sums[i] /= float64(window)
+	}
+	return sums, nil
+}
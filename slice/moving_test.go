@@ -0,0 +1,59 @@
+package slice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-functional/core/monoid"
+	"github.com/stretchr/testify/require"
+)
+
+// naiveMovingAgg recomputes every window from scratch
+func naiveMovingAgg[T any](slc []T, window int, s monoid.Semigroup[T]) []T {
+	var ret []T
+	for i := 0; i+window <= len(slc); i++ {
+		agg := slc[i]
+		for _, t := range slc[i+1 : i+window] {
+			agg = s.Combine(agg, t)
+		}
+		ret = append(ret, agg)
+	}
+	return ret
+}
+
+func TestMovingAgg(t *testing.T) {
+	r := require.New(t)
+	maxInt := monoid.Max(math.MinInt)
+	out, err := MovingAgg([]int{1, 3, 2, 5, 4}, 2, maxInt)
+	r.NoError(err)
+	r.Equal([]int{3, 3, 5, 5}, out)
+
+	in := []int{5, 1, 4, 2, 8, 0, 3, 3, 9, 7, 1, 6}
+	for _, s := range []monoid.Semigroup[int]{maxInt, monoid.Min(math.MaxInt), monoid.Sum[int]()} {
+		for w := 1; w <= len(in); w++ {
+			got, err := MovingAgg(in, w, s)
+			r.NoError(err)
+			r.Equal(naiveMovingAgg(in, w, s), got, "window %d", w)
+		}
+	}
+
+	// a non-commutative operation checks the order elements are combined in
+	concat, err := MovingAgg([]string{"a", "b", "c", "d", "e"}, 3, monoid.String())
+	r.NoError(err)
+	r.Equal([]string{"abc", "bcd", "cde"}, concat)
+
+	out, err = MovingAgg([]int{1}, 2, maxInt)
+	r.NoError(err)
+	r.Nil(out)
+	_, err = MovingAgg([]int{1}, 0, maxInt)
+	r.Error(err)
+}
+
+func TestMovingAverage(t *testing.T) {
+	r := require.New(t)
+	out, err := MovingAverage([]int{1, 2, 3, 4}, 2)
+	r.NoError(err)
+	r.Equal([]float64{1.5, 2.5, 3.5}, out)
+	_, err = MovingAverage([]int{1}, -1)
+	r.EqualError(err, "MovingAverage called with a window smaller than 1")
+}
@@ -41,6 +41,7 @@ func minmaxSlice[T any](a, b []T) (smaller, larger []T) {
 // is longer than the other, the remainder of the returned slice will just
 // have the rest of the elements in the longer slice
 func Zip[T any](slc1 []T, slc2 []T) []T {
+	length := len(slc1) + len(slc2)
 	ret := make([]T, 0, length)
 
 	smaller, larger := minmaxSlice(slc1, slc2)
@@ -50,6 +51,5 @@ func Zip[T any](slc1 []T, slc2 []T) []T {
 		ret = append(ret, slc2[i])
 	}
 
-	length := len(slc1) + len(slc2)
 	return append(ret, larger[len(larger)-(length-len(ret)):]...)
 }
@@ -0,0 +1,109 @@
+package slice
+
+import (
+	"context"
+	"runtime"
+
+	"github.com/go-functional/core/par"
+)
+
+// minShard is the smallest number of elements ParGroupBy and ParFrequencies
+// give a shard. Below it, the cost of merging more maps outweighs the time
+// saved by splitting the work
+const minShard = 4096
+
+// checkEvery is how many elements a shard processes between checks of its
+// context
+const checkEvery = 1024
+
+// shards splits [0, n) into contiguous ranges, one per shard, and returns
+// the start of each range, followed by n
+func shards(n int) []int {
+	count := max(min(runtime.GOMAXPROCS(0), (n+minShard-1)/minShard), 1)
+	bounds := make([]int, count+1)
+	for i := range bounds {
+		bounds[i] = i * n / count
+	}
+	return bounds
+}
+
+// parShard calls fn for every shard of slc in parallel, passing each one the
+// shard and its own accumulator, and returns the accumulators in shard order
+func parShard[T, A any](
+	ctx context.Context,
+	slc []T,
+	newAcc func() A,
+	fn func(acc A, t T),
+	opts []par.Option,
+) ([]A, error) {
+	bounds := shards(len(slc))
+	accs := make([]A, len(bounds)-1)
+	err := par.Do(ctx, len(accs), func(ctx context.Context, s int) error {
+		acc := newAcc()
+		for i, t := range slc[bounds[s]:bounds[s+1]] {
+			if i%checkEvery == 0 {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+			}
+			fn(acc, t)
+		}
+		accs[s] = acc
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return accs, nil
+}
+
+// ParGroupBy is the same as GroupBy, except slc is split into shards that
+// are grouped in parallel, each into its own map, and the maps are merged at
+// the end. The elements for each key are still in the order they appear in
+// slc. It's worth it for slices of millions of elements; for small slices,
+// it does the same work as GroupBy on a single goroutine. Returns nil and
+// ctx.Err() if ctx is done first.
+//
+// Example usage:
+//
+//	byUser, err := ParGroupBy(ctx, events, func(e Event) string { return e.UserID })
+func ParGroupBy[T any, K comparable](
+	ctx context.Context,
+	slc []T,
+	key func(T) K,
+	opts ...par.Option,
+) (map[K][]T, error) {
+	parts, err := parShard(ctx, slc, func() map[K][]T { return map[K][]T{} }, func(acc map[K][]T, t T) {
+		k := key(t)
+		acc[k] = append(acc[k], t)
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	ret := parts[0]
+	for _, part := range parts[1:] {
+		for k, ts := range part {
+			ret[k] = append(ret[k], ts...)
+		}
+	}
+	return ret, nil
+}
+
+// ParFrequencies is the same as Frequencies, except slc is counted in
+// parallel shards, the same way ParGroupBy groups it. Returns nil and
+// ctx.Err() if ctx is done first
+func ParFrequencies[T comparable](ctx context.Context, slc []T, opts ...par.Option) (map[T]int, error) {
+	parts, err := parShard(ctx, slc, func() map[T]int { return map[T]int{} }, func(acc map[T]int, t T) {
+		acc[t]++
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	ret := parts[0]
+	for _, part := range parts[1:] {
+		for t, n := range part {
+			ret[t] += n
+		}
+	}
+	return ret, nil
+}
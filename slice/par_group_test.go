@@ -0,0 +1,38 @@
+package slice
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParGroupByFrequencies(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	for _, n := range []int{0, 10, minShard*3 + 7} {
+		in := make([]int, n)
+		for i := range in {
+			in[i] = (i * 7919) % 13
+		}
+		mod := func(i int) int { return i % 5 }
+
+		groups, err := ParGroupBy(ctx, in, mod)
+		r.NoError(err)
+		r.Equal(GroupBy(in, mod), groups)
+
+		groups, err = ParGroupBy(ctx, in, mod, par.Limit(2))
+		r.NoError(err)
+		r.Equal(GroupBy(in, mod), groups)
+
+		freqs, err := ParFrequencies(ctx, in)
+		r.NoError(err)
+		r.Equal(Frequencies(in), freqs)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := ParFrequencies(cancelled, make([]int, 10))
+	r.ErrorIs(err, context.Canceled)
+}
@@ -0,0 +1,78 @@
+package slice
+
+import (
+	"context"
+
+	"github.com/go-functional/core/errs"
+	"github.com/go-functional/core/par"
+)
+
+// MapPartial is similar to Map, except an error from fn doesn't stop it.
+// It calls fn with every element of slc, and returns a slice the same length
+// as slc holding the result for every element fn succeeded on, and the zero
+// value of U for every one it failed on. The error is nil if every call
+// succeeded, and otherwise a *errs.Multi holding every failure, keyed by its
+// index as a uint, in index order.
+//
+// It's for batch jobs where one bad record shouldn't throw away the rest of
+// the batch.
+//
+// Example usage:
+//
+//	users, err := MapPartial(rows, func(_ uint, r Row) (User, error) {
+//		return parseUser(r)
+//	})
+//	var failed *errs.Multi
+//	if errors.As(err, &failed) {
+//		for _, e := range failed.Errors() {
+//			log.Printf("skipping row %d: %v", e.Key, e.Err)
+//		}
+//	}
+func MapPartial[T, U any](slc []T, fn func(uint, T) (U, error)) ([]U, error) {
+	ret := make([]U, len(slc))
+	var failed errs.Multi
+	for i, t := range slc {
+		u, err := fn(uint(i), t)
+		if err != nil {
+			failed.Add(uint(i), err)
+			continue
+		}
+		ret[i] = u
+	}
+	return ret, failed.Err()
+}
+
+// ParMapPartial is the same as MapPartial, except fn is called in parallel,
+// like ParMap. An error from one call doesn't cancel the others. If ctx is
+// done before every element has been processed, each element that wasn't is
+// recorded as failing with ctx.Err()
+func ParMapPartial[T, U any](
+	ctx context.Context,
+	slc []T,
+	fn func(context.Context, uint, T) (U, error),
+	opts ...par.Option,
+) ([]U, error) {
+	ret := make([]U, len(slc))
+	// every call writes only its own index, so these need no locking
+	callErrs := make([]error, len(slc))
+	done := make([]bool, len(slc))
+	doErr := par.Do(ctx, len(slc), func(ctx context.Context, i int) error {
+		u, err := fn(ctx, uint(i), slc[i])
+		if err != nil {
+			callErrs[i] = err
+		} else {
+			ret[i] = u
+		}
+		done[i] = true
+		return nil
+	}, opts...)
+
+	var failed errs.Multi
+	for i, err := range callErrs {
+		if !done[i] {
+			err = doErr
+		}
+		failed.Add(uint(i), err)
+	}
+	return ret, failed.Err()
+}
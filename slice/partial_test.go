@@ -0,0 +1,57 @@
+package slice
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/go-functional/core/errs"
+	"github.com/go-functional/core/par"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapPartial(t *testing.T) {
+	r := require.New(t)
+	atoi := func(_ uint, s string) (int, error) { return strconv.Atoi(s) }
+	out, err := MapPartial([]string{"1", "x", "3", "y"}, atoi)
+	r.Equal([]int{1, 0, 3, 0}, out)
+	var failed *errs.Multi
+	r.ErrorAs(err, &failed)
+	failures := failed.Errors()
+	r.Len(failures, 2)
+	r.Equal(uint(1), failures[0].Key)
+	r.Equal(uint(3), failures[1].Key)
+	var numErr *strconv.NumError
+	r.ErrorAs(err, &numErr)
+	r.Contains(err.Error(), "2 errors: 1: ")
+
+	out, err = MapPartial([]string{"1"}, atoi)
+	r.Equal([]int{1}, out)
+	r.NoError(err)
+}
+
+func TestParMapPartial(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	atoi := func(_ context.Context, _ uint, s string) (int, error) { return strconv.Atoi(s) }
+	in := []string{"1", "x", "3", "y", "5"}
+	for _, opts := range [][]par.Option{nil, {par.Limit(2)}} {
+		out, err := ParMapPartial(ctx, in, atoi, opts...)
+		r.Equal([]int{1, 0, 3, 0, 5}, out)
+		var failed *errs.Multi
+		r.ErrorAs(err, &failed)
+		failures := failed.Errors()
+		r.Len(failures, 2)
+		r.Equal(uint(1), failures[0].Key)
+		r.Equal(uint(3), failures[1].Key)
+	}
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := ParMapPartial(cancelled, in, atoi, par.Limit(2))
+	var failed *errs.Multi
+	r.ErrorAs(err, &failed)
+	r.Equal(len(in), failed.Len())
+	r.True(errors.Is(err, context.Canceled))
+}
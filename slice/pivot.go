@@ -0,0 +1,82 @@
+package slice
+
+import "github.com/go-functional/core/monoid"
+
+// Pivot cross-tabulates rows: it groups them by rowKey and then by colKey,
+// and aggregates the values valueFn returns for each group with agg. Only
+// cells that at least one row fell into are in the result.
+//
+// Example usage, totalling sales by region and quarter:
+//
+//	totals := Pivot(sales,
+//		func(s Sale) string { return s.Region },
+//		func(s Sale) int { return s.Quarter },
+//		func(s Sale) float64 { return s.Amount },
+//		monoid.Sum[float64](),
+//	)
+//	// totals["emea"][2] is the total for EMEA in Q2
+func Pivot[T any, RK, CK comparable, V any](
+	rows []T,
+	rowKey func(T) RK,
+	colKey func(T) CK,
+	valueFn func(T) V,
+	agg monoid.Monoid[V],
+) map[RK]map[CK]V {
+	ret := map[RK]map[CK]V{}
+	for rk, group := range GroupBy(rows, rowKey) {
+		cols := map[CK]V{}
+		for ck, cell := range GroupBy(group, colKey) {
+			cols[ck] = monoid.FoldMap(cell, agg, valueFn)
+		}
+		ret[rk] = cols
+	}
+	return ret
+}
+
+// Table is the ordered, 2D form of a pivot. Cells[i][j] is the aggregate for
+// Rows[i] and Cols[j]
+type Table[RK, CK comparable, V any] struct {
+	Rows  []RK
+	Cols  []CK
+	Cells [][]V
+}
+
+// PivotTable is the same as Pivot, except it returns a Table, whose row and
+// column keys are in the order they first appear in rows. Cells that no row
+// fell into hold agg.Empty(), so the table is always complete
+func PivotTable[T any, RK, CK comparable, V any](
+	rows []T,
+	rowKey func(T) RK,
+	colKey func(T) CK,
+	valueFn func(T) V,
+	agg monoid.Monoid[V],
+) Table[RK, CK, V] {
+	var (
+		tbl      Table[RK, CK, V]
+		seenRows = map[RK]bool{}
+		seenCols = map[CK]bool{}
+	)
+	for _, t := range rows {
+		if rk := rowKey(t); !seenRows[rk] {
+			seenRows[rk] = true
+			tbl.Rows = append(tbl.Rows, rk)
+		}
+		if ck := colKey(t); !seenCols[ck] {
+			seenCols[ck] = true
+			tbl.Cols = append(tbl.Cols, ck)
+		}
+	}
+	pivot := Pivot(rows, rowKey, colKey, valueFn, agg)
+	tbl.Cells = make([][]V, len(tbl.Rows))
+	for i, rk := range tbl.Rows {
+		tbl.Cells[i] = make([]V, len(tbl.Cols))
+		for j, ck := range tbl.Cols {
+			v, ok := pivot[rk][ck]
+			if !ok {
+				v = agg.Empty()
+			}
+			tbl.Cells[i][j] = v
+		}
+	}
+	return tbl
+}
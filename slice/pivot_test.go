@@ -0,0 +1,40 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/go-functional/core/monoid"
+	"github.com/stretchr/testify/require"
+)
+
+type sale struct {
+	Region  string
+	Quarter int
+	Amount  int
+}
+
+func TestPivot(t *testing.T) {
+	r := require.New(t)
+	sales := []sale{
+		{"emea", 1, 10},
+		{"apac", 2, 5},
+		{"emea", 2, 7},
+		{"emea", 1, 3},
+	}
+	region := func(s sale) string { return s.Region }
+	quarter := func(s sale) int { return s.Quarter }
+	amount := func(s sale) int { return s.Amount }
+
+	r.Equal(map[string]map[int]int{
+		"emea": {1: 13, 2: 7},
+		"apac": {2: 5},
+	}, Pivot(sales, region, quarter, amount, monoid.Sum[int]()))
+
+	tbl := PivotTable(sales, region, quarter, amount, monoid.Sum[int]())
+	r.Equal([]string{"emea", "apac"}, tbl.Rows)
+	r.Equal([]int{1, 2}, tbl.Cols)
+	r.Equal([][]int{{13, 7}, {0, 5}}, tbl.Cells)
+
+	counts := PivotTable(sales, region, quarter, func(sale) int { return 1 }, monoid.Sum[int]())
+	r.Equal([][]int{{2, 1}, {0, 1}}, counts.Cells)
+}
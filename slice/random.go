@@ -0,0 +1,79 @@
+package slice
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+)
+
+// Choice returns a random element of slc, picked with r, each equally
+// likely. Pass a seeded r to get the same choice every run, like in tests.
+// Returns an error if slc is empty
+func Choice[T any](r *rand.Rand, slc []T) (T, error) {
+	if len(slc) == 0 {
+		var zero T
+		return zero, errors.New("Choice called on empty slice")
+	}
+	return slc[r.IntN(len(slc))], nil
+}
+
+// Choices returns n random elements of slc, picked with r, with replacement,
+// so the same element can be picked more than once. Returns an error if slc
+// is empty and n is positive
+func Choices[T any](r *rand.Rand, slc []T, n int) ([]T, error) {
+	if n <= 0 {
+		return []T{}, nil
+	}
+	if len(slc) == 0 {
+		return nil, errors.New("Choices called on empty slice")
+	}
+	ret := make([]T, n)
+	for i := range ret {
+		ret[i] = slc[r.IntN(len(slc))]
+	}
+	return ret, nil
+}
+
+// WeightedChoice returns a random element of slc, picked with r, where the
+// chance of picking slc[i] is weights[i] divided by the sum of the weights.
+// Returns an error if the lengths of slc and weights differ, if any weight is
+// negative, NaN or infinite, if they're all zero, or if their sum overflows.
+//
+// Example usage:
+//
+//	backend, err := WeightedChoice(rnd, []string{"primary", "canary"}, []float64{0.95, 0.05})
+func WeightedChoice[T any](r *rand.Rand, slc []T, weights []float64) (T, error) {
+	var zero T
+	if len(slc) != len(weights) {
+		return zero, errors.New("WeightedChoice called with a different number of elements and weights")
+	}
+	total := 0.0
+	for _, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			return zero, errors.New("WeightedChoice called with a negative, NaN or infinite weight")
+		}
+		total += w
+	}
+	if total == 0 {
+		return zero, errors.New("WeightedChoice called with no positive weights")
+	}
+	if math.IsInf(total, 1) {
+		return zero, errors.New("WeightedChoice called with weights whose sum overflows")
+	}
+	target := r.Float64() * total
+	for i, w := range weights {
+		if target < w {
+			return slc[i], nil
+		}
+		target -= w
+	}
+	// rounding error can leave target just past the last weight, so fall
+	// back to the last element that could have been picked
+	for i := len(weights) - 1; i >= 0; i-- {
+		if weights[i] > 0 {
+			return slc[i], nil
+		}
+	}
+	// unreachable, since total > 0 means some weight is positive
+	return zero, errors.New("WeightedChoice found no positive weight")
+}
@@ -0,0 +1,67 @@
+package slice
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoice(t *testing.T) {
+	r := require.New(t)
+	rnd := rand.New(rand.NewPCG(1, 1))
+	seen := map[string]bool{}
+	for i := 0; i < 100; i++ {
+		c, err := Choice(rnd, []string{"a", "b", "c"})
+		r.NoError(err)
+		seen[c] = true
+	}
+	r.Len(seen, 3)
+	_, err := Choice(rnd, []int(nil))
+	r.Error(err)
+
+	a, _ := Choice(rand.New(rand.NewPCG(7, 7)), RangeN(100))
+	b, _ := Choice(rand.New(rand.NewPCG(7, 7)), RangeN(100))
+	r.Equal(a, b)
+}
+
+func TestChoices(t *testing.T) {
+	r := require.New(t)
+	rnd := rand.New(rand.NewPCG(1, 1))
+	cs, err := Choices(rnd, []int{1, 2}, 50)
+	r.NoError(err)
+	r.Len(cs, 50)
+	r.Subset([]int{1, 2}, cs)
+	cs, err = Choices(rnd, []int(nil), 0)
+	r.NoError(err)
+	r.Empty(cs)
+	_, err = Choices(rnd, []int(nil), 1)
+	r.Error(err)
+}
+
+func TestWeightedChoice(t *testing.T) {
+	r := require.New(t)
+	rnd := rand.New(rand.NewPCG(1, 1))
+	counts := map[string]int{}
+	for i := 0; i < 10000; i++ {
+		c, err := WeightedChoice(rnd, []string{"a", "b", "never"}, []float64{3, 1, 0})
+		r.NoError(err)
+		counts[c]++
+	}
+	r.Zero(counts["never"])
+	r.InDelta(0.75, float64(counts["a"])/10000, 0.03)
+
+	_, err := WeightedChoice(rnd, []int{1}, []float64{1, 2})
+	r.Error(err)
+	_, err = WeightedChoice(rnd, []int{1}, []float64{-1})
+	r.Error(err)
+	_, err = WeightedChoice(rnd, []int{1, 2}, []float64{0, 0})
+	r.Error(err)
+	_, err = WeightedChoice(rnd, []int{1}, []float64{math.NaN()})
+	r.Error(err)
+	_, err = WeightedChoice(rnd, []int{1, 2}, []float64{1, math.Inf(1)})
+	r.Error(err)
+	_, err = WeightedChoice(rnd, []int{1, 2}, []float64{math.MaxFloat64, math.MaxFloat64})
+	r.Error(err)
+}
@@ -0,0 +1,51 @@
+package slice
+
+import (
+	"slices"
+
+	"github.com/go-functional/core/constraints"
+	"github.com/go-functional/core/seq"
+)
+
+// RangeN returns a slice of the integers from 0 up to, but not including, n.
+// It's the same as collecting seq.RangeN
+func RangeN(n int) []int {
+	ret := make([]int, max(n, 0))
+	for i := range ret {
+		ret[i] = i
+	}
+	return ret
+}
+
+// Range returns a slice of the values from start, moving by step, up to but
+// not including end. It's the same as collecting seq.Range, and returns the
+// same errors when step doesn't move start toward end, or when a float
+// argument is NaN or infinite
+func Range[N constraints.Number](start, end, step N) ([]N, error) {
+	s, err := seq.Range(start, end, step)
+	if err != nil {
+		return nil, err
+	}
+	return slices.Collect(s), nil
+}
+
+// Linspace returns a slice of n evenly spaced values from lo to hi,
+// including both. It's the same as collecting seq.Linspace
+func Linspace[F constraints.Float](lo, hi F, n int) []F {
+	ret := make([]F, 0, max(n, 0))
+	return slices.AppendSeq(ret, seq.Linspace(lo, hi, n))
+}
+
+// Times returns a slice holding the results of calling fn with 0, 1, ... n-1.
+//
+// Example usage:
+//
+//	squares := Times(4, func(i int) int { return i * i })
+//	// squares will be []int{0, 1, 4, 9}
+func Times[T any](n int, fn func(i int) T) []T {
+	ret := make([]T, max(n, 0))
+	for i := range ret {
+		ret[i] = fn(i)
+	}
+	return ret
+}
@@ -0,0 +1,25 @@
+package slice
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRange(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]int{0, 1, 2}, RangeN(3))
+	r.Empty(RangeN(-2))
+
+	odds, err := Range(1, 8, 2)
+	r.NoError(err)
+	r.Equal([]int{1, 3, 5, 7}, odds)
+	_, err = Range(1.0, 0.0, 0.5)
+	r.Error(err)
+	_, err = Range(0.0, 1.0, math.NaN())
+	r.Error(err)
+
+	r.Equal([]float64{-1, 0, 1}, Linspace(-1.0, 1.0, 3))
+	r.Equal([]int{0, 1, 4, 9}, Times(4, func(i int) int { return i * i }))
+}
@@ -0,0 +1,72 @@
+package slice
+
+// Fill returns a new slice the same length as slc, with every element set
+// to v
+func Fill[T any](slc []T, v T) []T {
+	ret := make([]T, len(slc))
+	FillInPlace(ret, v)
+	return ret
+}
+
+// FillInPlace sets every element of slc to v
+func FillInPlace[T any](slc []T, v T) {
+	for i := range slc {
+		slc[i] = v
+	}
+}
+
+// ReplaceFirst returns a copy of slc with the first element that equals old
+// replaced by new. If no element equals old, the copy is unchanged
+func ReplaceFirst[T comparable](slc []T, old, new T) []T {
+	ret := append([]T(nil), slc...)
+	ReplaceFirstInPlace(ret, old, new)
+	return ret
+}
+
+// ReplaceFirstInPlace replaces the first element of slc that equals old with
+// new. Returns true if there was one to replace
+func ReplaceFirstInPlace[T comparable](slc []T, old, new T) bool {
+	i := IndexOf(slc, old)
+	if i < 0 {
+		return false
+	}
+	slc[i] = new
+	return true
+}
+
+// ReplaceAll returns a copy of slc with every element that equals old
+// replaced by new
+func ReplaceAll[T comparable](slc []T, old, new T) []T {
+	return ReplaceWhere(slc, func(t T) bool { return t == old }, new)
+}
+
+// ReplaceAllInPlace replaces every element of slc that equals old with new,
+// and returns how many it replaced
+func ReplaceAllInPlace[T comparable](slc []T, old, new T) int {
+	return ReplaceWhereInPlace(slc, func(t T) bool { return t == old }, new)
+}
+
+// ReplaceWhere returns a copy of slc with every element for which pred
+// returns true replaced by v.
+//
+// Example usage:
+//
+//	ReplaceWhere([]int{-1, 2, -3}, func(i int) bool { return i < 0 }, 0) // [0, 2, 0]
+func ReplaceWhere[T any](slc []T, pred func(T) bool, v T) []T {
+	ret := append([]T(nil), slc...)
+	ReplaceWhereInPlace(ret, pred, v)
+	return ret
+}
+
+// ReplaceWhereInPlace replaces every element of slc for which pred returns
+// true with v, and returns how many it replaced
+func ReplaceWhereInPlace[T any](slc []T, pred func(T) bool, v T) int {
+	n := 0
+	for i, t := range slc {
+		if pred(t) {
+			slc[i] = v
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,41 @@
+package slice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFill(t *testing.T) {
+	r := require.New(t)
+	in := []int{1, 2, 3}
+	r.Equal([]int{7, 7, 7}, Fill(in, 7))
+	r.Equal([]int{1, 2, 3}, in)
+	FillInPlace(in, 0)
+	r.Equal([]int{0, 0, 0}, in)
+	r.Empty(Fill([]int(nil), 1))
+}
+
+func TestReplace(t *testing.T) {
+	r := require.New(t)
+	in := []string{"a", "b", "a"}
+	r.Equal([]string{"x", "b", "a"}, ReplaceFirst(in, "a", "x"))
+	r.Equal([]string{"x", "b", "x"}, ReplaceAll(in, "a", "x"))
+	r.Equal([]string{"a", "b", "a"}, ReplaceFirst(in, "z", "x"))
+	r.Equal([]string{"a", "b", "a"}, in)
+
+	r.True(ReplaceFirstInPlace(in, "b", "y"))
+	r.False(ReplaceFirstInPlace(in, "b", "y"))
+	r.Equal(2, ReplaceAllInPlace(in, "a", "x"))
+	r.Equal([]string{"x", "y", "x"}, in)
+}
+
+func TestReplaceWhere(t *testing.T) {
+	r := require.New(t)
+	neg := func(i int) bool { return i < 0 }
+	in := []int{-1, 2, -3}
+	r.Equal([]int{0, 2, 0}, ReplaceWhere(in, neg, 0))
+	r.Equal([]int{-1, 2, -3}, in)
+	r.Equal(2, ReplaceWhereInPlace(in, neg, 0))
+	r.Equal([]int{0, 2, 0}, in)
+}
@@ -0,0 +1,103 @@
+package stats
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/go-functional/core/constraints"
+)
+
+// Histogram counts how many values fall in each bin between consecutive
+// edges. Bin i counts the values v with edges[i] <= v < edges[i+1], except
+// the last bin, which also counts values equal to the last edge. Values
+// outside the edges, and NaN values, aren't counted. The result has
+// len(edges)-1 elements.
+//
+// Returns an error if there are fewer than two edges, or they aren't sorted
+// in strictly increasing order, which includes any edge being NaN. Empty
+// values is fine, and gives all-zero counts.
+//
+// Example usage, bucketing latencies in milliseconds:
+//
+//	counts, err := Histogram(latencies, []int{0, 10, 50, 100, 1000})
+func Histogram[N constraints.Number](values []N, edges []N) ([]int, error) {
+	if len(edges) < 2 {
+		return nil, errors.New("Histogram called with fewer than two edges")
+	}
+	for i := 1; i < len(edges); i++ {
+		if !(edges[i] > edges[i-1]) {
+			return nil, errors.New("Histogram called with edges that aren't strictly increasing")
+		}
+	}
+	counts := make([]int, len(edges)-1)
+	last := edges[len(edges)-1]
+	for _, v := range values {
+		// written this way round so that NaN is skipped too
+		if !(v >= edges[0] && v <= last) {
+			continue
+		}
+		if v == last {
+			counts[len(counts)-1]++
+			continue
+		}
+		// the first edge greater than v closes v's bin
+		i := sort.Search(len(edges), func(i int) bool { return edges[i] > v })
+		counts[i-1]++
+	}
+	return counts, nil
+}
+
+// Bucket is a bin of width values starting at Lo, and the number of values
+// that fell in it
+type Bucket[N constraints.Number] struct {
+	Lo    N
+	Count int
+}
+
+// Bucketize sorts values into bins that are each width wide and aligned to
+// multiples of width, so a value v lands in the bin starting at
+// floor(v/width)*width. It returns the bins that have any values in them,
+// in increasing order. NaN values aren't counted. Returns an error if width
+// isn't positive.
+//
+// Example usage:
+//
+//	Bucketize([]int{1, 4, 5, 12}, 5) // [{0 2} {5 1} {10 1}]
+func Bucketize[N constraints.Number](values []N, width N) ([]Bucket[N], error) {
+	if !(width > 0) {
+		return nil, errors.New("Bucketize called with a width that isn't positive")
+	}
+	counts := map[N]int{}
+	// N(1)/N(2) is only non-zero when N is a float type
+	isFloat := N(1)/N(2) != 0
+	for _, v := range values {
+		if math.IsNaN(float64(v)) {
+			// NaN != NaN, so every one would get a map key of its own
+			continue
+		}
+		var lo N
+		if isFloat {
+			lo = N(math.Floor(float64(v)/float64(width))) * width
+		} else {
+			// integer division, since float64 can't hold every int64. Go's
+			// division truncates towards zero, so round negative values down
+			q := v / width
+			if v < 0 && q*width != v {
+				q--
+			}
+			lo = q * width
+		}
+		counts[lo]++
+	}
+	ret := make([]Bucket[N], 0, len(counts))
+	for lo, n := range counts {
+		ret = append(ret, Bucket[N]{Lo: lo, Count: n})
+	}
+	slices.SortFunc(ret, func(a, b Bucket[N]) int {
+		return cmp.Compare(a.Lo, b.Lo)
+	})
+	return ret, nil
+}
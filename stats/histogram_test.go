@@ -0,0 +1,63 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistogram(t *testing.T) {
+	r := require.New(t)
+	counts, err := Histogram([]int{0, 5, 10, 49, 50, 100, 1000, -1, 1001}, []int{0, 10, 50, 100, 1000})
+	r.NoError(err)
+	r.Equal([]int{2, 2, 1, 2}, counts)
+
+	counts, err = Histogram([]float64{}, []float64{0, 0.5, 1})
+	r.NoError(err)
+	r.Equal([]int{0, 0}, counts)
+
+	counts, err = Histogram([]float64{1, math.NaN(), 2}, []float64{0, 1, 2})
+	r.NoError(err)
+	r.Equal([]int{0, 2}, counts)
+	_, err = Histogram([]float64{1}, []float64{0, math.NaN(), 2})
+	r.Error(err)
+
+	_, err = Histogram([]int{1}, []int{1})
+	r.Error(err)
+	_, err = Histogram([]int{1}, []int{0, 2, 2})
+	r.Error(err)
+}
+
+func TestBucketize(t *testing.T) {
+	r := require.New(t)
+	buckets, err := Bucketize([]int{1, 4, 5, 12}, 5)
+	r.NoError(err)
+	r.Equal([]Bucket[int]{{0, 2}, {5, 1}, {10, 1}}, buckets)
+
+	floats, err := Bucketize([]float64{-0.1, 0.1, 0.3}, 0.25)
+	r.NoError(err)
+	r.Equal([]Bucket[float64]{{-0.25, 1}, {0, 1}, {0.25, 1}}, floats)
+
+	buckets, err = Bucketize([]int{-1, -5, -6}, 5)
+	r.NoError(err)
+	r.Equal([]Bucket[int]{{-10, 1}, {-5, 2}}, buckets)
+
+	floats, err = Bucketize([]float64{math.NaN(), 1, math.NaN()}, 1)
+	r.NoError(err)
+	r.Equal([]Bucket[float64]{{1, 1}}, floats)
+	_, err = Bucketize([]float64{1}, math.NaN())
+	r.Error(err)
+
+	// above 2^53, where float64 can't tell neighbouring int64s apart
+	big := int64(1)<<53 + 1
+	wide, err := Bucketize([]int64{big, big + 1}, 1)
+	r.NoError(err)
+	r.Equal([]Bucket[int64]{{big, 1}, {big + 1, 1}}, wide)
+
+	buckets, err = Bucketize([]int(nil), 1)
+	r.NoError(err)
+	r.Empty(buckets)
+	_, err = Bucketize([]int{1}, 0)
+	r.Error(err)
+}
@@ -0,0 +1,201 @@
+// Package stats provides summary statistics over numeric slices, for things
+// like analyzing benchmark timings and summarizing metrics.
+//
+// Every function here leaves its input unmodified. The summary statistics
+// return a descriptive, non-nil error when the input is empty; the binning
+// functions, Histogram and Bucketize, treat it as having no values.
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/go-functional/core/constraints"
+)
+
+var errEmpty = errors.New("stats called on empty slice")
+
+// Mean returns the arithmetic mean of slc
+func Mean[N constraints.Number](slc []N) (float64, error) {
+	if len(slc) == 0 {
+		return 0, errEmpty
+	}
+	sum := 0.0
+	for _, n := range slc {
+		sum += float64(n)
+	}
+	return sum / float64(len(slc)), nil
+}
+
+// Median returns the middle value of slc, or the mean of the two middle values
+// if slc has an even number of elements.
+//
+// Median uses a selection algorithm rather than sorting, so it runs in
+// expected O(n) time.
+func Median[N constraints.Number](slc []N) (float64, error) {
+	return Percentile(slc, 50)
+}
+
+// Variance returns the population variance of slc, which is the mean of the
+// squared differences from the mean. Use SampleVariance if slc is a sample of
+// a larger population
+func Variance[N constraints.Number](slc []N) (float64, error) {
+	ss, err := sumSquares(slc)
+	if err != nil {
+		return 0, err
+	}
+	return ss / float64(len(slc)), nil
+}
+
+// SampleVariance returns the sample variance of slc, which divides by n-1
+// instead of n to correct for estimating the mean from the sample. slc must
+// have at least two elements
+func SampleVariance[N constraints.Number](slc []N) (float64, error) {
+	if len(slc) == 1 {
+		return 0, errors.New("SampleVariance called on a slice with one element")
+	}
+	ss, err := sumSquares(slc)
+	if err != nil {
+		return 0, err
+	}
+	return ss / float64(len(slc)-1), nil
+}
+
+// StdDev returns the population standard deviation of slc, which is the
+// square root of its Variance
+func StdDev[N constraints.Number](slc []N) (float64, error) {
+	v, err := Variance(slc)
+	if err != nil {
+		return 0, err
+	}
+	return math.Sqrt(v), nil
+}
+
+func sumSquares[N constraints.Number](slc []N) (float64, error) {
+	mean, err := Mean(slc)
+	if err != nil {
+		return 0, err
+	}
+	ss := 0.0
+	for _, n := range slc {
+		d := float64(n) - mean
+		ss += d * d
+	}
+	return ss, nil
+}
+
+// Percentile returns the p-th percentile of slc, where p is between 0 and 100.
+// When the percentile falls between two elements, the result is linearly
+// interpolated between them, so Percentile(slc, 50) is the median.
+//
+// Like Median, Percentile runs in expected O(n) time. Use Quantiles to
+// compute several at once, which sorts once instead.
+//
+// Example usage:
+//
+//	p99, err := Percentile(latencies, 99)
+func Percentile[N constraints.Number](slc []N, p float64) (float64, error) {
+	if len(slc) == 0 {
+		return 0, errEmpty
+	}
+	if p < 0 || p > 100 || math.IsNaN(p) {
+		return 0, fmt.Errorf("Percentile called with %v, which isn't between 0 and 100", p)
+	}
+	xs := toFloats(slc)
+	rank := p / 100 * float64(len(xs)-1)
+	lo := int(math.Floor(rank))
+	lower := quickselect(xs, lo)
+	if frac := rank - float64(lo); frac > 0 {
+		// after selecting, everything after lo is at least lower, so the next
+		// order statistic is the smallest of them
+		upper := slices.Min(xs[lo+1:])
+		return lower + frac*(upper-lower), nil
+	}
+	return lower, nil
+}
+
+// Quantiles returns the quantiles of slc at each of qs, where each q is
+// between 0 and 1. They're interpolated the same way as Percentile, so
+// Quantiles(slc, []float64{0.5}) is the median. slc is sorted once in
+// O(n log n), so this is cheaper than calling Percentile for each q when
+// there are many of them.
+//
+// Example usage:
+//
+//	qs, err := Quantiles(latencies, []float64{0.5, 0.9, 0.99})
+func Quantiles[N constraints.Number](slc []N, qs []float64) ([]float64, error) {
+	if len(slc) == 0 {
+		return nil, errEmpty
+	}
+	xs := toFloats(slc)
+	slices.Sort(xs)
+	ret := make([]float64, len(qs))
+	for i, q := range qs {
+		if q < 0 || q > 1 || math.IsNaN(q) {
+			return nil, fmt.Errorf("Quantiles called with %v, which isn't between 0 and 1", q)
+		}
+		rank := q * float64(len(xs)-1)
+		lo := int(math.Floor(rank))
+		ret[i] = xs[lo]
+		if frac := rank - float64(lo); frac > 0 {
+			ret[i] += frac * (xs[lo+1] - xs[lo])
+		}
+	}
+	return ret, nil
+}
+
+func toFloats[N constraints.Number](slc []N) []float64 {
+	ret := make([]float64, len(slc))
+	for i, n := range slc {
+		ret[i] = float64(n)
+	}
+	return ret
+}
+
+// quickselect partially reorders xs so that xs[k] holds the value that would
+// be there if xs were sorted, everything before it is no greater and
+// everything after it is no smaller. Returns xs[k]
+func quickselect(xs []float64, k int) float64 {
+	lo, hi := 0, len(xs)-1
+	for lo < hi {
+		// use the median of three as the pivot to avoid quadratic behavior on
+		// already-sorted input
+		mid := lo + (hi-lo)/2
+		if xs[mid] < xs[lo] {
+			xs[mid], xs[lo] = xs[lo], xs[mid]
+		}
+		if xs[hi] < xs[lo] {
+			xs[hi], xs[lo] = xs[lo], xs[hi]
+		}
+		if xs[hi] < xs[mid] {
+			xs[hi], xs[mid] = xs[mid], xs[hi]
+		}
+		pivot := xs[mid]
+
+		i, j := lo, hi
+		for i <= j {
+			for xs[i] < pivot {
+				i++
+			}
+			for xs[j] > pivot {
+				j--
+			}
+			if i <= j {
+				xs[i], xs[j] = xs[j], xs[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case k <= j:
+			hi = j
+		case k >= i:
+			lo = i
+		default:
+			return xs[k]
+		}
+	}
+	return xs[k]
+}
@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeanVariance(t *testing.T) {
+	r := require.New(t)
+	xs := []int{2, 4, 4, 4, 5, 5, 7, 9}
+	mean, err := Mean(xs)
+	r.NoError(err)
+	r.Equal(5.0, mean)
+	v, err := Variance(xs)
+	r.NoError(err)
+	r.Equal(4.0, v)
+	sd, err := StdDev(xs)
+	r.NoError(err)
+	r.Equal(2.0, sd)
+	sv, err := SampleVariance(xs)
+	r.NoError(err)
+	r.InDelta(32.0/7, sv, 1e-9)
+
+	_, err = Variance([]int{})
+	r.Error(err)
+	_, err = SampleVariance([]int{1})
+	r.Error(err)
+}
+
+func TestMedianPercentile(t *testing.T) {
+	r := require.New(t)
+	m, err := Median([]int{5, 1, 3})
+	r.NoError(err)
+	r.Equal(3.0, m)
+	m, err = Median([]int{4, 1, 3, 2})
+	r.NoError(err)
+	r.Equal(2.5, m)
+
+	xs := []float64{3, 1, 2}
+	p, err := Percentile(xs, 100)
+	r.NoError(err)
+	r.Equal(3.0, p)
+	r.Equal([]float64{3, 1, 2}, xs, "input must not be modified")
+	_, err = Percentile(xs, 101)
+	r.Error(err)
+	_, err = Median([]int{})
+	r.Error(err)
+}
+
+func TestPercentileMatchesQuantiles(t *testing.T) {
+	r := require.New(t)
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		xs := make([]int, 1+rnd.Intn(50))
+		for j := range xs {
+			xs[j] = rnd.Intn(10)
+		}
+		qs, err := Quantiles(xs, []float64{0, 0.25, 0.5, 0.9, 1})
+		r.NoError(err)
+		for j, p := range []float64{0, 25, 50, 90, 100} {
+			got, err := Percentile(xs, p)
+			r.NoError(err)
+			r.InDelta(qs[j], got, 1e-9)
+		}
+		sorted := slices.Clone(xs)
+		slices.Sort(sorted)
+		r.Equal(float64(sorted[0]), qs[0])
+		r.Equal(float64(sorted[len(sorted)-1]), qs[4])
+	}
+}
@@ -0,0 +1,95 @@
+// Package strslice provides helpers for slices of strings, like
+// case-insensitive membership and sorting, built on the generic primitives
+// in package slice.
+//
+// Case-insensitive comparisons here use Unicode case folding, the same as
+// strings.EqualFold, so "Straße" and "STRASSE" are different but "K" and the
+// Kelvin sign are the same.
+package strslice
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/go-functional/core/slice"
+)
+
+// foldKey returns a string that's the same for two strings exactly when
+// strings.EqualFold says they're equal, by replacing every rune with the
+// smallest rune in its case folding orbit
+func foldKey(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		least := r
+		for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+			least = min(least, f)
+		}
+		b.WriteRune(least)
+	}
+	return b.String()
+}
+
+// ContainsFold returns true if any element of slc equals s, ignoring case
+func ContainsFold(slc []string, s string) bool {
+	return slice.ContainsBy(slc, func(e string) bool { return strings.EqualFold(e, s) })
+}
+
+// DistinctFold returns the elements of slc with case-insensitive duplicates
+// removed, keeping the first of each in its original case and order.
+//
+// Example usage:
+//
+//	DistinctFold([]string{"Go", "rust", "GO", "Rust"}) // ["Go", "rust"]
+func DistinctFold(slc []string) []string {
+	if slc == nil {
+		return nil
+	}
+	seen := make(map[string]struct{}, len(slc))
+	ret := make([]string, 0, len(slc))
+	for _, s := range slc {
+		k := foldKey(s)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+// SortFold returns a copy of slc sorted in case-insensitive order. Strings
+// that are equal ignoring case are sorted by their bytes, so the order
+// doesn't depend on the order of the input
+func SortFold(slc []string) []string {
+	type keyed struct{ key, s string }
+	ks := make([]keyed, len(slc))
+	for i, s := range slc {
+		ks[i] = keyed{key: foldKey(s), s: s}
+	}
+	slices.SortFunc(ks, func(a, b keyed) int {
+		return cmp.Or(strings.Compare(a.key, b.key), strings.Compare(a.s, b.s))
+	})
+	ret := slice.Clone(slc)
+	for i, k := range ks {
+		ret[i] = k.s
+	}
+	return ret
+}
+
+// TrimSpaceAll returns a copy of slc with leading and trailing white space
+// removed from every element
+func TrimSpaceAll(slc []string) []string {
+	ret, _ := slice.Map(slc, func(_ uint, s string) (string, error) {
+		return strings.TrimSpace(s), nil
+	})
+	return ret
+}
+
+// FilterNonEmpty returns the elements of slc that aren't empty strings, in
+// order. Combine it with TrimSpaceAll to drop blank strings too
+func FilterNonEmpty(slc []string) []string {
+	return slice.FilterInto(nil, slc, func(s string) bool { return s != "" })
+}
@@ -0,0 +1,37 @@
+package strslice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestContainsFold(t *testing.T) {
+	r := require.New(t)
+	r.True(ContainsFold([]string{"Go", "Rust"}, "GO"))
+	r.True(ContainsFold([]string{"K"}, "k"))
+	r.False(ContainsFold([]string{"Go"}, "Gopher"))
+}
+
+func TestDistinctFold(t *testing.T) {
+	r := require.New(t)
+	r.Equal([]string{"Go", "rust"}, DistinctFold([]string{"Go", "rust", "GO", "Rust"}))
+	r.Equal([]string{"k"}, DistinctFold([]string{"k", "K", "K"}))
+	r.Nil(DistinctFold(nil))
+}
+
+func TestSortFold(t *testing.T) {
+	r := require.New(t)
+	in := []string{"banana", "Apple", "cherry", "apple"}
+	r.Equal([]string{"Apple", "apple", "banana", "cherry"}, SortFold(in))
+	r.Equal([]string{"banana", "Apple", "cherry", "apple"}, in)
+	r.Equal(SortFold([]string{"b", "B", "a"}), SortFold([]string{"B", "a", "b"}))
+}
+
+func TestTrimAndFilter(t *testing.T) {
+	r := require.New(t)
+	in := []string{" a ", "\t", "b\n", ""}
+	r.Equal([]string{"a", "", "b", ""}, TrimSpaceAll(in))
+	r.Equal([]string{"a", "b"}, FilterNonEmpty(TrimSpaceAll(in)))
+	r.Equal([]string{" a ", "\t", "b\n"}, FilterNonEmpty(in))
+}
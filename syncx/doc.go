@@ -0,0 +1,4 @@
+// Package syncx provides synchronization primitives that complement the
+// standard library's sync package, like semaphores and rate limiters that
+// can be shared between several parallel call sites.
+package syncx
@@ -0,0 +1,122 @@
+package syncx
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter. The bucket holds up to burst
+// tokens, is refilled at a steady rate, and each event takes one token, so
+// events can happen at the rate on average, with bursts of up to burst at
+// once
+type Limiter struct {
+	mut sync.Mutex
+	// perNano is the refill rate in tokens per nanosecond. It's kept as a
+	// float, since a rate above one per nanosecond has no Duration interval
+	perNano float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	now     func() time.Time
+}
+
+// NewLimiter creates a Limiter that allows up to perSecond events per second
+// on average, and bursts of up to burst events. The bucket starts full. It
+// panics if perSecond isn't positive or burst is less than 1
+func NewLimiter(perSecond float64, burst int) *Limiter {
+	// written this way round so a NaN perSecond panics too
+	if !(perSecond > 0) {
+		panic("syncx: NewLimiter called with a perSecond <= 0")
+	}
+	if burst < 1 {
+		panic("syncx: NewLimiter called with a burst < 1")
+	}
+	b := float64(burst)
+	return &Limiter{
+		perNano: perSecond / float64(time.Second),
+		burst:   b,
+		tokens:  b,
+		last:    time.Now(),
+		now:     time.Now,
+	}
+}
+
+// refill adds the tokens that accumulated since the last call. l.mut must be
+// held
+func (l *Limiter) refill() time.Time {
+	now := l.now()
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+float64(elapsed)*l.perNano)
+	}
+	return now
+}
+
+// Allow takes a token and returns true if one is available right now, and
+// returns false otherwise
+func (l *Limiter) Allow() bool {
+	l.mut.Lock()
+	defer l.mut.Unlock()
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		return true
+	}
+	return false
+}
+
+// Wait blocks until a token is available, takes it and returns nil. Returns
+// ctx.Err() without taking a token if ctx is done first. Waiters are served
+// in the order they called Wait
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mut.Lock()
+	l.refill()
+	// take the token now, even if it puts the bucket in debt, so that
+	// concurrent waiters line up behind each other
+	l.tokens--
+	var delay time.Duration
+	if l.tokens < 0 {
+		// at very low rates the wait is longer than a Duration can hold, and
+		// converting it would overflow to a negative delay
+		if d := -l.tokens / l.perNano; d >= math.MaxInt64 {
+			delay = math.MaxInt64
+		} else {
+			delay = time.Duration(d)
+		}
+	}
+	l.mut.Unlock()
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.mut.Lock()
+		l.tokens++
+		l.mut.Unlock()
+		return ctx.Err()
+	}
+}
+
+// WithLimiter wraps fn, which has the same signature ParMap expects, so that
+// every call waits for a token from l first. If ctx is done before a token is
+// available, the call returns ctx.Err() without calling fn
+func WithLimiter[T, U any](
+	l *Limiter,
+	fn func(context.Context, uint, T) (U, error),
+) func(context.Context, uint, T) (U, error) {
+	return func(ctx context.Context, i uint, t T) (U, error) {
+		if err := l.Wait(ctx); err != nil {
+			var zero U
+			return zero, err
+		}
+		return fn(ctx, i, t)
+	}
+}
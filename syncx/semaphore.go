@@ -0,0 +1,71 @@
+package syncx
+
+import "context"
+
+// Semaphore limits how many holders can hold it at the same time. A single
+// Semaphore can be shared between any number of ParMap calls, or other
+// goroutines, to give them one combined concurrency budget
+type Semaphore struct {
+	slots chan struct{}
+}
+
+// NewSemaphore creates a Semaphore that can be held by up to n holders at
+// once. n less than 1 is treated as 1
+func NewSemaphore(n int) *Semaphore {
+	return &Semaphore{slots: make(chan struct{}, max(n, 1))}
+}
+
+// Acquire blocks until the Semaphore can be held, and returns nil. Returns
+// ctx.Err() without acquiring if ctx is done first
+func (s *Semaphore) Acquire(ctx context.Context) error {
+	select {
+	case s.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TryAcquire acquires the Semaphore and returns true if it can do so without
+// blocking, and returns false otherwise
+func (s *Semaphore) TryAcquire() bool {
+	select {
+	case s.slots <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release gives up one hold on the Semaphore. It panics if the Semaphore
+// isn't held, since that means Acquire and Release calls are mismatched
+func (s *Semaphore) Release() {
+	select {
+	case <-s.slots:
+	default:
+		panic("syncx: Release called on a Semaphore that isn't held")
+	}
+}
+
+// WithSemaphore wraps fn, which has the same signature ParMap expects, so
+// that every call holds sem for as long as fn runs. If ctx is done before sem
+// can be acquired, the call returns ctx.Err() without calling fn.
+//
+// Example usage, capping two ParMap calls at 10 requests in total:
+//
+//	sem := NewSemaphore(10)
+//	go slice.ParMap(ctx, users, WithSemaphore(sem, fetchUser))
+//	go slice.ParMap(ctx, orders, WithSemaphore(sem, fetchOrder))
+func WithSemaphore[T, U any](
+	sem *Semaphore,
+	fn func(context.Context, uint, T) (U, error),
+) func(context.Context, uint, T) (U, error) {
+	return func(ctx context.Context, i uint, t T) (U, error) {
+		if err := sem.Acquire(ctx); err != nil {
+			var zero U
+			return zero, err
+		}
+		defer sem.Release()
+		return fn(ctx, i, t)
+	}
+}
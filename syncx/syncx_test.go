@@ -0,0 +1,109 @@
+package syncx
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-functional/core/slice"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSemaphore(t *testing.T) {
+	r := require.New(t)
+	sem := NewSemaphore(2)
+	r.True(sem.TryAcquire())
+	r.NoError(sem.Acquire(context.Background()))
+	r.False(sem.TryAcquire())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	r.ErrorIs(sem.Acquire(ctx), context.DeadlineExceeded)
+	sem.Release()
+	r.True(sem.TryAcquire())
+	sem.Release()
+	sem.Release()
+	r.Panics(sem.Release)
+}
+
+func TestWithSemaphore(t *testing.T) {
+	r := require.New(t)
+	sem := NewSemaphore(3)
+	var running, peak atomic.Int64
+	fn := WithSemaphore(sem, func(_ context.Context, _ uint, i int) (int, error) {
+		cur := running.Add(1)
+		defer running.Add(-1)
+		for old := peak.Load(); cur > old && !peak.CompareAndSwap(old, cur); old = peak.Load() {
+		}
+		time.Sleep(time.Millisecond)
+		return i, nil
+	})
+	done := make(chan struct{})
+	for j := 0; j < 2; j++ {
+		go func() {
+			_, err := slice.ParMap(context.Background(), slice.RangeN(20), fn)
+			r.NoError(err)
+			done <- struct{}{}
+		}()
+	}
+	<-done
+	<-done
+	r.LessOrEqual(peak.Load(), int64(3))
+}
+
+func TestLimiter(t *testing.T) {
+	r := require.New(t)
+	now := time.Unix(0, 0)
+	l := NewLimiter(10, 2)
+	l.now = func() time.Time { return now }
+	l.last = now
+
+	r.True(l.Allow())
+	r.True(l.Allow())
+	r.False(l.Allow())
+	now = now.Add(100 * time.Millisecond)
+	r.True(l.Allow())
+	r.False(l.Allow())
+
+	fast := NewLimiter(1000, 1)
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		r.NoError(fast.Wait(context.Background()))
+	}
+	r.GreaterOrEqual(time.Since(start), 3*time.Millisecond)
+
+	slow := NewLimiter(0.001, 1)
+	r.True(slow.Allow())
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	r.ErrorIs(slow.Wait(ctx), context.DeadlineExceeded)
+
+	// a wait too long for a Duration still waits, rather than overflowing
+	glacial := NewLimiter(1e-10, 1)
+	r.True(glacial.Allow())
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	r.ErrorIs(glacial.Wait(ctx), context.DeadlineExceeded)
+
+	// faster than one token per nanosecond, with no time passing between
+	// calls
+	huge := NewLimiter(1e12, 3)
+	huge.now = func() time.Time { return now }
+	huge.last = now
+	for i := 0; i < 3; i++ {
+		r.True(huge.Allow())
+	}
+	r.False(huge.Allow())
+	now = now.Add(time.Nanosecond)
+	r.True(huge.Allow())
+	r.NoError(huge.Wait(context.Background()))
+	// the bucket refilled to burst, then Allow and Wait each took a token
+	r.Equal(float64(1), huge.tokens)
+
+	r.Panics(func() { NewLimiter(0, 1) })
+	r.Panics(func() { NewLimiter(-1, 1) })
+	r.Panics(func() { NewLimiter(math.NaN(), 1) })
+	r.Panics(func() { NewLimiter(1, 0) })
+}
@@ -0,0 +1,62 @@
+package syncx
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/go-functional/core/errs"
+)
+
+// WaitGroupE is a sync.WaitGroup that collects the errors its goroutines
+// return. Unlike an errgroup, it has no context and doesn't stop anything
+// when a goroutine fails; it just runs every goroutine to completion and
+// reports everything that went wrong. The zero value is ready to use.
+//
+// Example usage:
+//
+//	var wg WaitGroupE
+//	for _, f := range files {
+//		wg.Go(func() error { return upload(f) })
+//	}
+//	if err := wg.Wait(); err != nil {
+//		log.Printf("some uploads failed: %v", err)
+//	}
+type WaitGroupE struct {
+	wg   sync.WaitGroup
+	mut  sync.Mutex
+	errs []error
+}
+
+// Go calls fn in a new goroutine. If fn returns an error, or panics, Wait
+// will return it. A panic is recovered and recorded as a *errs.PanicError,
+// so it doesn't crash the program
+func (w *WaitGroupE) Go(fn func() error) {
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer func() {
+			if v := recover(); v != nil {
+				w.record(errs.NewPanicError(v))
+			}
+		}()
+		if err := fn(); err != nil {
+			w.record(err)
+		}
+	}()
+}
+
+func (w *WaitGroupE) record(err error) {
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	w.errs = append(w.errs, err)
+}
+
+// Wait blocks until every goroutine started with Go has returned, and
+// returns every error they returned or panicked with, joined with
+// errors.Join. Returns nil if none of them failed
+func (w *WaitGroupE) Wait() error {
+	w.wg.Wait()
+	w.mut.Lock()
+	defer w.mut.Unlock()
+	return errors.Join(w.errs...)
+}
@@ -0,0 +1,34 @@
+package syncx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-functional/core/errs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitGroupE(t *testing.T) {
+	r := require.New(t)
+	var ok WaitGroupE
+	r.NoError(ok.Wait())
+	for i := 0; i < 5; i++ {
+		ok.Go(func() error { return nil })
+	}
+	r.NoError(ok.Wait())
+
+	errA := errors.New("a")
+	errB := errors.New("b")
+	var wg WaitGroupE
+	wg.Go(func() error { return errA })
+	wg.Go(func() error { return nil })
+	wg.Go(func() error { panic(errB) })
+	wg.Go(func() error { panic("boom") })
+	err := wg.Wait()
+	r.ErrorIs(err, errA)
+	r.ErrorIs(err, errB)
+	var pe *errs.PanicError
+	r.ErrorAs(err, &pe)
+	r.Len(err.(interface{ Unwrap() []error }).Unwrap(), 3)
+	r.Contains(err.Error(), "boom")
+}
@@ -0,0 +1,135 @@
+// Package taskgroup runs a group of goroutines that each produce a typed
+// result, and collects those results, without the shared-slice-plus-index
+// bookkeeping that errgroup needs.
+package taskgroup
+
+import (
+	"context"
+	"sync"
+)
+
+// Order decides the order of the results Wait returns
+type Order int
+
+const (
+	// SubmissionOrder returns results in the order their tasks were passed
+	// to Go. This is the default
+	SubmissionOrder Order = iota
+	// CompletionOrder returns results in the order their tasks finished
+	CompletionOrder
+)
+
+// Option configures a Group
+type Option func(*config)
+
+type config struct {
+	limit int
+	order Order
+}
+
+// Limit caps the number of tasks that run at the same time at n. Once n
+// tasks are running, Go blocks until one of them finishes. n <= 0 means no
+// limit, which is the default
+func Limit(n int) Option {
+	return func(c *config) {
+		c.limit = n
+	}
+}
+
+// WithOrder sets the order of the results Wait returns
+func WithOrder(o Order) Option {
+	return func(c *config) {
+		c.order = o
+	}
+}
+
+// Group is a collection of tasks that each return a value of type T. Like an
+// errgroup.Group, the first task to fail cancels the context passed to the
+// rest, and Wait returns that failure.
+//
+// Example usage:
+//
+//	g := New[*User](ctx, Limit(8))
+//	for _, id := range ids {
+//		g.Go(func(ctx context.Context) (*User, error) {
+//			return client.GetUser(ctx, id)
+//		})
+//	}
+//	users, err := g.Wait()
+type Group[T any] struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	cfg    config
+	sem    chan struct{}
+	wg     sync.WaitGroup
+
+	mut     sync.Mutex
+	results []T
+	err     error
+}
+
+// New creates a Group whose tasks are passed a context derived from ctx
+func New[T any](ctx context.Context, opts ...Option) *Group[T] {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	g := &Group[T]{ctx: ctx, cancel: cancel, cfg: cfg}
+	if cfg.limit > 0 {
+		g.sem = make(chan struct{}, cfg.limit)
+	}
+	return g
+}
+
+// Go runs fn in a new goroutine. If fn returns an error and it's the first
+// task in the group to do so, the group's context is cancelled
+func (g *Group[T]) Go(fn func(ctx context.Context) (T, error)) {
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	idx := -1
+	if g.cfg.order == SubmissionOrder {
+		var zero T
+		g.mut.Lock()
+		idx = len(g.results)
+		g.results = append(g.results, zero)
+		g.mut.Unlock()
+	}
+
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if g.sem != nil {
+			defer func() { <-g.sem }()
+		}
+		v, err := fn(g.ctx)
+		g.mut.Lock()
+		defer g.mut.Unlock()
+		switch {
+		case err != nil:
+			if g.err == nil {
+				g.err = err
+				g.cancel()
+			}
+		case idx >= 0:
+			g.results[idx] = v
+		default:
+			g.results = append(g.results, v)
+		}
+	}()
+}
+
+// Wait blocks until every task passed to Go has returned. It returns the
+// first error a task returned along with a nil slice, or every task's result
+// in the Group's Order if they all succeeded
+func (g *Group[T]) Wait() ([]T, error) {
+	g.wg.Wait()
+	g.cancel()
+	g.mut.Lock()
+	defer g.mut.Unlock()
+	if g.err != nil {
+		return nil, g.err
+	}
+	return g.results, nil
+}
@@ -0,0 +1,64 @@
+package taskgroup
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubmissionOrder(t *testing.T) {
+	r := require.New(t)
+	g := New[int](context.Background())
+	for i := 0; i < 5; i++ {
+		g.Go(func(context.Context) (int, error) {
+			time.Sleep(time.Duration(5-i) * time.Millisecond)
+			return i, nil
+		})
+	}
+	res, err := g.Wait()
+	r.NoError(err)
+	r.Equal([]int{0, 1, 2, 3, 4}, res)
+}
+
+func TestCompletionOrder(t *testing.T) {
+	r := require.New(t)
+	g := New[int](context.Background(), WithOrder(CompletionOrder))
+	for i := 0; i < 3; i++ {
+		g.Go(func(context.Context) (int, error) {
+			time.Sleep(time.Duration(3-i) * 10 * time.Millisecond)
+			return i, nil
+		})
+	}
+	res, err := g.Wait()
+	r.NoError(err)
+	r.Equal([]int{2, 1, 0}, res)
+}
+
+func TestLimitAndError(t *testing.T) {
+	r := require.New(t)
+	var running, peak atomic.Int64
+	g := New[int](context.Background(), Limit(2))
+	boom := errors.New("boom")
+	for i := 0; i < 6; i++ {
+		g.Go(func(ctx context.Context) (int, error) {
+			cur := running.Add(1)
+			defer running.Add(-1)
+			if cur > peak.Load() {
+				peak.Store(cur)
+			}
+			if i == 3 {
+				return 0, boom
+			}
+			time.Sleep(time.Millisecond)
+			return i, ctx.Err()
+		})
+	}
+	res, err := g.Wait()
+	r.ErrorIs(err, boom)
+	r.Nil(res)
+	r.LessOrEqual(peak.Load(), int64(2))
+}
@@ -0,0 +1,107 @@
+// Package textio processes text streams a line at a time, so files and
+// standard input can be handled with the same combinators as slices without
+// reading them into memory first.
+package textio
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"iter"
+	"strings"
+)
+
+// readLines calls yield with every line in r, without its line ending, until
+// r is exhausted or yield returns false. Both "\n" and "\r\n" line endings
+// are recognized, and a final line with no line ending is still yielded.
+// There's no limit on line length. Returns the first read error other than
+// io.EOF
+func readLines(r io.Reader, yield func(string) bool) error {
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimSuffix(line, "\n")
+			line = strings.TrimSuffix(line, "\r")
+			if !yield(line) {
+				return nil
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Lines returns a sequence of the lines in r, without their line endings.
+// Lines are read lazily, as the sequence is consumed, and r is only read
+// once, so the sequence can only be ranged over once.
+//
+// A read error ends the sequence early. Use MapLines, or read r yourself,
+// when the difference between the end of r and a failed read matters.
+//
+// Example usage:
+//
+//	for line := range textio.Lines(os.Stdin) {
+//		fmt.Println(strings.ToUpper(line))
+//	}
+func Lines(r io.Reader) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		_ = readLines(r, yield)
+	}
+}
+
+// MapLines reads r a line at a time, calls fn with every line, without its
+// line ending, and writes each result to w followed by "\n". Only one line is
+// held in memory at a time.
+//
+// MapLines stops and returns the error if fn returns one, if reading r or
+// writing w fails, or if ctx is done, in which case it returns ctx.Err().
+// Results written before the error stay written.
+//
+// Example usage:
+//
+//	err := textio.MapLines(ctx, os.Stdin, func(line string) (string, error) {
+//		return strings.ToUpper(line), nil
+//	}, os.Stdout)
+func MapLines(
+	ctx context.Context,
+	r io.Reader,
+	fn func(string) (string, error),
+	w io.Writer,
+) error {
+	bw := bufio.NewWriter(w)
+	var fail error
+	err := readLines(r, func(line string) bool {
+		if fail = ctx.Err(); fail != nil {
+			return false
+		}
+		out, err := fn(line)
+		if err != nil {
+			fail = err
+			return false
+		}
+		if _, err := bw.WriteString(out); err != nil {
+			fail = err
+			return false
+		}
+		if fail = bw.WriteByte('\n'); fail != nil {
+			return false
+		}
+		return true
+	})
+	if fail != nil {
+		// flush what was written before the failure, but report the failure
+		_ = bw.Flush()
+		return fail
+	}
+	if err != nil {
+		_ = bw.Flush()
+		return err
+	}
+	return bw.Flush()
+}
@@ -0,0 +1,67 @@
+package textio
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	r := require.New(t)
+	in := "a\nb\r\n\nlast"
+	r.Equal([]string{"a", "b", "", "last"}, slices.Collect(Lines(strings.NewReader(in))))
+	r.Empty(slices.Collect(Lines(strings.NewReader(""))))
+	r.Equal([]string{"a"}, slices.Collect(Lines(strings.NewReader("a\n"))))
+
+	long := strings.Repeat("x", 1<<20)
+	r.Equal([]string{long}, slices.Collect(Lines(strings.NewReader(long))))
+
+	for line := range Lines(strings.NewReader(in)) {
+		r.Equal("a", line)
+		break
+	}
+
+	errRead := errors.New("read failed")
+	broken := iotest.ErrReader(errRead)
+	r.Empty(slices.Collect(Lines(broken)))
+}
+
+func TestMapLines(t *testing.T) {
+	r := require.New(t)
+	ctx := context.Background()
+	var out strings.Builder
+	err := MapLines(ctx, strings.NewReader("a\nb\nc"), func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	}, &out)
+	r.NoError(err)
+	r.Equal("A\nB\nC\n", out.String())
+
+	errBad := errors.New("bad line")
+	out.Reset()
+	err = MapLines(ctx, strings.NewReader("a\nbad\nc"), func(s string) (string, error) {
+		if s == "bad" {
+			return "", errBad
+		}
+		return s, nil
+	}, &out)
+	r.ErrorIs(err, errBad)
+	r.Equal("a\n", out.String())
+
+	errRead := errors.New("read failed")
+	err = MapLines(ctx, iotest.ErrReader(errRead), func(s string) (string, error) {
+		return s, nil
+	}, &out)
+	r.ErrorIs(err, errRead)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = MapLines(cancelled, strings.NewReader("a"), func(s string) (string, error) {
+		return s, nil
+	}, &out)
+	r.ErrorIs(err, context.Canceled)
+}
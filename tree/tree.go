@@ -0,0 +1,130 @@
+// Package tree provides a generic rose tree, where every node holds a value and
+// any number of children.
+//
+// Rose trees are a natural fit for org charts, file trees, ASTs and other
+// hierarchical data that the slice combinators can't express.
+package tree
+
+import "iter"
+
+// Tree is a node with a value and zero or more child trees
+type Tree[T any] struct {
+	Value    T
+	Children []Tree[T]
+}
+
+// New creates a Tree with val at the root and the given children
+func New[T any](val T, children ...Tree[T]) Tree[T] {
+	return Tree[T]{Value: val, Children: children}
+}
+
+// Size returns the number of nodes in t, including t itself
+func (t Tree[T]) Size() int {
+	return Fold(t, func(_ T, sizes []int) int {
+		total := 1
+		for _, s := range sizes {
+			total += s
+		}
+		return total
+	})
+}
+
+// DepthFirst returns an iterator over every value in t in depth-first,
+// pre-order. That is, each node is visited before its children, and children
+// are visited in order
+func (t Tree[T]) DepthFirst() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		depthFirst(t, yield)
+	}
+}
+
+func depthFirst[T any](t Tree[T], yield func(T) bool) bool {
+	if !yield(t.Value) {
+		return false
+	}
+	for _, c := range t.Children {
+		if !depthFirst(c, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// BreadthFirst returns an iterator over every value in t in breadth-first
+// order. That is, t's value first, then the values of its children, then the
+// values of its grandchildren, and so on
+func (t Tree[T]) BreadthFirst() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		queue := []*Tree[T]{&t}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if !yield(n.Value) {
+				return
+			}
+			for i := range n.Children {
+				queue = append(queue, &n.Children[i])
+			}
+		}
+	}
+}
+
+// Map creates a new Tree with the same shape as t, where every value is the
+// result of calling fn on the value at the same position in t.
+//
+// Example usage:
+//
+//	t := New(1, New(2), New(3))
+//	strs := Map(t, strconv.Itoa)
+//	// strs will be New("1", New("2"), New("3"))
+func Map[T, U any](t Tree[T], fn func(T) U) Tree[U] {
+	ret := Tree[U]{Value: fn(t.Value)}
+	if len(t.Children) > 0 {
+		ret.Children = make([]Tree[U], len(t.Children))
+		for i, c := range t.Children {
+			ret.Children[i] = Map(c, fn)
+		}
+	}
+	return ret
+}
+
+// Fold collapses t into a single value, bottom-up. fn is called for every
+// node with the node's value and the results of folding each of its
+// children, in order. Leaves are passed an empty slice.
+//
+// Example usage:
+//
+//	t := New(1, New(2), New(3, New(4)))
+//	sum := Fold(t, func(val int, childSums []int) int {
+//		for _, s := range childSums {
+//			val += s
+//		}
+//		return val
+//	})
+//	// sum will be 10
+func Fold[T, A any](t Tree[T], fn func(T, []A) A) A {
+	results := make([]A, len(t.Children))
+	for i, c := range t.Children {
+		results[i] = Fold(c, fn)
+	}
+	return fn(t.Value, results)
+}
+
+// Filter creates a new Tree that contains only the nodes of t for which pred
+// returns true. If pred returns false for a node, that node's entire subtree
+// is pruned, even if pred would return true for some of its descendants.
+//
+// Returns false if pred returns false for the root of t, since there's no
+// tree left to return.
+func Filter[T any](t Tree[T], pred func(T) bool) (Tree[T], bool) {
+	if !pred(t.Value) {
+		return Tree[T]{}, false
+	}
+	ret := Tree[T]{Value: t.Value}
+	for _, c := range t.Children {
+		if fc, ok := Filter(c, pred); ok {
+			ret.Children = append(ret.Children, fc)
+		}
+	}
+	return ret, true
+}
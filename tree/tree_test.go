@@ -0,0 +1,57 @@
+package tree
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func sample() Tree[int] {
+	return New(1,
+		New(2, New(4), New(5)),
+		New(3, New(6)),
+	)
+}
+
+func TestIterators(t *testing.T) {
+	r := require.New(t)
+	tr := sample()
+	r.Equal([]int{1, 2, 4, 5, 3, 6}, slices.Collect(tr.DepthFirst()))
+	r.Equal([]int{1, 2, 3, 4, 5, 6}, slices.Collect(tr.BreadthFirst()))
+	r.Equal(6, tr.Size())
+
+	var firstTwo []int
+	for v := range tr.BreadthFirst() {
+		firstTwo = append(firstTwo, v)
+		if len(firstTwo) == 2 {
+			break
+		}
+	}
+	r.Equal([]int{1, 2}, firstTwo)
+}
+
+func TestMapFold(t *testing.T) {
+	r := require.New(t)
+	strs := Map(sample(), strconv.Itoa)
+	r.Equal([]string{"1", "2", "4", "5", "3", "6"}, slices.Collect(strs.DepthFirst()))
+
+	sum := Fold(sample(), func(val int, childSums []int) int {
+		for _, s := range childSums {
+			val += s
+		}
+		return val
+	})
+	r.Equal(21, sum)
+}
+
+func TestFilter(t *testing.T) {
+	r := require.New(t)
+	filtered, ok := Filter(sample(), func(i int) bool { return i != 2 })
+	r.True(ok)
+	r.Equal([]int{1, 3, 6}, slices.Collect(filtered.DepthFirst()))
+
+	_, ok = Filter(sample(), func(i int) bool { return i > 1 })
+	r.False(ok)
+}
@@ -0,0 +1,199 @@
+// Package trie provides a prefix tree keyed by strings.
+//
+// A Trie answers questions that a map can't answer efficiently, like "what's
+// the longest key that is a prefix of this string?" (useful for routing
+// tables) or "give me every key that starts with this prefix" (useful for
+// autocomplete).
+package trie
+
+import (
+	"iter"
+	"sort"
+)
+
+type node[V any] struct {
+	label    byte
+	children []*node[V]
+	val      V
+	hasVal   bool
+}
+
+// child returns the index in n.children where a child with the given label
+// is or would be, along with whether it exists
+func (n *node[V]) child(label byte) (int, bool) {
+	idx := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label >= label
+	})
+	return idx, idx < len(n.children) && n.children[idx].label == label
+}
+
+// Trie is a prefix tree that maps string keys to values of type V.
+// The zero value is an empty Trie ready to use. A Trie is not safe for
+// concurrent use without external synchronization.
+type Trie[V any] struct {
+	root node[V]
+	size int
+}
+
+// New returns a new, empty Trie
+func New[V any]() *Trie[V] {
+	return &Trie[V]{}
+}
+
+// Len returns the number of keys stored in t
+func (t *Trie[V]) Len() int {
+	return t.size
+}
+
+// Insert stores val at key, replacing any value that was already there.
+// Returns true if a previous value was replaced
+func (t *Trie[V]) Insert(key string, val V) bool {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		idx, ok := n.child(key[i])
+		if !ok {
+			c := &node[V]{label: key[i]}
+			n.children = append(n.children, nil)
+			copy(n.children[idx+1:], n.children[idx:])
+			n.children[idx] = c
+		}
+		n = n.children[idx]
+	}
+	replaced := n.hasVal
+	n.val, n.hasVal = val, true
+	if !replaced {
+		t.size++
+	}
+	return replaced
+}
+
+// Get returns the value stored at key and true, or the zero value of V and
+// false if key isn't in t
+func (t *Trie[V]) Get(key string) (V, bool) {
+	n := t.find(key)
+	if n == nil || !n.hasVal {
+		var zero V
+		return zero, false
+	}
+	return n.val, true
+}
+
+// Delete removes key from t, pruning any nodes that no longer lead to a
+// value. Returns true if key was present
+func (t *Trie[V]) Delete(key string) bool {
+	path := make([]*node[V], 0, len(key)+1)
+	n := &t.root
+	path = append(path, n)
+	for i := 0; i < len(key); i++ {
+		idx, ok := n.child(key[i])
+		if !ok {
+			return false
+		}
+		n = n.children[idx]
+		path = append(path, n)
+	}
+	if !n.hasVal {
+		return false
+	}
+	var zero V
+	n.val, n.hasVal = zero, false
+	t.size--
+
+	// walk back up, removing nodes that have neither a value nor children
+	for i := len(path) - 1; i > 0; i-- {
+		cur := path[i]
+		if cur.hasVal || len(cur.children) > 0 {
+			break
+		}
+		parent := path[i-1]
+		idx, _ := parent.child(cur.label)
+		parent.children = append(parent.children[:idx], parent.children[idx+1:]...)
+	}
+	return true
+}
+
+// LongestPrefix finds the longest key in t that is a prefix of s. It returns
+// that key, its value and true, or empty values and false if no key in t is
+// a prefix of s.
+//
+// Example usage:
+//
+//	t := New[string]()
+//	t.Insert("/api", "api")
+//	t.Insert("/api/users", "users")
+//	prefix, val, ok := t.LongestPrefix("/api/users/123")
+//	// prefix will be "/api/users", val will be "users" and ok will be true
+func (t *Trie[V]) LongestPrefix(s string) (string, V, bool) {
+	var (
+		bestLen = -1
+		bestVal V
+	)
+	n := &t.root
+	if n.hasVal {
+		bestLen, bestVal = 0, n.val
+	}
+	for i := 0; i < len(s); i++ {
+		idx, ok := n.child(s[i])
+		if !ok {
+			break
+		}
+		n = n.children[idx]
+		if n.hasVal {
+			bestLen, bestVal = i+1, n.val
+		}
+	}
+	if bestLen < 0 {
+		var zero V
+		return "", zero, false
+	}
+	return s[:bestLen], bestVal, true
+}
+
+// WalkPrefix returns an iterator over every key in t that starts with
+// prefix, along with its value. Keys are yielded in lexicographic (byte-wise)
+// order. Pass the empty string to iterate over every key in t.
+//
+// Example usage:
+//
+//	for key, val := range t.WalkPrefix("ap") {
+//		fmt.Println(key, val)
+//	}
+//
+// t must not be modified while the iterator is in use.
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		n := t.find(prefix)
+		if n == nil {
+			return
+		}
+		buf := []byte(prefix)
+		walk(n, buf, yield)
+	}
+}
+
+// walk does a pre-order traversal of n, which guarantees keys are visited
+// in lexicographic order since children are sorted by label. Returns false
+// if yield asked to stop
+func walk[V any](n *node[V], buf []byte, yield func(string, V) bool) bool {
+	if n.hasVal && !yield(string(buf), n.val) {
+		return false
+	}
+	for _, c := range n.children {
+		if !walk(c, append(buf, c.label), yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (t *Trie[V]) find(key string) *node[V] {
+	n := &t.root
+	for i := 0; i < len(key); i++ {
+		idx, ok := n.child(key[i])
+		if !ok {
+			return nil
+		}
+		n = n.children[idx]
+	}
+	return n
+}
@@ -0,0 +1,77 @@
+package trie
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertGetDelete(t *testing.T) {
+	r := require.New(t)
+	tr := New[int]()
+	r.False(tr.Insert("foo", 1))
+	r.False(tr.Insert("foobar", 2))
+	r.True(tr.Insert("foo", 3))
+	r.Equal(2, tr.Len())
+
+	v, ok := tr.Get("foo")
+	r.True(ok)
+	r.Equal(3, v)
+	_, ok = tr.Get("fo")
+	r.False(ok)
+
+	r.False(tr.Delete("fo"))
+	r.True(tr.Delete("foobar"))
+	r.Equal(1, tr.Len())
+	_, ok = tr.Get("foobar")
+	r.False(ok)
+	// the "bar" branch should have been pruned
+	r.Empty(tr.find("foo").children)
+}
+
+func TestLongestPrefix(t *testing.T) {
+	r := require.New(t)
+	var tr Trie[string]
+	_, _, ok := tr.LongestPrefix("/api")
+	r.False(ok)
+
+	tr.Insert("/", "root")
+	tr.Insert("/api", "api")
+	tr.Insert("/api/users", "users")
+
+	prefix, val, ok := tr.LongestPrefix("/api/users/123")
+	r.True(ok)
+	r.Equal("/api/users", prefix)
+	r.Equal("users", val)
+
+	prefix, val, ok = tr.LongestPrefix("/apple")
+	r.True(ok)
+	r.Equal("/", prefix)
+	r.Equal("root", val)
+}
+
+func TestWalkPrefix(t *testing.T) {
+	r := require.New(t)
+	tr := New[int]()
+	for i, k := range []string{"banana", "app", "apple", "apply", "ape"} {
+		tr.Insert(k, i)
+	}
+	var keys []string
+	for k := range tr.WalkPrefix("ap") {
+		keys = append(keys, k)
+	}
+	r.Equal([]string{"ape", "app", "apple", "apply"}, keys)
+
+	keys = nil
+	for k := range tr.WalkPrefix("") {
+		keys = append(keys, k)
+		if len(keys) == 2 {
+			break
+		}
+	}
+	r.Equal([]string{"ape", "app"}, keys)
+
+	for range tr.WalkPrefix("zzz") {
+		r.Fail("no keys should match")
+	}
+}
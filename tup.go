@@ -3,14 +3,14 @@ package core
 // Tuple is a structure that holds exactly two values.
 // You can read the values, but not change them
 type Tuple[T any, U any] struct {
-	first T
+	first  T
 	second U
 }
 
 // Tup creates a new tuple with the first parameter being the first
 // element in the tuple and the second being the second
 func Tup[T, U any](first T, second U) Tuple[T, U] {
-	return Tuple{first: first, second: second}
+	return Tuple[T, U]{first: first, second: second}
 }
 
 // First gets the first element of the tuple